@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/apl"
 	"github.com/wowsims/cata/sim/core/proto"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -21,10 +22,29 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
-	simCmd.MarkFlagRequired("infile")
+	simCmd.Flags().StringVar(&rotationfile, "rotation", "", "location of an APL text file, overrides the rotation of the first player in the input")
+	simCmd.Flags().StringVar(&link, "link", "", "UI export link to sim, used instead of the input file")
+	addFormatFlag(simCmd, formatJSON)
 }
 
-func simMain(cmd *cobra.Command, args []string) {
+// Exits unless the input was given with --infile or --link.
+func requireInputFlag(cmd *cobra.Command) {
+	if link == "" && !cmd.Flags().Changed("infile") {
+		log.Fatalf("no input given, use --infile or --link")
+	}
+}
+
+// Returns the RaidSimRequest from the --link or --infile flags.
+func loadRaidSimRequest(cmd *cobra.Command) *proto.RaidSimRequest {
+	requireInputFlag(cmd)
+	if link != "" {
+		input, err := raidSimRequestFromLink(link)
+		if err != nil {
+			log.Fatalf("failed to load link: %s", err)
+		}
+		return input
+	}
+
 	data, err := os.ReadFile(infile)
 	if err != nil {
 		log.Fatalf("failed to load input json file %q: %v", infile, err)
@@ -35,8 +55,16 @@ func simMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	return input
+}
+
+func simMain(cmd *cobra.Command, args []string) {
+	format := outputFormat(cmd)
+	input := loadRaidSimRequest(cmd)
+	if rotationfile != "" {
+		loadRotationFile(input, rotationfile)
+	}
 
-	var output []byte
 	reporter := make(chan *proto.ProgressMetrics, 10)
 	core.RunRaidSimAsync(input, reporter)
 
@@ -51,20 +79,52 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
-	if err != nil {
-		log.Fatalf("failed to marshal final results: %s", err)
+	if format == formatJSON {
+		writeOutput(formatProtoJSON(finalResult))
+		return
+	}
+	if finalResult.ErrorResult != "" {
+		log.Fatalf("sim failed: %s", finalResult.ErrorResult)
 	}
+	writeOutput(simResultTable(finalResult).format(format))
+}
 
-	if outfile == "" {
-		fmt.Print(string(output))
-	} else {
-		err = os.WriteFile(outfile, output, 0666)
-		if err != nil {
-			log.Fatalf("failed to write output file:: %s", err)
+// Returns the main metrics of each player, and the whole raid.
+func simResultTable(result *proto.RaidSimResult) *outputTable {
+	table := newOutputTable("Name", "DPS", "DPS Stdev", "HPS", "TPS", "DTPS")
+	addRow := func(name string, metrics *proto.UnitMetrics) {
+		table.addRow(name, metrics.GetDps().GetAvg(), metrics.GetDps().GetStdev(), metrics.GetHps().GetAvg(), metrics.GetThreat().GetAvg(), metrics.GetDtps().GetAvg())
+	}
+	for _, party := range result.GetRaidMetrics().GetParties() {
+		for _, player := range party.GetPlayers() {
+			addRow(player.Name, player)
 		}
-		if verbose {
-			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+	}
+
+	raidMetrics := result.GetRaidMetrics()
+	table.addRow("Raid", raidMetrics.GetDps().GetAvg(), raidMetrics.GetDps().GetStdev(), raidMetrics.GetHps().GetAvg(), "", "")
+	return table
+}
+
+// Replaces the rotation of the first player in the raid with the APL text
+// rotation in filename.
+func loadRotationFile(input *proto.RaidSimRequest, filename string) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("failed to load rotation file %q: %v", filename, err)
+	}
+	rotation, err := apl.Parse(string(data))
+	if err != nil {
+		log.Fatalf("failed to parse rotation file %q: %s", filename, err)
+	}
+
+	for _, party := range input.GetRaid().GetParties() {
+		for _, player := range party.GetPlayers() {
+			if player.GetClass() != proto.Class_ClassUnknown {
+				player.Rotation = rotation
+				return
+			}
 		}
 	}
+	log.Fatalf("no player found in input to apply rotation %q to", filename)
 }
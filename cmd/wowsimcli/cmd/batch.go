@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	batchDir       string
+	batchBaseline  string
+	batchOutdir    string
+	batchThreshold float64
+)
+
+// Changes are significant if they are outside the 95% confidence interval of
+// the difference between the means.
+const batchSignificanceZ = 1.96
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "sim a directory of inputs and compare the results to a baseline",
+	Long:  "sim every RaidSimRequest json file in a directory, write each result to the output directory, and report the DPS, HPS and TPS changes from the results for the same files in the baseline directory. Exits with status 1 if any sim fails, or any metric drops significantly by more than the threshold.",
+	Run:   batchMain,
+}
+
+func init() {
+	batchCmd.Flags().StringVar(&batchDir, "dir", "", "directory of input files (RaidSimRequest in protojson format)")
+	batchCmd.Flags().StringVar(&batchBaseline, "baseline", "", "directory of results to compare against, e.g. the output directory of an earlier run")
+	batchCmd.Flags().StringVar(&batchOutdir, "outdir", "batch_results", "directory to write the result of each input to")
+	batchCmd.Flags().StringVar(&outfile, "outfile", "", "location of report file, defaults to stdout")
+	batchCmd.Flags().Float64Var(&batchThreshold, "threshold", 1, "percentage drop from the baseline above which a significant change is a regression")
+	batchCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	batchCmd.Flags().String("format", formatMarkdown, "report format: markdown or csv")
+	batchCmd.MarkFlagRequired("dir")
+}
+
+// Changes between the baseline and current result of one metric.
+type batchChange struct {
+	baseline *proto.DistributionMetrics
+	current  *proto.DistributionMetrics
+}
+
+func (c batchChange) delta() float64 {
+	return c.current.GetAvg() - c.baseline.GetAvg()
+}
+
+func (c batchChange) percent() float64 {
+	if c.baseline.GetAvg() == 0 {
+		return 0
+	}
+	return c.delta() / c.baseline.GetAvg() * 100
+}
+
+func (c batchChange) significant() bool {
+	variance := func(metrics *proto.DistributionMetrics) float64 {
+		n := metrics.GetAggregatorData().GetN()
+		if n == 0 {
+			return 0
+		}
+		return metrics.Stdev * metrics.Stdev / float64(n)
+	}
+	stderr := math.Sqrt(variance(c.baseline) + variance(c.current))
+	return math.Abs(c.delta()) > batchSignificanceZ*stderr
+}
+
+func (c batchChange) regressed(thresholdPercent float64) bool {
+	return c.significant() && c.percent() < -thresholdPercent
+}
+
+func batchMain(cmd *cobra.Command, args []string) {
+	format, _ := cmd.Flags().GetString("format")
+	if format != formatMarkdown && format != formatCSV {
+		log.Fatalf("unknown report format %q, must be markdown or csv", format)
+	}
+
+	entries, err := os.ReadDir(batchDir)
+	if err != nil {
+		log.Fatalf("failed to read input directory %q: %v", batchDir, err)
+	}
+	if err := os.MkdirAll(batchOutdir, 0755); err != nil {
+		log.Fatalf("failed to create output directory %q: %v", batchOutdir, err)
+	}
+
+	report := newOutputTable("File", "Unit", "Metric", "Baseline", "Current", "Change %", "Significant", "Status")
+	var numRegressions, numFailures int
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		name := entry.Name()
+		if verbose {
+			fmt.Printf("Simming %s\n", name)
+		}
+
+		result, err := runBatchInput(filepath.Join(batchDir, name))
+		if err != nil {
+			log.Printf("%s failed: %s", name, err)
+			report.addRow(name, "", "", "", "", "", "", "failed")
+			numFailures++
+			continue
+		}
+		regressions, err := compareAndWriteBatchResult(report, name, result)
+		if err != nil {
+			log.Fatal(err)
+		}
+		numRegressions += regressions
+	}
+
+	writeOutput(report.format(format))
+
+	if numFailures > 0 || numRegressions > 0 {
+		log.Printf("%d failed sims, %d regressions", numFailures, numRegressions)
+		os.Exit(1)
+	}
+}
+
+func runBatchInput(filename string) (*proto.RaidSimResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	input := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return nil, err
+	}
+	if input.Raid == nil || input.SimOptions == nil {
+		return nil, errors.New("input is not a RaidSimRequest")
+	}
+
+	result := core.RunConcurrentRaidSimSync(input)
+	if result.ErrorResult != "" {
+		return nil, errors.New(result.ErrorResult)
+	}
+	return result, nil
+}
+
+// Adds the report rows for the result of an input, then writes it to the output
+// directory, and returns the number of regressions. The baseline is loaded
+// before writing, since the output directory may be the baseline directory.
+func compareAndWriteBatchResult(report *outputTable, name string, result *proto.RaidSimResult) (int, error) {
+	var baseline *proto.RaidSimResult
+	if batchBaseline != "" {
+		var err error
+		baseline, err = loadBatchBaseline(filepath.Join(batchBaseline, name))
+		if err != nil {
+			return 0, fmt.Errorf("failed to load baseline for %s: %w", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(batchOutdir, name), formatProtoJSON(result), 0666); err != nil {
+		return 0, fmt.Errorf("failed to write result for %s: %w", name, err)
+	}
+	return addBatchRows(report, name, baseline, result), nil
+}
+
+// Returns the baseline result for an input, or nil if there isn't one.
+func loadBatchBaseline(filename string) (*proto.RaidSimResult, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	baseline := &proto.RaidSimResult{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, baseline); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+// Adds a row for each metric of each player and the raid, and returns the
+// number of regressions. Players are matched to the baseline by their
+// position in the raid.
+func addBatchRows(report *outputTable, file string, baseline *proto.RaidSimResult, current *proto.RaidSimResult) int {
+	numRegressions := 0
+	addRow := func(unit string, metric string, baselineMetrics *proto.DistributionMetrics, currentMetrics *proto.DistributionMetrics) {
+		// Also covers players added since the baseline.
+		if baselineMetrics == nil {
+			report.addRow(file, unit, metric, "", currentMetrics.GetAvg(), "", "", "new")
+			return
+		}
+
+		change := batchChange{baseline: baselineMetrics, current: currentMetrics}
+		status := "unchanged"
+		if change.regressed(batchThreshold) {
+			status = "regressed"
+			numRegressions++
+		} else if change.significant() {
+			status = core.Ternary(change.delta() > 0, "improved", "worse")
+		}
+		report.addRow(file, unit, metric, baselineMetrics.GetAvg(), currentMetrics.GetAvg(), change.percent(), core.Ternary(change.significant(), "yes", "no"), status)
+	}
+
+	baselineParties := baseline.GetRaidMetrics().GetParties()
+	for partyIdx, party := range current.GetRaidMetrics().GetParties() {
+		for playerIdx, player := range party.GetPlayers() {
+			var baselinePlayer *proto.UnitMetrics
+			if partyIdx < len(baselineParties) && playerIdx < len(baselineParties[partyIdx].Players) {
+				baselinePlayer = baselineParties[partyIdx].Players[playerIdx]
+			}
+
+			unit := strings.TrimSpace(player.Name)
+			if unit == "" {
+				unit = fmt.Sprintf("Player %d", partyIdx*5+playerIdx+1)
+			}
+			for _, metric := range []struct {
+				name string
+				get  func(*proto.UnitMetrics) *proto.DistributionMetrics
+			}{
+				{"DPS", (*proto.UnitMetrics).GetDps},
+				{"HPS", (*proto.UnitMetrics).GetHps},
+				{"TPS", (*proto.UnitMetrics).GetThreat},
+			} {
+				if metric.get(player).GetAvg() == 0 && metric.get(baselinePlayer).GetAvg() == 0 {
+					continue
+				}
+				addRow(unit, metric.name, metric.get(baselinePlayer), metric.get(player))
+			}
+		}
+	}
+
+	raid, baselineRaid := current.GetRaidMetrics(), baseline.GetRaidMetrics()
+	if raid.GetDps().GetAvg() != 0 || baselineRaid.GetDps().GetAvg() != 0 {
+		addRow("Raid", "DPS", baselineRaid.GetDps(), raid.GetDps())
+	}
+	if raid.GetHps().GetAvg() != 0 || baselineRaid.GetHps().GetAvg() != 0 {
+		addRow("Raid", "HPS", baselineRaid.GetHps(), raid.GetHps())
+	}
+	return numRegressions
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func distribution(avg float64, stdev float64, n int32) *proto.DistributionMetrics {
+	return &proto.DistributionMetrics{Avg: avg, Stdev: stdev, AggregatorData: &proto.AggregatorData{N: n}}
+}
+
+func TestBatchChange(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		change      batchChange
+		significant bool
+		regressed   bool
+	}{
+		{"within noise", batchChange{distribution(1000, 100, 100), distribution(980, 100, 100)}, false, false},
+		{"improved", batchChange{distribution(1000, 100, 100), distribution(1050, 100, 100)}, true, false},
+		{"regressed", batchChange{distribution(1000, 100, 100), distribution(950, 100, 100)}, true, true},
+		{"below threshold", batchChange{distribution(1000, 100, 1000000), distribution(995, 100, 1000000)}, true, false},
+		{"no iterations", batchChange{distribution(1000, 100, 0), distribution(999, 100, 0)}, true, false},
+		{"unchanged without iterations", batchChange{distribution(1000, 0, 0), distribution(1000, 0, 0)}, false, false},
+	} {
+		if significant := tc.change.significant(); significant != tc.significant {
+			t.Fatalf("%s: expected significant %t, got %t", tc.name, tc.significant, significant)
+		}
+		if regressed := tc.change.regressed(1); regressed != tc.regressed {
+			t.Fatalf("%s: expected regressed %t, got %t", tc.name, tc.regressed, regressed)
+		}
+	}
+}
+
+func batchResult(raidDps *proto.DistributionMetrics, players ...*proto.UnitMetrics) *proto.RaidSimResult {
+	return &proto.RaidSimResult{
+		RaidMetrics: &proto.RaidMetrics{
+			Dps:     raidDps,
+			Parties: []*proto.PartyMetrics{{Players: players}},
+		},
+	}
+}
+
+func TestAddBatchRows(t *testing.T) {
+	baseline := batchResult(distribution(1000, 100, 100), &proto.UnitMetrics{Name: "Mage", Dps: distribution(1000, 100, 100)})
+	current := batchResult(distribution(1400, 100, 100),
+		&proto.UnitMetrics{Name: "Mage", Dps: distribution(900, 100, 100)},
+		&proto.UnitMetrics{Dps: distribution(500, 100, 100)},
+	)
+
+	for _, tc := range []struct {
+		name           string
+		baseline       *proto.RaidSimResult
+		expected       [][]any
+		numRegressions int
+	}{
+		{
+			name:     "baseline",
+			baseline: baseline,
+			expected: [][]any{
+				{"in.json", "Mage", "DPS", 1000.0, 900.0, -10.0, "yes", "regressed"},
+				{"in.json", "Player 2", "DPS", "", 500.0, "", "", "new"},
+				{"in.json", "Raid", "DPS", 1000.0, 1400.0, 40.0, "yes", "improved"},
+			},
+			numRegressions: 1,
+		},
+		{
+			name: "no baseline",
+			expected: [][]any{
+				{"in.json", "Mage", "DPS", "", 900.0, "", "", "new"},
+				{"in.json", "Player 2", "DPS", "", 500.0, "", "", "new"},
+				{"in.json", "Raid", "DPS", "", 1400.0, "", "", "new"},
+			},
+		},
+	} {
+		report := newOutputTable()
+		if numRegressions := addBatchRows(report, "in.json", tc.baseline, current); numRegressions != tc.numRegressions {
+			t.Fatalf("%s: expected %d regressions, got %d", tc.name, tc.numRegressions, numRegressions)
+		}
+		if !slices.EqualFunc(report.rows, tc.expected, slices.Equal[[]any]) {
+			t.Fatalf("%s: unexpected rows:\n%v\nexpected:\n%v", tc.name, report.rows, tc.expected)
+		}
+	}
+}
+
+func TestBatchOutdirIsBaseline(t *testing.T) {
+	dir := t.TempDir()
+	baseline := batchResult(distribution(1000, 100, 100))
+	if err := os.WriteFile(filepath.Join(dir, "in.json"), formatProtoJSON(baseline), 0666); err != nil {
+		t.Fatal(err)
+	}
+	batchBaseline, batchOutdir = dir, dir
+	t.Cleanup(func() { batchBaseline, batchOutdir = "", "batch_results" })
+
+	report := newOutputTable()
+	current := batchResult(distribution(900, 100, 100))
+	if numRegressions, err := compareAndWriteBatchResult(report, "in.json", current); err != nil || numRegressions != 1 {
+		t.Fatalf("Expected 1 regression against the old baseline, got %d (err: %v)", numRegressions, err)
+	}
+	expected := [][]any{{"in.json", "Raid", "DPS", 1000.0, 900.0, -10.0, "yes", "regressed"}}
+	if !slices.EqualFunc(report.rows, expected, slices.Equal[[]any]) {
+		t.Fatalf("Unexpected rows:\n%v\nexpected:\n%v", report.rows, expected)
+	}
+
+	if written, err := loadBatchBaseline(filepath.Join(dir, "in.json")); err != nil || written.GetRaidMetrics().GetDps().GetAvg() != 900 {
+		t.Fatalf("Expected the new result to replace the baseline, got %v (err: %v)", written, err)
+	}
+}
@@ -16,10 +16,12 @@ import (
 )
 
 var (
-	infile      string
-	replacefile string
-	outfile     string
-	verbose     bool
+	infile       string
+	replacefile  string
+	outfile      string
+	verbose      bool
+	rotationfile string
+	link         string
 )
 
 var bulkCmd = &cobra.Command{
@@ -34,6 +36,7 @@ func init() {
 	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file. Writes a CSV result of the items replaced instead of JSON")
 	bulkCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
 	bulkCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	bulkCmd.Flags().StringVar(&rotationfile, "rotation", "", "location of an APL text file, overrides the rotation of the first player in the input")
 	bulkCmd.MarkFlagRequired("infile")
 	bulkCmd.MarkFlagRequired("replacefile")
 }
@@ -49,6 +52,9 @@ func bulkSimMain(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
+	if rotationfile != "" {
+		loadRotationFile(input, rotationfile)
+	}
 
 	output := BulkSim(input, replacefile, verbose)
 
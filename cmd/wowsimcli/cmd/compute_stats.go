@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "compute the character stats of each player",
+	Long:  "compute the character stats of each player, broken down by source",
+	Run:   statsMain,
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	statsCmd.Flags().StringVar(&link, "link", "", "UI export link to compute stats for, used instead of the input file")
+	statsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	addFormatFlag(statsCmd, formatTable)
+}
+
+func statsMain(cmd *cobra.Command, args []string) {
+	format := outputFormat(cmd)
+	input := loadRaidSimRequest(cmd)
+
+	result := core.ComputeStats(&proto.ComputeStatsRequest{
+		Raid:      input.Raid,
+		Encounter: input.Encounter,
+	})
+	if result.ErrorResult != "" {
+		log.Fatalf("failed to compute stats: %s", result.ErrorResult)
+	}
+
+	if format == formatJSON {
+		writeOutput(formatProtoJSON(result))
+		return
+	}
+	writeOutput(computeStatsTable(input.Raid, result.RaidStats).format(format))
+}
+
+// Returns a row for each stat which any player has, with the stat's value from
+// each source.
+func computeStatsTable(raid *proto.Raid, raidStats *proto.RaidStats) *outputTable {
+	table := newOutputTable("Player", "Stat", "Base", "Gear", "Talents", "Buffs", "Consumes", "Final")
+	for partyIdx, partyStats := range raidStats.GetParties() {
+		for playerIdx, playerStats := range partyStats.GetPlayers() {
+			if playerStats == nil {
+				continue
+			}
+			name := raid.Parties[partyIdx].Players[playerIdx].GetName()
+
+			for stat := stats.Stat(0); stat < stats.Len; stat++ {
+				final := unitStatValue(playerStats.FinalStats, stat)
+				if final == 0 {
+					continue
+				}
+				table.addRow(name, statName(proto.Stat(stat)),
+					unitStatValue(playerStats.BaseStats, stat),
+					unitStatValue(playerStats.GearStats, stat),
+					unitStatValue(playerStats.TalentsStats, stat),
+					unitStatValue(playerStats.BuffsStats, stat),
+					unitStatValue(playerStats.ConsumesStats, stat),
+					final)
+			}
+		}
+	}
+	return table
+}
+
+func unitStatValue(unitStats *proto.UnitStats, stat stats.Stat) float64 {
+	if int(stat) >= len(unitStats.GetStats()) {
+		return 0
+	}
+	return unitStats.Stats[stat]
+}
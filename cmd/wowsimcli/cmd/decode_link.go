@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"google.golang.org/protobuf/encoding/protojson"
 	goproto "google.golang.org/protobuf/proto"
@@ -27,28 +28,39 @@ var decodeLinkCmd = &cobra.Command{
 var errInvalidLink = errors.New("invalid wowsims export link")
 
 func decodeLink(link string) error {
+	settings, err := decodeLinkSettings(link)
+	if err != nil {
+		return err
+	}
+	fmt.Println(protojson.Format(settings))
+	return nil
+}
+
+// Returns the settings in a UI export link, either a *proto.RaidSimSettings or
+// a *proto.IndividualSimSettings.
+func decodeLinkSettings(link string) (goproto.Message, error) {
 	parts := strings.Split(link, "#")
 	switch {
 	case len(parts) != 2:
-		return errInvalidLink
+		return nil, errInvalidLink
 	case parts[1] == "":
-		return errInvalidLink
+		return nil, errInvalidLink
 	}
 
 	raw, err := base64.StdEncoding.DecodeString(parts[1])
 	if err != nil {
-		return fmt.Errorf("cannot decode proto from link: %w", err)
+		return nil, fmt.Errorf("cannot decode proto from link: %w", err)
 	}
 
 	r, err := zlib.NewReader(bytes.NewReader(raw))
 	if err != nil {
-		return fmt.Errorf("cannot create zlib reader: %w", err)
+		return nil, fmt.Errorf("cannot create zlib reader: %w", err)
 	}
 	defer r.Close()
 
 	var buf bytes.Buffer
 	if _, err := buf.ReadFrom(r); err != nil {
-		return fmt.Errorf("reading zlib data failed: %w", err)
+		return nil, fmt.Errorf("reading zlib data failed: %w", err)
 	}
 
 	var settings goproto.Message
@@ -59,9 +71,45 @@ func decodeLink(link string) error {
 	}
 
 	if err := goproto.Unmarshal(buf.Bytes(), settings); err != nil {
-		return fmt.Errorf("cannot unmarshal raw proto: %w", err)
+		return nil, fmt.Errorf("cannot unmarshal raw proto: %w", err)
 	}
+	return settings, nil
+}
 
-	fmt.Println(protojson.Format(goproto.Message(settings)))
-	return nil
+// Iterations used for links which don't set them, same as the UI default.
+const defaultLinkIterations = 3000
+
+// Returns the request the UI would sim for the settings in an export link.
+func raidSimRequestFromLink(link string) (*proto.RaidSimRequest, error) {
+	settings, err := decodeLinkSettings(link)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &proto.RaidSimRequest{}
+	var simSettings *proto.SimSettings
+	switch settings := settings.(type) {
+	case *proto.IndividualSimSettings:
+		if settings.Player == nil {
+			return nil, fmt.Errorf("%w: no player", errInvalidLink)
+		}
+		request.Raid = core.SinglePlayerRaidProto(settings.Player, settings.PartyBuffs, settings.RaidBuffs, settings.Debuffs)
+		request.Raid.Tanks = settings.Tanks
+		request.Raid.TargetDummies = settings.TargetDummies
+		request.Encounter = settings.Encounter
+		simSettings = settings.Settings
+	case *proto.RaidSimSettings:
+		request.Raid = settings.Raid
+		request.Encounter = settings.Encounter
+		simSettings = settings.Settings
+	}
+
+	request.SimOptions = &proto.SimOptions{
+		Iterations: simSettings.GetIterations(),
+		RandomSeed: simSettings.GetFixedRngSeed(),
+	}
+	if request.SimOptions.Iterations == 0 {
+		request.SimOptions.Iterations = defaultLinkIterations
+	}
+	return request, nil
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// Encodes settings the same way as the UI's export links.
+func makeLink(t *testing.T, path string, settings goproto.Message) string {
+	data, err := goproto.Marshal(settings)
+	if err != nil {
+		t.Fatalf("Failed to marshal settings: %s", err)
+	}
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return "https://wowsims.github.io/cata/" + path + "#" + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestRaidSimRequestFromLink(t *testing.T) {
+	player := &proto.Player{Name: "Player", Class: proto.Class_ClassMage}
+	link := makeLink(t, "mage/fire/", &proto.IndividualSimSettings{
+		Player:        player,
+		RaidBuffs:     &proto.RaidBuffs{ArcaneBrilliance: true},
+		Tanks:         []*proto.UnitReference{{Type: proto.UnitReference_Player}},
+		TargetDummies: 2,
+		Encounter:     &proto.Encounter{Duration: 120},
+		Settings:      &proto.SimSettings{FixedRngSeed: 5},
+	})
+
+	request, err := raidSimRequestFromLink(link)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if got := request.Raid.Parties[0].Players[0]; !goproto.Equal(got, player) {
+		t.Fatalf("Expected the link's player, got %v", got)
+	}
+	if !request.Raid.Buffs.ArcaneBrilliance || len(request.Raid.Tanks) != 1 || request.Raid.TargetDummies != 2 {
+		t.Fatalf("Expected the link's raid settings, got %v", request.Raid)
+	}
+	if request.Encounter.Duration != 120 {
+		t.Fatalf("Expected the link's encounter, got %v", request.Encounter)
+	}
+	if request.SimOptions.Iterations != defaultLinkIterations || request.SimOptions.RandomSeed != 5 {
+		t.Fatalf("Expected the default iterations and the link's seed, got %v", request.SimOptions)
+	}
+}
+
+func TestRaidSimRequestFromRaidLink(t *testing.T) {
+	raid := &proto.Raid{Parties: []*proto.Party{{Players: []*proto.Player{{Name: "Player", Class: proto.Class_ClassMage}}}}}
+	link := makeLink(t, "raid/", &proto.RaidSimSettings{
+		Raid:     raid,
+		Settings: &proto.SimSettings{Iterations: 100},
+	})
+
+	request, err := raidSimRequestFromLink(link)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !goproto.Equal(request.Raid, raid) || request.SimOptions.Iterations != 100 {
+		t.Fatalf("Expected the link's raid and iterations, got %v", request)
+	}
+}
+
+func TestRaidSimRequestFromInvalidLink(t *testing.T) {
+	for _, link := range []string{
+		"https://wowsims.github.io/cata/mage/fire/",
+		"https://wowsims.github.io/cata/mage/fire/#",
+		makeLink(t, "mage/fire/", &proto.IndividualSimSettings{}),
+	} {
+		if _, err := raidSimRequestFromLink(link); !errors.Is(err, errInvalidLink) {
+			t.Fatalf("Expected an invalid link error for %q, got %v", link, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+
+	// Only used for reports.
+	formatMarkdown = "markdown"
+)
+
+func addFormatFlag(cmd *cobra.Command, defaultFormat string) {
+	cmd.Flags().String("format", defaultFormat, "output format: table, json or csv")
+}
+
+// Returns the value of the --format flag added by addFormatFlag.
+func outputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return format
+	}
+	log.Fatalf("unknown output format %q, must be table, json or csv", format)
+	return ""
+}
+
+// Rows of output, printed as an aligned text table, CSV or a Markdown table.
+type outputTable struct {
+	header []string
+	rows   [][]any
+}
+
+func newOutputTable(header ...string) *outputTable {
+	return &outputTable{header: header}
+}
+
+func (t *outputTable) addRow(values ...any) {
+	t.rows = append(t.rows, values)
+}
+
+// Formats a cell, rounding floats in tables but not in CSV.
+func (t *outputTable) formatValue(value any, format string) string {
+	switch value := value.(type) {
+	case float64:
+		if format == formatCSV {
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		}
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func (t *outputTable) format(format string) []byte {
+	var buf bytes.Buffer
+	if format == formatCSV {
+		w := csv.NewWriter(&buf)
+		w.Write(t.header)
+		for _, row := range t.rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = t.formatValue(value, format)
+			}
+			w.Write(record)
+		}
+		w.Flush()
+		return buf.Bytes()
+	}
+
+	if format == formatMarkdown {
+		writeRow := func(cells []string) {
+			for _, cell := range cells {
+				fmt.Fprintf(&buf, "| %s ", strings.ReplaceAll(cell, "|", "\\|"))
+			}
+			buf.WriteString("|\n")
+		}
+		writeRow(t.header)
+		separator := make([]string, len(t.header))
+		for i := range separator {
+			separator[i] = "---"
+		}
+		writeRow(separator)
+		for _, row := range t.rows {
+			cells := make([]string, len(row))
+			for i, value := range row {
+				cells[i] = t.formatValue(value, format)
+			}
+			writeRow(cells)
+		}
+		return buf.Bytes()
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	for _, name := range t.header {
+		fmt.Fprintf(w, "%s\t", name)
+	}
+	fmt.Fprintln(w)
+	for _, row := range t.rows {
+		for _, value := range row {
+			fmt.Fprintf(w, "%s\t", t.formatValue(value, format))
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+func formatProtoJSON(msg goproto.Message) []byte {
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		log.Fatalf("failed to marshal results: %s", err)
+	}
+	return output
+}
+
+// Writes output to the --outfile location, or stdout if it isn't set.
+func writeOutput(output []byte) {
+	if outfile == "" {
+		fmt.Print(string(output))
+		return
+	}
+	if err := os.WriteFile(outfile, output, 0666); err != nil {
+		log.Fatalf("failed to write output file:: %s", err)
+	}
+	if verbose {
+		fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+	}
+}
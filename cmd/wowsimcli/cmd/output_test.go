@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestOutputTableFormat(t *testing.T) {
+	table := newOutputTable("Name", "DPS")
+	table.addRow("a|b", 1234.5678)
+	table.addRow("Raid", 10)
+
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{formatTable, "  Name      DPS\n   a|b  1234.57\n  Raid       10\n"},
+		{formatCSV, "Name,DPS\na|b,1234.5678\nRaid,10\n"},
+		{formatMarkdown, "| Name | DPS |\n| --- | --- |\n| a\\|b | 1234.57 |\n| Raid | 10 |\n"},
+	} {
+		if output := string(table.format(tc.format)); output != tc.expected {
+			t.Fatalf("Unexpected %s output:\n%q\nexpected:\n%q", tc.format, output, tc.expected)
+		}
+	}
+}
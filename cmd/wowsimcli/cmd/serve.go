@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+var serveHost string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "host the sim API as a Connect service",
+	Long:  "host the sim API as a Connect service (proto.SimService in proto/sim_service.proto), usable from any Connect, gRPC or gRPC-Web client with the proto or json codec",
+	Run:   serveMain,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost:3334", "address to listen on")
+}
+
+func serveMain(cmd *cobra.Command, args []string) {
+	mux := http.NewServeMux()
+	path, handler := newSimServiceHandler()
+	mux.Handle(path, handler)
+
+	log.Printf("Serving %s on %s", path, serveHost)
+	// gRPC needs HTTP/2, so accept it without TLS (h2c).
+	if err := http.ListenAndServe(serveHost, h2c.NewHandler(mux, &http2.Server{})); err != nil {
+		log.Fatalf("failed to serve: %s", err)
+	}
+}
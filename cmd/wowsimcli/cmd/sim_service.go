@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/proto/protoconnect"
+)
+
+// Implements proto.SimService from proto/sim_service.proto. The handler is
+// generated by protoc-gen-connect-go, and serves the Connect, gRPC and
+// gRPC-Web protocols with the proto and json codecs.
+type simService struct{}
+
+// Limit on the size of a single request message.
+const simServiceMaxMessageSize = 64 << 20
+
+// Returns the path to mount the service on, and its handler.
+func newSimServiceHandler() (string, http.Handler) {
+	return newSimServiceHandlerFor(simService{})
+}
+
+func newSimServiceHandlerFor(service protoconnect.SimServiceHandler) (string, http.Handler) {
+	return protoconnect.NewSimServiceHandler(service,
+		connect.WithReadMaxBytes(simServiceMaxMessageSize),
+		// Bad requests shouldn't take down the server.
+		connect.WithRecover(func(_ context.Context, _ connect.Spec, _ http.Header, r any) error {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("%v", r))
+		}),
+	)
+}
+
+func (simService) ComputeStats(ctx context.Context, request *connect.Request[proto.ComputeStatsRequest]) (*connect.Response[proto.ComputeStatsResult], error) {
+	return connect.NewResponse(core.ComputeStats(request.Msg)), nil
+}
+
+func (simService) RaidSim(ctx context.Context, request *connect.Request[proto.RaidSimRequest], stream *connect.ServerStream[proto.ProgressMetrics]) error {
+	return sendSimProgress(ctx, stream, func(ctx context.Context, progress chan *proto.ProgressMetrics) {
+		core.RunRaidSimAsyncContext(ctx, request.Msg, progress)
+	})
+}
+
+func (simService) StatWeights(ctx context.Context, request *connect.Request[proto.StatWeightsRequest], stream *connect.ServerStream[proto.ProgressMetrics]) error {
+	return sendSimProgress(ctx, stream, func(ctx context.Context, progress chan *proto.ProgressMetrics) {
+		core.StatWeightsAsyncContext(ctx, request.Msg, progress)
+	})
+}
+
+func (simService) BulkSim(ctx context.Context, request *connect.Request[proto.BulkSimRequest], stream *connect.ServerStream[proto.ProgressMetrics]) error {
+	return sendSimProgress(ctx, stream, func(ctx context.Context, progress chan *proto.ProgressMetrics) {
+		core.RunBulkSimAsync(ctx, request.Msg, progress)
+	})
+}
+
+// Streams sim progress to the client. start runs the sim asynchronously,
+// sending progress on the channel until the final result. The sim is stopped
+// if the request is canceled or the client goes away.
+func sendSimProgress(ctx context.Context, stream *connect.ServerStream[proto.ProgressMetrics], start func(context.Context, chan *proto.ProgressMetrics)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := make(chan *proto.ProgressMetrics, 100)
+	start(ctx, progress)
+
+	for {
+		select {
+		case metrics, ok := <-progress:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(metrics); err != nil {
+				cancel()
+				go drainProgress(progress)
+				return err
+			}
+			if core.IsFinalProgress(metrics) {
+				return nil
+			}
+		case <-ctx.Done():
+			// Keep reading progress so the canceled sim can finish.
+			go drainProgress(progress)
+			return contextError(ctx)
+		}
+	}
+}
+
+// Returns the error for a request whose context is done.
+func contextError(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return connect.NewError(connect.CodeDeadlineExceeded, errors.New("sim did not finish before the deadline"))
+	}
+	return connect.NewError(connect.CodeCanceled, errors.New("sim was canceled"))
+}
+
+// Reads progress until the final result, discarding it.
+func drainProgress(progress chan *proto.ProgressMetrics) {
+	for metrics := range progress {
+		if core.IsFinalProgress(metrics) {
+			return
+		}
+	}
+}
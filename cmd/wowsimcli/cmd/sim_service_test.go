@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/proto/protoconnect"
+)
+
+// Starts a server for the service over HTTP/2, which gRPC needs.
+func newSimServiceTestServer(t *testing.T, service protoconnect.SimServiceHandler) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(newSimServiceHandlerFor(service))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+var simServiceTestProtocols = []struct {
+	name    string
+	options []connect.ClientOption
+}{
+	{"connect", nil},
+	{"connect json", []connect.ClientOption{connect.WithProtoJSON()}},
+	{"grpc", []connect.ClientOption{connect.WithGRPC()}},
+	{"grpc-web", []connect.ClientOption{connect.WithGRPCWeb()}},
+}
+
+func TestSimServiceUnary(t *testing.T) {
+	server := newSimServiceTestServer(t, simService{})
+
+	for _, protocol := range simServiceTestProtocols {
+		client := protoconnect.NewSimServiceClient(server.Client(), server.URL, protocol.options...)
+		response, err := client.ComputeStats(context.Background(), connect.NewRequest(&proto.ComputeStatsRequest{Raid: &proto.Raid{}}))
+		if err != nil {
+			t.Fatalf("%s: request failed: %s", protocol.name, err)
+		}
+		if response.Msg.RaidStats == nil {
+			t.Fatalf("%s: expected raid stats in the result", protocol.name)
+		}
+	}
+}
+
+func TestSimServiceUnaryErrors(t *testing.T) {
+	server := newSimServiceTestServer(t, simService{})
+
+	for _, tc := range []struct {
+		contentType string
+		body        string
+		status      int
+		code        string
+	}{
+		{"text/plain", `{}`, http.StatusUnsupportedMediaType, ""},
+		{"application/json", `{"raid": 5}`, http.StatusBadRequest, "invalid_argument"},
+	} {
+		resp, err := server.Client().Post(server.URL+protoconnect.SimServiceComputeStatsProcedure, tc.contentType, bytes.NewReader([]byte(tc.body)))
+		if err != nil {
+			t.Fatalf("Request failed: %s", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Fatalf("Expected status %d for %s, got %d", tc.status, tc.body, resp.StatusCode)
+		}
+		if tc.code == "" {
+			continue
+		}
+		var connectErr struct {
+			Code string `json:"code"`
+		}
+		if err := json.Unmarshal(body, &connectErr); err != nil || connectErr.Code != tc.code {
+			t.Fatalf("Expected error code %s, got %s", tc.code, body)
+		}
+	}
+}
+
+func TestSimServiceStream(t *testing.T) {
+	server := newSimServiceTestServer(t, simService{})
+
+	for _, protocol := range simServiceTestProtocols {
+		client := protoconnect.NewSimServiceClient(server.Client(), server.URL, protocol.options...)
+
+		// Fails immediately, without running any sims.
+		request := &proto.RaidSimRequest{SimOptions: &proto.SimOptions{Iterations: 0}}
+		stream, err := client.RaidSim(context.Background(), connect.NewRequest(request))
+		if err != nil {
+			t.Fatalf("%s: request failed: %s", protocol.name, err)
+		}
+		var messages []*proto.ProgressMetrics
+		for stream.Receive() {
+			messages = append(messages, stream.Msg())
+		}
+		if err := stream.Err(); err != nil {
+			t.Fatalf("%s: unexpected stream error: %s", protocol.name, err)
+		}
+		stream.Close()
+
+		if len(messages) == 0 || messages[len(messages)-1].FinalRaidResult == nil {
+			t.Fatalf("%s: expected the stream to end with the final result", protocol.name)
+		}
+		if errorResult := messages[len(messages)-1].FinalRaidResult.ErrorResult; errorResult != "Iterations can't be 0!" {
+			t.Fatalf("%s: unexpected sim error: %s", protocol.name, errorResult)
+		}
+	}
+}
+
+// A service whose raid sims run until canceled, reporting the context they
+// were given.
+type blockingSimService struct {
+	protoconnect.UnimplementedSimServiceHandler
+	simCtx chan context.Context
+}
+
+func (s blockingSimService) RaidSim(ctx context.Context, _ *connect.Request[proto.RaidSimRequest], stream *connect.ServerStream[proto.ProgressMetrics]) error {
+	return sendSimProgress(ctx, stream, func(ctx context.Context, progress chan *proto.ProgressMetrics) {
+		go func() {
+			<-ctx.Done()
+			progress <- &proto.ProgressMetrics{FinalRaidResult: &proto.RaidSimResult{ErrorResult: "canceled"}}
+			s.simCtx <- ctx
+		}()
+	})
+}
+
+// Runs a blocking raid sim with ctx and request headers, and returns the
+// context the sim was given and the stream error.
+func runBlockingSim(t *testing.T, ctx context.Context, header http.Header) (context.Context, error) {
+	service := blockingSimService{simCtx: make(chan context.Context, 1)}
+	server := newSimServiceTestServer(t, service)
+	client := protoconnect.NewSimServiceClient(server.Client(), server.URL)
+
+	request := connect.NewRequest(&proto.RaidSimRequest{})
+	for key, values := range header {
+		request.Header()[key] = values
+	}
+	stream, err := client.RaidSim(ctx, request)
+	if err == nil {
+		for stream.Receive() {
+		}
+		err = stream.Err()
+		stream.Close()
+	}
+
+	select {
+	case simCtx := <-service.simCtx:
+		return simCtx, err
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Expected the sim to be canceled")
+	}
+	return nil, nil
+}
+
+func TestSimServiceStreamCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		cancel()
+	}()
+
+	_, err := runBlockingSim(t, ctx, nil)
+	if connect.CodeOf(err) != connect.CodeCanceled {
+		t.Fatalf("Expected a canceled error, got %v", err)
+	}
+}
+
+func TestSimServiceStreamTimeout(t *testing.T) {
+	// Sent without a client deadline, so that the server times out first.
+	simCtx, err := runBlockingSim(t, context.Background(), http.Header{"Connect-Timeout-Ms": {"10"}})
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Fatalf("Expected a deadline_exceeded error, got %v", err)
+	}
+	if !errors.Is(simCtx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("Expected the sim context to pass its deadline, got %v", simCtx.Err())
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	weightStats       []string
+	weightPseudoStats []string
+	referenceStatName string
+	weightsMetric     string
+	weightsPlayer     int
+)
+
+var weightsCmd = &cobra.Command{
+	Use:   "weights",
+	Short: "calculate stat weights and EP values",
+	Long:  "calculate stat weights and EP values, from a StatWeightsRequest, or from a RaidSimRequest or UI export link plus a list of stats",
+	Run:   weightsMain,
+}
+
+func init() {
+	weightsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (StatWeightsRequest or RaidSimRequest in protojson format)")
+	weightsCmd.Flags().StringVar(&link, "link", "", "UI export link to calculate weights for, used instead of the input file")
+	weightsCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
+	weightsCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	weightsCmd.Flags().StringSliceVar(&weightStats, "stats", nil, "stats to weigh, e.g. SpellPower,SpellHit,Mastery. Required unless the input is a StatWeightsRequest, which it replaces the stats of")
+	weightsCmd.Flags().StringSliceVar(&weightPseudoStats, "pseudostats", nil, "pseudo stats to weigh, e.g. MainHandDps")
+	weightsCmd.Flags().StringVar(&referenceStatName, "reference", "", "stat which EP values are relative to. Defaults to the reference stat of a StatWeightsRequest, otherwise the first of --stats")
+	weightsCmd.Flags().StringVar(&weightsMetric, "metric", "dps", "metric to show in table and csv output: dps, hps, tps, dtps, tmi or pdeath")
+	weightsCmd.Flags().IntVar(&weightsPlayer, "player", 0, "raid index of the player to weigh, for RaidSimRequest and link inputs")
+	addFormatFlag(weightsCmd, formatTable)
+}
+
+func weightsMain(cmd *cobra.Command, args []string) {
+	format := outputFormat(cmd)
+	request := loadStatWeightsRequest(cmd)
+	if request.SimOptions == nil {
+		log.Fatalf("input has no sim options")
+	}
+	if len(request.StatsToWeigh) == 0 && len(request.PseudoStatsToWeigh) == 0 {
+		log.Fatalf("no stats to weigh, set them with --stats")
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 10)
+	core.StatWeightsAsync(request, reporter)
+
+	var result *proto.StatWeightsResult
+	for v := range reporter {
+		if v.FinalWeightResult != nil {
+			result = v.FinalWeightResult
+			break
+		}
+		if verbose {
+			fmt.Printf("Sim Progress: %d / %d\n", v.CompletedIterations, v.TotalIterations)
+		}
+	}
+
+	if format == formatJSON {
+		writeOutput(formatProtoJSON(result))
+		return
+	}
+	writeOutput(statWeightsTable(request, result).format(format))
+}
+
+// Returns the StatWeightsRequest from the --link or --infile flags, with the
+// stats from the command line applied.
+func loadStatWeightsRequest(cmd *cobra.Command) *proto.StatWeightsRequest {
+	requireInputFlag(cmd)
+	var request *proto.StatWeightsRequest
+	derived := true
+	if link != "" {
+		input, err := raidSimRequestFromLink(link)
+		if err != nil {
+			log.Fatalf("failed to load link: %s", err)
+		}
+		request = statWeightsRequestFromRaidSim(input, weightsPlayer)
+	} else {
+		data, err := os.ReadFile(infile)
+		if err != nil {
+			log.Fatalf("failed to load input json file %q: %v", infile, err)
+		}
+
+		// Only RaidSimRequests have a raid.
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			log.Fatalf("failed to load input json file: %s", err)
+		}
+		if _, ok := fields["raid"]; ok {
+			input := &proto.RaidSimRequest{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+				log.Fatalf("failed to load input json file: %s", err)
+			}
+			request = statWeightsRequestFromRaidSim(input, weightsPlayer)
+		} else {
+			request = &proto.StatWeightsRequest{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, request); err != nil {
+				log.Fatalf("failed to load input json file: %s", err)
+			}
+			derived = false
+		}
+	}
+
+	if len(weightStats) > 0 {
+		request.StatsToWeigh = request.StatsToWeigh[:0]
+		for _, name := range weightStats {
+			stat, err := parseStat(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			request.StatsToWeigh = append(request.StatsToWeigh, stat)
+		}
+	}
+	if len(weightPseudoStats) > 0 {
+		request.PseudoStatsToWeigh = request.PseudoStatsToWeigh[:0]
+		for _, name := range weightPseudoStats {
+			pseudoStat, err := parsePseudoStat(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			request.PseudoStatsToWeigh = append(request.PseudoStatsToWeigh, pseudoStat)
+		}
+	}
+
+	if referenceStatName != "" {
+		stat, err := parseStat(referenceStatName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		request.EpReferenceStat = stat
+	} else if derived && len(request.StatsToWeigh) > 0 {
+		request.EpReferenceStat = request.StatsToWeigh[0]
+	}
+	return request
+}
+
+// Returns the request the UI would make to weigh the player with the given
+// raid index.
+func statWeightsRequestFromRaidSim(input *proto.RaidSimRequest, playerIdx int) *proto.StatWeightsRequest {
+	parties := input.GetRaid().GetParties()
+	partyIdx, slot := playerIdx/5, playerIdx%5
+	if playerIdx < 0 || partyIdx >= len(parties) || slot >= len(parties[partyIdx].Players) || parties[partyIdx].Players[slot].GetClass() == proto.Class_ClassUnknown {
+		log.Fatalf("no player with raid index %d in input", playerIdx)
+	}
+	party := parties[partyIdx]
+
+	// The player is always the first and only player in the weights sims.
+	var tanks []*proto.UnitReference
+	for _, tank := range input.Raid.Tanks {
+		if tank.Type == proto.UnitReference_Player && int(tank.Index) == playerIdx {
+			tanks = []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 0}}
+		}
+	}
+
+	return &proto.StatWeightsRequest{
+		Player:     party.Players[slot],
+		RaidBuffs:  input.Raid.Buffs,
+		PartyBuffs: party.Buffs,
+		Debuffs:    input.Raid.Debuffs,
+		Encounter:  input.Encounter,
+		SimOptions: input.SimOptions,
+		Tanks:      tanks,
+	}
+}
+
+// Returns a row for each weighed stat, with the weights of the --metric metric.
+func statWeightsTable(request *proto.StatWeightsRequest, result *proto.StatWeightsResult) *outputTable {
+	var values *proto.StatWeightValues
+	switch weightsMetric {
+	case "dps":
+		values = result.Dps
+	case "hps":
+		values = result.Hps
+	case "tps":
+		values = result.Tps
+	case "dtps":
+		values = result.Dtps
+	case "tmi":
+		values = result.Tmi
+	case "pdeath":
+		values = result.PDeath
+	default:
+		log.Fatalf("unknown metric %q, must be dps, hps, tps, dtps, tmi or pdeath", weightsMetric)
+	}
+
+	table := newOutputTable("Stat", "Weight", "Weight Stdev", "EP", "EP Stdev")
+	for _, stat := range request.StatsToWeigh {
+		table.addRow(statName(stat),
+			indexOrZero(values.GetWeights().GetStats(), int(stat)),
+			indexOrZero(values.GetWeightsStdev().GetStats(), int(stat)),
+			indexOrZero(values.GetEpValues().GetStats(), int(stat)),
+			indexOrZero(values.GetEpValuesStdev().GetStats(), int(stat)))
+	}
+	for _, pseudoStat := range request.PseudoStatsToWeigh {
+		table.addRow(pseudoStatName(pseudoStat),
+			indexOrZero(values.GetWeights().GetPseudoStats(), int(pseudoStat)),
+			indexOrZero(values.GetWeightsStdev().GetPseudoStats(), int(pseudoStat)),
+			indexOrZero(values.GetEpValues().GetPseudoStats(), int(pseudoStat)),
+			indexOrZero(values.GetEpValuesStdev().GetPseudoStats(), int(pseudoStat)))
+	}
+	return table
+}
+
+func indexOrZero(values []float64, idx int) float64 {
+	if idx >= len(values) {
+		return 0
+	}
+	return values[idx]
+}
+
+func statName(stat proto.Stat) string {
+	return strings.TrimPrefix(stat.String(), "Stat")
+}
+
+func pseudoStatName(pseudoStat proto.PseudoStat) string {
+	return strings.TrimPrefix(pseudoStat.String(), "PseudoStat")
+}
+
+// Parses a stat name, with or without the "Stat" prefix, ignoring case.
+func parseStat(name string) (proto.Stat, error) {
+	for value := range proto.Stat_name {
+		stat := proto.Stat(value)
+		if strings.EqualFold(name, statName(stat)) || strings.EqualFold(name, stat.String()) {
+			return stat, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown stat %q", name)
+}
+
+// Parses a pseudo stat name, with or without the "PseudoStat" prefix, ignoring case.
+func parsePseudoStat(name string) (proto.PseudoStat, error) {
+	for value := range proto.PseudoStat_name {
+		pseudoStat := proto.PseudoStat(value)
+		if strings.EqualFold(name, pseudoStatName(pseudoStat)) || strings.EqualFold(name, pseudoStat.String()) {
+			return pseudoStat, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown pseudo stat %q", name)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestStatWeightsRequestFromRaidSim(t *testing.T) {
+	mage := &proto.Player{Name: "Mage", Class: proto.Class_ClassMage}
+	tank := &proto.Player{Name: "Tank", Class: proto.Class_ClassWarrior}
+	tankPartyBuffs := &proto.PartyBuffs{}
+	input := &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{Players: []*proto.Player{mage}},
+				{Players: []*proto.Player{tank}, Buffs: tankPartyBuffs},
+			},
+			Buffs:   &proto.RaidBuffs{ArcaneBrilliance: true},
+			Debuffs: &proto.Debuffs{CriticalMass: true},
+			Tanks:   []*proto.UnitReference{{Type: proto.UnitReference_Player, Index: 5}},
+		},
+		Encounter:  &proto.Encounter{Duration: 120},
+		SimOptions: &proto.SimOptions{Iterations: 100},
+	}
+
+	// The tank is the first player of the second party, at raid index 5.
+	request := statWeightsRequestFromRaidSim(input, 5)
+	if request.Player != tank || request.PartyBuffs != tankPartyBuffs {
+		t.Fatalf("Expected the tank and their party's buffs, got %v", request)
+	}
+	if !request.RaidBuffs.ArcaneBrilliance || !request.Debuffs.CriticalMass || request.Encounter.Duration != 120 || request.SimOptions.Iterations != 100 {
+		t.Fatalf("Expected the raid's buffs, debuffs, encounter and options, got %v", request)
+	}
+	if len(request.Tanks) != 1 || request.Tanks[0].Index != 0 {
+		t.Fatalf("Expected the tank to be referenced as the only player, got %v", request.Tanks)
+	}
+
+	if request := statWeightsRequestFromRaidSim(input, 0); request.Player != mage || request.Tanks != nil {
+		t.Fatalf("Expected the mage without tanks, got %v", request)
+	}
+}
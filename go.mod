@@ -3,17 +3,20 @@ module github.com/wowsims/cata
 go 1.21
 
 require (
-	github.com/google/go-cmp v0.5.8
+	connectrpc.com/connect v1.16.1
+	github.com/google/go-cmp v0.5.9
 	github.com/google/uuid v1.3.1
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
 	github.com/spf13/cobra v1.7.0
 	github.com/tailscale/hujson v0.0.0-20221223112325-20486734a56a
 	golang.org/x/exp v0.0.0-20221028150844-83b7d23a625f
+	golang.org/x/net v0.21.0
 	google.golang.org/protobuf v1.33.0
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/sys v0.1.0 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
 )
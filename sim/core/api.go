@@ -34,14 +34,38 @@ func StatWeights(request *proto.StatWeightsRequest) *proto.StatWeightsResult {
 }
 
 func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics) {
+	StatWeightsAsyncContext(context.Background(), request, progress)
+}
+
+// Like StatWeightsAsync, but the sims stop early with an empty result if ctx is canceled.
+func StatWeightsAsyncContext(ctx context.Context, request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics) {
 	go func() {
-		result := CalcStatWeight(request, stats.Stat(request.EpReferenceStat), progress)
+		result := calcStatWeight(ctx, request, stats.Stat(request.EpReferenceStat), progress)
 		progress <- &proto.ProgressMetrics{
 			FinalWeightResult: result.ToProto(),
 		}
 	}()
 }
 
+/**
+ * Picks gems, enchants and reforges which maximize the EP of a player's gear, while respecting hit and expertise caps.
+ * EP values come from weights if given, otherwise from a sim.
+ */
+func OptimizeGear(request *proto.OptimizeGearRequest, weights *proto.StatWeightValues) *proto.OptimizeGearResult {
+	return optimizeGear(request, weights)
+}
+
+/**
+ * Searches for the values of the tunable rotation constants which give the highest DPS.
+ */
+func TuneRotation(request *proto.RaidSimRequest, settings *proto.TuneSettings) *proto.TuneRotationResult {
+	return runTuneRotation(request, settings, nil)
+}
+
+func TuneRotationAsync(request *proto.RaidSimRequest, settings *proto.TuneSettings, progress chan *proto.ProgressMetrics) {
+	go runTuneRotation(request, settings, progress)
+}
+
 /**
  * Runs multiple iterations of the sim with a full raid.
  */
@@ -50,7 +74,12 @@ func RunRaidSim(request *proto.RaidSimRequest) *proto.RaidSimResult {
 }
 
 func RunRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
-	RunConcurrentRaidSimAsync(request, progress)
+	RunConcurrentRaidSimAsync(context.Background(), request, progress)
+}
+
+// Like RunRaidSimAsync, but the sim stops early with an error result if ctx is canceled.
+func RunRaidSimAsyncContext(ctx context.Context, request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
+	RunConcurrentRaidSimAsync(ctx, request, progress)
 }
 
 func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
@@ -60,3 +89,9 @@ func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
 func RunBulkSimAsync(ctx context.Context, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) {
 	go BulkSim(ctx, request, progress)
 }
+
+// Returns whether progress is the last message of an async sim, which carries
+// its final result.
+func IsFinalProgress(progress *proto.ProgressMetrics) bool {
+	return progress.FinalRaidResult != nil || progress.FinalWeightResult != nil || progress.FinalBulkResult != nil || progress.FinalTuneResult != nil
+}
@@ -3,6 +3,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -29,19 +30,21 @@ func (rsrc *raidSimResultCombiner) newDistMetrics() *proto.DistributionMetrics {
 
 func (rsrc *raidSimResultCombiner) newUnitMetrics(baseUnit *proto.UnitMetrics) *proto.UnitMetrics {
 	newUm := &proto.UnitMetrics{
-		Name:      baseUnit.Name,
-		UnitIndex: baseUnit.UnitIndex,
-		Dps:       rsrc.newDistMetrics(),
-		Dpasp:     rsrc.newDistMetrics(),
-		Threat:    rsrc.newDistMetrics(),
-		Dtps:      rsrc.newDistMetrics(),
-		Tmi:       rsrc.newDistMetrics(),
-		Hps:       rsrc.newDistMetrics(),
-		Tto:       rsrc.newDistMetrics(),
-		Actions:   make([]*proto.ActionMetrics, 0, len(baseUnit.Actions)),
-		Auras:     make([]*proto.AuraMetrics, len(baseUnit.Auras)),
-		Resources: make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
-		Pets:      make([]*proto.UnitMetrics, len(baseUnit.Pets)),
+		Name:         baseUnit.Name,
+		UnitIndex:    baseUnit.UnitIndex,
+		Dps:          rsrc.newDistMetrics(),
+		Dpasp:        rsrc.newDistMetrics(),
+		Threat:       rsrc.newDistMetrics(),
+		Dtps:         rsrc.newDistMetrics(),
+		Tmi:          rsrc.newDistMetrics(),
+		Hps:          rsrc.newDistMetrics(),
+		Ehps:         rsrc.newDistMetrics(),
+		Tto:          rsrc.newDistMetrics(),
+		LifetimeDtps: rsrc.newDistMetrics(),
+		Actions:      make([]*proto.ActionMetrics, 0, len(baseUnit.Actions)),
+		Auras:        make([]*proto.AuraMetrics, len(baseUnit.Auras)),
+		Resources:    make([]*proto.ResourceMetrics, 0, len(baseUnit.Resources)),
+		Pets:         make([]*proto.UnitMetrics, len(baseUnit.Pets)),
 	}
 
 	for i, aura := range baseUnit.Auras {
@@ -141,11 +144,43 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Damage += addTgt.Damage
 		baseTgt.Threat += addTgt.Threat
 		baseTgt.Healing += addTgt.Healing
+		baseTgt.Overhealing += addTgt.Overhealing
 		baseTgt.Shielding += addTgt.Shielding
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
+		for _, addOutcome := range addTgt.DamageOutcomes {
+			rsrc.addDamageOutcomeMetrics(baseTgt, addOutcome)
+		}
 	}
 }
 
+func (rsrc *raidSimResultCombiner) addDamageOutcomeMetrics(tgt *proto.TargetedActionMetrics, add *proto.DamageOutcomeMetrics) {
+	var dom *proto.DamageOutcomeMetrics
+	for _, baseOutcome := range tgt.DamageOutcomes {
+		if baseOutcome.Outcome == add.Outcome && baseOutcome.IsPeriodic == add.IsPeriodic && baseOutcome.IsPartialResist == add.IsPartialResist {
+			dom = baseOutcome
+			break
+		}
+	}
+
+	if dom == nil {
+		tgt.DamageOutcomes = append(tgt.DamageOutcomes, &proto.DamageOutcomeMetrics{
+			Outcome:         add.Outcome,
+			IsPeriodic:      add.IsPeriodic,
+			IsPartialResist: add.IsPartialResist,
+			Count:           add.Count,
+			Damage:          add.Damage,
+			MinDamage:       add.MinDamage,
+			MaxDamage:       add.MaxDamage,
+		})
+		return
+	}
+
+	dom.Count += add.Count
+	dom.Damage += add.Damage
+	dom.MinDamage = min(dom.MinDamage, add.MinDamage)
+	dom.MaxDamage = max(dom.MaxDamage, add.MaxDamage)
+}
+
 func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, add *proto.AuraMetrics, weight float64, isLast bool) {
 	base.UptimeSecondsAvg += add.UptimeSecondsAvg * weight
 	base.ProcsAvg += add.ProcsAvg * weight
@@ -191,10 +226,13 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 	rsrc.combineDistMetrics(base.Dtps, add.Dtps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tmi, add.Tmi, isLast, weight)
 	rsrc.combineDistMetrics(base.Hps, add.Hps, isLast, weight)
+	rsrc.combineDistMetrics(base.Ehps, add.Ehps, isLast, weight)
 	rsrc.combineDistMetrics(base.Tto, add.Tto, isLast, weight)
+	rsrc.combineDistMetrics(base.LifetimeDtps, add.LifetimeDtps, isLast, weight)
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
 	base.ChanceOfDeath += add.ChanceOfDeath * weight
+	base.LifetimeAvg += add.LifetimeAvg * weight
 
 	for _, addAction := range add.Actions {
 		rsrc.addActionMetrics(base, addAction)
@@ -213,6 +251,44 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 	}
 }
 
+// Combines each bin weighted by the number of iterations which reached it.
+func (rsrc *raidSimResultCombiner) combineTimeSeriesValues(base []float64, add []float64, addIterations []int32) []float64 {
+	baseIterations := rsrc.combined.TimeSeries.BinIterations
+	for i, addValue := range add {
+		if i == len(base) {
+			base = append(base, addValue)
+			continue
+		}
+		baseN, addN := float64(baseIterations[i]), float64(addIterations[i])
+		base[i] = (base[i]*baseN + addValue*addN) / (baseN + addN)
+	}
+	return base
+}
+
+func (rsrc *raidSimResultCombiner) addTimeSeries(add *proto.TimeSeriesMetrics) {
+	base := rsrc.combined.TimeSeries
+	for i, addPlayer := range add.Players {
+		basePlayer := base.Players[i]
+		basePlayer.Dps = rsrc.combineTimeSeriesValues(basePlayer.Dps, addPlayer.Dps, add.BinIterations)
+		basePlayer.TargetHealthPercent = rsrc.combineTimeSeriesValues(basePlayer.TargetHealthPercent, addPlayer.TargetHealthPercent, add.BinIterations)
+		for j, addResource := range addPlayer.Resources {
+			baseResource := basePlayer.Resources[j]
+			baseResource.Values = rsrc.combineTimeSeriesValues(baseResource.Values, addResource.Values, add.BinIterations)
+		}
+		for j, addAura := range addPlayer.Auras {
+			baseAura := basePlayer.Auras[j]
+			baseAura.Uptime = rsrc.combineTimeSeriesValues(baseAura.Uptime, addAura.Uptime, add.BinIterations)
+		}
+	}
+
+	for i, n := range add.BinIterations {
+		if i == len(base.BinIterations) {
+			base.BinIterations = append(base.BinIterations, 0)
+		}
+		base.BinIterations[i] += n
+	}
+}
+
 func (rsrc *raidSimResultCombiner) addResult(result *proto.RaidSimResult, isLast bool, weight float64) {
 	rsrc.combineDistMetrics(rsrc.combined.RaidMetrics.Dps, result.RaidMetrics.Dps, isLast, weight)
 	rsrc.combineDistMetrics(rsrc.combined.RaidMetrics.Hps, result.RaidMetrics.Hps, isLast, weight)
@@ -231,6 +307,21 @@ func (rsrc *raidSimResultCombiner) addResult(result *proto.RaidSimResult, isLast
 	}
 
 	rsrc.combined.AvgIterationDuration += result.AvgIterationDuration * weight
+	rsrc.combined.Iterations += result.Iterations
+
+	if result.AplTrace != nil {
+		for i, counts := range result.AplTrace.ActionCounts {
+			baseCounts := rsrc.combined.AplTrace.ActionCounts[i]
+			baseCounts.Evaluated += counts.Evaluated
+			baseCounts.ConditionFalse += counts.ConditionFalse
+			baseCounts.NotReady += counts.NotReady
+			baseCounts.Executed += counts.Executed
+		}
+	}
+
+	if result.TimeSeries != nil {
+		rsrc.addTimeSeries(result.TimeSeries)
+	}
 }
 
 func (rsrc *raidSimResultCombiner) setBaseResult(baseRsr *proto.RaidSimResult) {
@@ -245,6 +336,8 @@ func (rsrc *raidSimResultCombiner) setBaseResult(baseRsr *proto.RaidSimResult) {
 		},
 		Logs:                   baseRsr.Logs,
 		FirstIterationDuration: baseRsr.FirstIterationDuration,
+		CombatLog:              baseRsr.CombatLog,
+		CombatLogJsonl:         baseRsr.CombatLogJsonl,
 	}
 
 	for i, party := range baseRsr.RaidMetrics.Parties {
@@ -255,6 +348,40 @@ func (rsrc *raidSimResultCombiner) setBaseResult(baseRsr *proto.RaidSimResult) {
 		newRsr.EncounterMetrics.Targets[i] = rsrc.newUnitMetrics(tar)
 	}
 
+	if baseRsr.AplTrace != nil {
+		// Counts are summed in addResult, evaluations only come from the first iteration.
+		newRsr.AplTrace = &proto.APLTrace{
+			Evaluations: baseRsr.AplTrace.Evaluations,
+		}
+		for _, counts := range baseRsr.AplTrace.ActionCounts {
+			newRsr.AplTrace.ActionCounts = append(newRsr.AplTrace.ActionCounts, &proto.APLTraceActionCounts{
+				UnitIndex: counts.UnitIndex,
+				List:      counts.List,
+				Index:     counts.Index,
+				Action:    counts.Action,
+			})
+		}
+	}
+
+	if baseRsr.TimeSeries != nil {
+		newRsr.TimeSeries = &proto.TimeSeriesMetrics{
+			BinSeconds: baseRsr.TimeSeries.BinSeconds,
+		}
+		for _, player := range baseRsr.TimeSeries.Players {
+			newPlayer := &proto.UnitTimeSeries{
+				Name:      player.Name,
+				UnitIndex: player.UnitIndex,
+			}
+			for _, resource := range player.Resources {
+				newPlayer.Resources = append(newPlayer.Resources, &proto.ResourceTimeSeries{Type: resource.Type})
+			}
+			for _, aura := range player.Auras {
+				newPlayer.Auras = append(newPlayer.Auras, &proto.AuraTimeSeries{Id: aura.Id})
+			}
+			newRsr.TimeSeries.Players = append(newRsr.TimeSeries.Players, newPlayer)
+		}
+	}
+
 	rsrc.combined = newRsr
 }
 
@@ -322,7 +449,8 @@ func (csd *concurrentSimData) GetCombinedFinalResult() *proto.RaidSimResult {
 }
 
 // Run sim on multiple threads concurrently by splitting interations over multiple sims, transparently combining results into the progress channel.
-func RunConcurrentRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
+// Canceling ctx stops all of the sims.
+func RunConcurrentRaidSimAsync(ctx context.Context, request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
 	if request.SimOptions.Iterations == 0 {
 		progress <- &proto.ProgressMetrics{
 			FinalRaidResult: &proto.RaidSimResult{
@@ -335,12 +463,39 @@ func RunConcurrentRaidSimAsync(request *proto.RaidSimRequest, progress chan *pro
 
 	concurrency := TernaryInt(request.SimOptions.IsTest, 3, runtime.NumCPU())
 
+	go func() {
+		defer close(progress)
+
+		if request.SimOptions.TargetError != nil {
+			runConcurrentTargetErrorSims(ctx, request, concurrency, progress)
+			return
+		}
+
+		csd := runConcurrentSims(ctx, request, concurrency, progress, 0, request.SimOptions.Iterations)
+		if csd == nil {
+			return
+		}
+		progress <- &proto.ProgressMetrics{
+			TotalIterations:     csd.IterationsTotal,
+			CompletedIterations: csd.GetIterationsDone(),
+			Dps:                 csd.GetDpsAvg(),
+			Hps:                 csd.GetHpsAvg(),
+			FinalRaidResult:     csd.GetCombinedFinalResult(),
+		}
+	}()
+}
+
+// Runs the request split over concurrent sims and waits for them to finish.
+// Progress is reported as iterationsBefore plus the completed iterations, out
+// of iterationsTotal. Returns nil if a sim failed or ctx was canceled, after
+// sending the error to the progress channel.
+func runConcurrentSims(ctx context.Context, request *proto.RaidSimRequest, concurrency int, progress chan *proto.ProgressMetrics, iterationsBefore int32, iterationsTotal int32) *concurrentSimData {
 	if concurrency > int(request.SimOptions.Iterations) {
 		concurrency = int(request.SimOptions.Iterations)
 	}
 
 	substituteChannels := make([]chan *proto.ProgressMetrics, concurrency)
-	substituteCases := make([]reflect.SelectCase, concurrency)
+	substituteCases := make([]reflect.SelectCase, concurrency+1) // The last case is ctx.Done().
 	quitChannels := make([]chan bool, concurrency)
 	running := concurrency
 	csd := concurrentSimData{
@@ -357,107 +512,179 @@ func RunConcurrentRaidSimAsync(request *proto.RaidSimRequest, progress chan *pro
 		substituteCases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(substituteChannels[i])}
 		quitChannels[i] = make(chan bool)
 	}
+	substituteCases[concurrency] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
 
 	if !request.SimOptions.IsTest {
 		log.Printf("Running %d iterations on %d concurrent sims.", csd.IterationsTotal, csd.Concurrency)
 	}
 
-	go func() {
-		defer func() {
-			close(progress)
-			// Send quit signals to threads in case we returned due to an error.
-			for _, quitChan := range quitChannels {
-				quitChan <- true
-				close(quitChan)
-			}
-		}()
+	// Closing the quit channels stops any sims which are still running, in case we returned due to an error.
+	defer func() {
+		for _, quitChan := range quitChannels {
+			close(quitChan)
+		}
+	}()
 
-		nextStartSeed := request.SimOptions.RandomSeed // Sims increment their seed each iteration.
+	nextStartSeed := request.SimOptions.RandomSeed // Sims increment their seed each iteration.
 
-		for i := 0; i < concurrency; i++ {
-			requestCopy := googleProto.Clone(request).(*proto.RaidSimRequest)
+	for i := 0; i < concurrency; i++ {
+		requestCopy := googleProto.Clone(request).(*proto.RaidSimRequest)
+
+		requestCopy.SimOptions.Iterations /= int32(concurrency)
+		if i == 0 {
+			requestCopy.SimOptions.Iterations += request.SimOptions.Iterations % int32(concurrency)
+		} else {
+			requestCopy.SimOptions.DebugFirstIteration = false
+			requestCopy.SimOptions.CombatLogFormat = proto.CombatLogFormat_CombatLogFormatNone
+		}
 
-			requestCopy.SimOptions.Iterations /= int32(concurrency)
-			if i == 0 {
-				requestCopy.SimOptions.Iterations += request.SimOptions.Iterations % int32(concurrency)
-			} else {
-				requestCopy.SimOptions.DebugFirstIteration = false
-			}
+		requestCopy.SimOptions.RandomSeed = nextStartSeed
+		nextStartSeed += int64(requestCopy.SimOptions.Iterations)
 
-			requestCopy.SimOptions.RandomSeed = nextStartSeed
-			nextStartSeed += int64(requestCopy.SimOptions.Iterations)
+		go RunSim(requestCopy, substituteChannels[i], quitChannels[i])
+
+		// Wait for first message to make sure env was constructed. Otherwise concurrent map writes to simdb will happen.
+		msg := <-substituteChannels[i]
+		// First message may be due to an immediate error, otherwise it can be ignored.
+		if msg.FinalRaidResult != nil && msg.FinalRaidResult.ErrorResult != "" {
+			progress <- msg
+			log.Printf("Thread %d had an error. Cancelling all sims!", i)
+			return nil
+		}
+	}
+
+	for running > 0 {
+		i, val, ok := reflect.Select(substituteCases)
+
+		if i == concurrency {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: &proto.RaidSimResult{
+					ErrorResult: "Canceled due to quit signal!",
+				},
+			}
+			return nil
+		}
 
-			go RunSim(requestCopy, substituteChannels[i], quitChannels[i])
+		if !ok {
+			substituteCases[i].Chan = reflect.ValueOf(nil)
+			running -= 1
+			continue
+		}
 
-			// Wait for first message to make sure env was constructed. Otherwise concurrent map writes to simdb will happen.
-			msg := <-substituteChannels[i]
-			// First message may be due to an immediate error, otherwise it can be ignored.
+		msg := val.Interface().(*proto.ProgressMetrics)
+		if csd.UpdateProgress(i, msg) {
 			if msg.FinalRaidResult != nil && msg.FinalRaidResult.ErrorResult != "" {
 				progress <- msg
 				log.Printf("Thread %d had an error. Cancelling all sims!", i)
-				return
+				return nil
 			}
+			substituteCases[i].Chan = reflect.ValueOf(nil)
+			running -= 1
+			continue
 		}
 
-		for running > 0 {
-			i, val, ok := reflect.Select(substituteCases)
+		progress <- &proto.ProgressMetrics{
+			TotalIterations:     iterationsTotal,
+			CompletedIterations: iterationsBefore + csd.GetIterationsDone(),
+			Dps:                 csd.GetDpsAvg(),
+			Hps:                 csd.GetHpsAvg(),
+		}
+	}
 
-			if !ok {
-				substituteCases[i].Chan = reflect.ValueOf(nil)
-				running -= 1
-				continue
+	for _, res := range csd.FinalResults {
+		if res == nil {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: &proto.RaidSimResult{
+					ErrorResult: "Missing one or more final sim result(s)!",
+				},
 			}
+			log.Print("Missing one or more final sim result(s)!")
+			return nil
+		}
+	}
 
-			msg := val.Interface().(*proto.ProgressMetrics)
-			if csd.UpdateProgress(i, msg) {
-				if msg.FinalRaidResult != nil && msg.FinalRaidResult.ErrorResult != "" {
-					progress <- msg
-					log.Printf("Thread %d had an error. Cancelling all sims!", i)
-					return
-				}
-				substituteCases[i].Chan = reflect.ValueOf(nil)
-				running -= 1
-				continue
-			}
+	if !request.SimOptions.IsTest {
+		log.Printf("All %d sims finished successfully.", csd.Concurrency)
+	}
 
-			progress <- &proto.ProgressMetrics{
-				TotalIterations:     csd.IterationsTotal,
-				CompletedIterations: csd.GetIterationsDone(),
-				Dps:                 csd.GetDpsAvg(),
-				Hps:                 csd.GetHpsAvg(),
-			}
+	return &csd
+}
+
+// Runs batches of concurrent sims until the DPS confidence interval of the
+// combined results is within SimOptions.TargetError, or SimOptions.Iterations
+// have been run.
+func runConcurrentTargetErrorSims(ctx context.Context, request *proto.RaidSimRequest, concurrency int, progress chan *proto.ProgressMetrics) {
+	targetError := request.SimOptions.TargetError
+	maxIterations := request.SimOptions.Iterations
+	batchIterations := max(int32(concurrency)*targetErrorMinIterations, maxIterations/20)
+
+	var batchResults []*proto.RaidSimResult
+	var batchSizes []int32
+	var combined *proto.RaidSimResult
+	iterationsDone := int32(0)
+	nextStartSeed := request.SimOptions.RandomSeed
+
+	for iterationsDone < maxIterations {
+		batchRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+		batchRequest.SimOptions.TargetError = nil
+		batchRequest.SimOptions.Iterations = min(batchIterations, maxIterations-iterationsDone)
+		batchRequest.SimOptions.RandomSeed = nextStartSeed
+		if iterationsDone > 0 {
+			batchRequest.SimOptions.DebugFirstIteration = false
+			batchRequest.SimOptions.CombatLogFormat = proto.CombatLogFormat_CombatLogFormatNone
 		}
 
-		for _, res := range csd.FinalResults {
-			if res == nil {
-				progress <- &proto.ProgressMetrics{
-					FinalRaidResult: &proto.RaidSimResult{
-						ErrorResult: "Missing one or more final sim result(s)!",
-					},
-				}
-				log.Print("Missing one or more final sim result(s)!")
-				return
+		csd := runConcurrentSims(ctx, batchRequest, concurrency, progress, iterationsDone, maxIterations)
+		if csd == nil {
+			return
+		}
+		batchResults = append(batchResults, csd.GetCombinedFinalResult())
+		batchSizes = append(batchSizes, batchRequest.SimOptions.Iterations)
+		iterationsDone += batchRequest.SimOptions.Iterations
+		nextStartSeed += int64(batchRequest.SimOptions.Iterations)
+
+		combined = combineBatchResults(batchResults, batchSizes, iterationsDone)
+		achievedError, err := targetErrorAchievedFromResult(combined, targetError)
+		if err != nil {
+			progress <- &proto.ProgressMetrics{
+				FinalRaidResult: &proto.RaidSimResult{
+					ErrorResult: err.Error(),
+				},
 			}
+			return
 		}
-
-		if !request.SimOptions.IsTest {
-			log.Printf("All %d sims finished successfully.", csd.Concurrency)
+		combined.AchievedError = achievedError
+		if iterationsDone >= targetErrorMinIterations && achievedError <= targetError.RelativeError {
+			break
 		}
+	}
 
-		progress <- &proto.ProgressMetrics{
-			TotalIterations:     csd.IterationsTotal,
-			CompletedIterations: csd.GetIterationsDone(),
-			Dps:                 csd.GetDpsAvg(),
-			Hps:                 csd.GetHpsAvg(),
-			FinalRaidResult:     csd.GetCombinedFinalResult(),
-		}
-	}()
+	progress <- &proto.ProgressMetrics{
+		TotalIterations:     maxIterations,
+		CompletedIterations: iterationsDone,
+		Dps:                 combined.RaidMetrics.Dps.Avg,
+		Hps:                 combined.RaidMetrics.Hps.Avg,
+		FinalRaidResult:     combined,
+	}
+}
+
+func combineBatchResults(results []*proto.RaidSimResult, batchSizes []int32, iterationsTotal int32) *proto.RaidSimResult {
+	if len(results) == 1 {
+		return results[0]
+	}
+
+	rsrc := raidSimResultCombiner{}
+	rsrc.setBaseResult(results[0])
+	for i, result := range results {
+		rsrc.addResult(result, i == len(results)-1, float64(batchSizes[i])/float64(iterationsTotal))
+	}
+	return rsrc.combined
 }
 
 // Run a concurrent sim and wait for final result
 func RunConcurrentRaidSimSync(request *proto.RaidSimRequest) *proto.RaidSimResult {
 	progress := make(chan *proto.ProgressMetrics, 10)
-	RunConcurrentRaidSimAsync(request, progress)
+	RunConcurrentRaidSimAsync(context.Background(), request, progress)
 	var rsr *proto.RaidSimResult
 	for msg := range progress {
 		if msg.FinalRaidResult != nil {
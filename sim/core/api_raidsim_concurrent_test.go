@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/wowsims/cata/sim/core"
@@ -68,6 +69,10 @@ func getTestPlayerMM() *proto.Player {
 	}
 }
 
+// Specs can only be registered once, so tests which sim the MM hunter share
+// a single player.
+var testPlayerMM = sync.OnceValue(getTestPlayerMM)
+
 func getTestPlayerBloodDk() *proto.Player {
 	var BloodTalents = "03323203132212311321--003"
 	var BloodDefaultGlyphs = &proto.Glyphs{
@@ -354,7 +359,7 @@ func compareStruct(t *testing.T, loc string, vst reflect.Value, vmt reflect.Valu
 
 func TestConcurrentRaidSim(t *testing.T) {
 	testCases := []*proto.RaidSimRequest{
-		makeTestCase(getTestPlayerMM()),
+		makeTestCase(testPlayerMM()),
 		makeTestCase(getTestPlayerBloodDk()),
 		makeTestCase(getTestPlayerFeralCat()),
 	}
@@ -374,3 +379,65 @@ func TestConcurrentRaidSim(t *testing.T) {
 		t.Log("A fail here means that either the combination of results is broken, or there's a state leak between iterations!")
 	}
 }
+
+func TestConcurrentRaidSimCombatLog(t *testing.T) {
+	rsr := makeTestCase(testPlayerMM())
+	rsr.SimOptions.CombatLogFormat = proto.CombatLogFormat_CombatLogFormatProto
+
+	stRes := core.RunRaidSim(rsr)
+	mtRes := core.RunConcurrentRaidSimSync(rsr)
+
+	if len(stRes.CombatLog) == 0 {
+		t.Fatalf("Expected combat log events but got none!")
+	}
+	if len(stRes.CombatLog) != len(mtRes.CombatLog) {
+		t.Fatalf("Expected %d combat log events but multi threaded result has %d!", len(stRes.CombatLog), len(mtRes.CombatLog))
+	}
+
+	rsr.SimOptions.CombatLogFormat = proto.CombatLogFormat_CombatLogFormatJsonl
+	jsonlRes := core.RunRaidSim(rsr)
+	if lines := strings.Count(jsonlRes.CombatLogJsonl, "\n"); lines != len(stRes.CombatLog) {
+		t.Fatalf("Expected %d combat log lines but got %d!", len(stRes.CombatLog), lines)
+	}
+}
+
+func TestConcurrentRaidSimAPLTrace(t *testing.T) {
+	rsr := makeTestCase(testPlayerMM())
+	rsr.SimOptions.AplTrace = true
+
+	stRes := core.RunRaidSim(rsr)
+	mtRes := core.RunConcurrentRaidSimSync(rsr)
+
+	if len(stRes.AplTrace.GetEvaluations()) == 0 {
+		t.Fatalf("Expected APL trace evaluations but got none!")
+	}
+	if len(stRes.AplTrace.Evaluations) != len(mtRes.AplTrace.GetEvaluations()) {
+		t.Fatalf("Expected %d APL trace evaluations but multi threaded result has %d!", len(stRes.AplTrace.Evaluations), len(mtRes.AplTrace.GetEvaluations()))
+	}
+	for i, counts := range stRes.AplTrace.ActionCounts {
+		if mtCounts := mtRes.AplTrace.ActionCounts[i]; mtCounts.Action != counts.Action {
+			t.Fatalf("Expected APL trace counts for %s but multi threaded result has %s!", counts.Action, mtCounts.Action)
+		}
+	}
+}
+
+func TestConcurrentRaidSimTargetError(t *testing.T) {
+	rsr := makeTestCase(testPlayerMM())
+	rsr.SimOptions.Iterations = 5000
+	rsr.SimOptions.TargetError = &proto.TargetError{RelativeError: 0.01}
+
+	for _, res := range []*proto.RaidSimResult{core.RunRaidSim(rsr), core.RunConcurrentRaidSimSync(rsr)} {
+		if res.ErrorResult != "" {
+			t.Fatalf("Sim failed with error: %s", res.ErrorResult)
+		}
+		if res.Iterations >= rsr.SimOptions.Iterations {
+			t.Fatalf("Expected to stop before %d iterations, ran %d", rsr.SimOptions.Iterations, res.Iterations)
+		}
+		if res.RaidMetrics.Dps.AggregatorData.N != res.Iterations {
+			t.Fatalf("Expected %d DPS samples, got %d", res.Iterations, res.RaidMetrics.Dps.AggregatorData.N)
+		}
+		if res.AchievedError > 0.01 {
+			t.Fatalf("Expected an error of at most 0.01, got %f", res.AchievedError)
+		}
+	}
+}
@@ -13,6 +13,20 @@ type APLRotation struct {
 	prepullActions []*APLAction
 	priorityList   []*APLAction
 
+	// Indices into the rotation config for each parsed action, and the 'Do At'
+	// time of each parsed prepull action. Used by lint().
+	prepullConfigIdxs      []int
+	priorityListConfigIdxs []int
+	prepullDoAts           []time.Duration
+
+	// Named lists, referenced by call_action_list and run_action_list.
+	actionLists []*aplActionList
+
+	// Named values, referenced by APLValueVariable.
+	variables          map[string]*aplVariable
+	variableConfigs    map[string]*proto.APLVariable
+	resolvingVariables map[string]bool
+
 	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
 	controllingActions []APLActionImpl
 
@@ -29,14 +43,24 @@ type APLRotation struct {
 	// Used to avoid recursive APL loops.
 	inLoop bool
 
+	// Number of times DoNextAction has evaluated the priority list.
+	numEvaluations int
+
+	// Set when a run_action_list has no ready actions, so the lists calling
+	// it stop looking for an action.
+	haltActionLists bool
+
 	// Used to override MCD restrictions within sequences.
 	inSequence bool
 
 	// Validation warnings that occur during proto parsing.
 	// We return these back to the user for display in the UI.
-	curWarnings          []string
-	prepullWarnings      [][]string
-	priorityListWarnings [][]string
+	curWarnings            []string
+	prepullWarnings        [][]string
+	priorityListWarnings   [][]string
+	actionListWarnings     [][]string
+	actionListItemWarnings [][][]string
+	variableWarnings       [][]string
 }
 
 func (rot *APLRotation) ValidationWarning(message string, vals ...interface{}) {
@@ -75,9 +99,37 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 	}
 
 	rotation := &APLRotation{
-		unit:                 unit,
-		prepullWarnings:      make([][]string, len(config.PrepullActions)),
-		priorityListWarnings: make([][]string, len(config.PriorityList)),
+		unit:                   unit,
+		variables:              make(map[string]*aplVariable),
+		variableConfigs:        make(map[string]*proto.APLVariable),
+		resolvingVariables:     make(map[string]bool),
+		prepullWarnings:        make([][]string, len(config.PrepullActions)),
+		priorityListWarnings:   make([][]string, len(config.PriorityList)),
+		actionListWarnings:     make([][]string, len(config.ActionLists)),
+		actionListItemWarnings: make([][][]string, len(config.ActionLists)),
+		variableWarnings:       make([][]string, len(config.Variables)),
+	}
+
+	// Parse variables first, since any action or value may reference them.
+	for i, variableConfig := range config.Variables {
+		rotation.doAndRecordWarnings(&rotation.variableWarnings[i], false, func() {
+			if variableConfig.Name == "" {
+				rotation.ValidationWarning("Variable must provide a name")
+			} else if _, ok := rotation.variableConfigs[variableConfig.Name]; ok {
+				rotation.ValidationWarning("Duplicate variable name: '%s'", variableConfig.Name)
+			} else {
+				rotation.variableConfigs[variableConfig.Name] = variableConfig
+			}
+		})
+	}
+	for i, variableConfig := range config.Variables {
+		rotation.doAndRecordWarnings(&rotation.variableWarnings[i], false, func() {
+			if rotation.variableConfigs[variableConfig.Name] == variableConfig {
+				if variable := rotation.getVariable(variableConfig.Name); variable != nil {
+					variable.configIdx = i
+				}
+			}
+		})
 	}
 
 	// Parse prepull actions
@@ -94,6 +146,8 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 						action := rotation.newAPLAction(prepullItem.Action)
 						if action != nil {
 							rotation.prepullActions = append(rotation.prepullActions, action)
+							rotation.prepullConfigIdxs = append(rotation.prepullConfigIdxs, prepullIdx)
+							rotation.prepullDoAts = append(rotation.prepullDoAts, doAt)
 							unit.RegisterPrepullAction(doAt, func(sim *Simulation) {
 								// Warnings for prepull cast failure are detected by running a fake prepull,
 								// so this action.Execute needs to record warnings.
@@ -109,19 +163,49 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 	}
 
 	// Parse priority list
-	var configIdxs []int
 	for i, aplItem := range config.PriorityList {
 		rotation.doAndRecordWarnings(&rotation.priorityListWarnings[i], false, func() {
 			if !aplItem.Hide {
 				action := rotation.newAPLAction(aplItem.Action)
 				if action != nil {
 					rotation.priorityList = append(rotation.priorityList, action)
-					configIdxs = append(configIdxs, i)
+					rotation.priorityListConfigIdxs = append(rotation.priorityListConfigIdxs, i)
 				}
 			}
 		})
 	}
 
+	// Parse action lists
+	for i, listConfig := range config.ActionLists {
+		rotation.actionListItemWarnings[i] = make([][]string, len(listConfig.Items))
+		rotation.doAndRecordWarnings(&rotation.actionListWarnings[i], false, func() {
+			if listConfig.Name == "" {
+				rotation.ValidationWarning("Action list must provide a name")
+			} else if rotation.getActionList(listConfig.Name) != nil {
+				rotation.ValidationWarning("Duplicate action list name: '%s'", listConfig.Name)
+			} else {
+				rotation.actionLists = append(rotation.actionLists, &aplActionList{
+					name:      listConfig.Name,
+					configIdx: i,
+				})
+			}
+		})
+	}
+	for _, list := range rotation.actionLists {
+		for i, aplItem := range config.ActionLists[list.configIdx].Items {
+			rotation.doAndRecordWarnings(&rotation.actionListItemWarnings[list.configIdx][i], false, func() {
+				if !aplItem.Hide {
+					action := rotation.newAPLAction(aplItem.Action)
+					if action != nil {
+						list.actions = append(list.actions, action)
+						list.configIdxs = append(list.configIdxs, i)
+					}
+				}
+			})
+		}
+	}
+	rotation.markActionListCycles()
+
 	// Finalize
 	for i, action := range rotation.prepullActions {
 		rotation.doAndRecordWarnings(&rotation.prepullWarnings[i], true, func() {
@@ -133,6 +217,20 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 			action.Finalize(rotation)
 		})
 	}
+	for _, list := range rotation.actionLists {
+		for i, action := range list.actions {
+			rotation.doAndRecordWarnings(&rotation.actionListItemWarnings[list.configIdx][list.configIdxs[i]], false, func() {
+				action.Finalize(rotation)
+			})
+		}
+	}
+	for i, variableConfig := range config.Variables {
+		if variable := rotation.variables[variableConfig.Name]; variable != nil && rotation.variableConfigs[variableConfig.Name] == variableConfig {
+			rotation.doAndRecordWarnings(&rotation.variableWarnings[i], false, func() {
+				variable.finalize(rotation)
+			})
+		}
+	}
 
 	// Remove MCDs that are referenced by APL actions, so that the Autocast Other Cooldowns
 	// action does not include them.
@@ -171,18 +269,49 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 	return rotation
 }
 func (rot *APLRotation) getStats() *proto.APLStats {
-	return &proto.APLStats{
+	stats := &proto.APLStats{
 		PrepullActions: MapSlice(rot.prepullWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
 		PriorityList:   MapSlice(rot.priorityListWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
+		ActionLists: MapSlice(rot.actionListItemWarnings, func(itemWarnings [][]string) *proto.APLActionListStats {
+			return &proto.APLActionListStats{
+				Items: MapSlice(itemWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
+			}
+		}),
+		Variables: MapSlice(rot.variableWarnings, func(warnings []string) *proto.APLActionStats { return &proto.APLActionStats{Warnings: warnings} }),
+	}
+	for i, warnings := range rot.actionListWarnings {
+		stats.ActionLists[i].Warnings = warnings
+	}
+
+	lints := rot.lint()
+	for i, itemLints := range lints.prepull {
+		stats.PrepullActions[i].Lints = itemLints
+	}
+	for i, itemLints := range lints.priorityList {
+		stats.PriorityList[i].Lints = itemLints
+	}
+	for i, listLints := range lints.actionLists {
+		for j, itemLints := range listLints {
+			stats.ActionLists[i].Items[j].Lints = itemLints
+		}
+	}
+	for i, itemLints := range lints.variables {
+		stats.Variables[i].Lints = itemLints
 	}
+	return stats
 }
 
 func (rot *APLRotation) allAPLActions() []*APLAction {
-	if rot == nil || rot.priorityList == nil {
+	if rot == nil {
 		return []*APLAction{}
 	}
 
-	return Flatten(MapSlice(rot.priorityList, func(action *APLAction) []*APLAction {
+	actions := rot.priorityList
+	for _, list := range rot.actionLists {
+		actions = append(actions[:len(actions):len(actions)], list.actions...)
+	}
+
+	return Flatten(MapSlice(actions, func(action *APLAction) []*APLAction {
 		// Check if action is nil before calling GetAllActions
 		if action == nil {
 			return []*APLAction{}
@@ -199,6 +328,12 @@ func (rot *APLRotation) allPrepullActions() []*APLAction {
 func (rot *APLRotation) reset(sim *Simulation) {
 	rot.controllingActions = nil
 	rot.inLoop = false
+	rot.haltActionLists = false
+	for _, variable := range rot.variables {
+		if variable != nil {
+			variable.override = nil
+		}
+	}
 	rot.interruptChannelIf = nil
 	rot.allowChannelRecastOnInterrupt = false
 	for _, action := range rot.allAPLActions() {
@@ -228,6 +363,7 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 
 	i := 0
 	apl.inLoop = true
+	apl.numEvaluations++
 
 	apl.unit.UpdatePosition(sim)
 	for nextAction := apl.getNextAction(sim); nextAction != nil; i, nextAction = i+1, apl.getNextAction(sim) {
@@ -251,16 +387,32 @@ func (apl *APLRotation) DoNextAction(sim *Simulation) {
 }
 
 func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
+	if sim.aplTrace != nil {
+		sim.aplTrace.pending = nil
+	}
 	if len(apl.controllingActions) != 0 {
 		return apl.controllingActions[len(apl.controllingActions)-1].GetNextAction(sim)
 	}
 
-	for _, action := range apl.priorityList {
+	apl.haltActionLists = false
+	if sim.aplTrace != nil {
+		return sim.aplTrace.getNextAction(sim, apl)
+	}
+	return apl.getNextActionFromList(sim, apl.priorityList)
+}
+
+func (apl *APLRotation) getNextActionFromList(sim *Simulation, actions []*APLAction) *APLAction {
+	if sim.aplTrace != nil {
+		return sim.aplTrace.getNextActionFromList(sim, apl, actions)
+	}
+	for _, action := range actions {
 		if action.IsReady(sim) {
 			return action
 		}
+		if apl.haltActionLists {
+			return nil
+		}
 	}
-
 	return nil
 }
 
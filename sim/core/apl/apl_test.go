@@ -0,0 +1,226 @@
+package apl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func spellID(id int32) *proto.ActionID {
+	return &proto.ActionID{RawId: &proto.ActionID_SpellId{SpellId: id}}
+}
+
+func TestParseExample(t *testing.T) {
+	rot, err := Parse("actions+=/cast_spell,id=49020,if=aura_remaining_time(51271)<2s&gcd_is_ready\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+		PriorityList: []*proto.APLListItem{{
+			Action: &proto.APLAction{
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID(49020)}},
+				Condition: &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: []*proto.APLValue{
+					{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+						Op:  proto.APLValueCompare_OpLt,
+						Lhs: &proto.APLValue{Value: &proto.APLValue_AuraRemainingTime{AuraRemainingTime: &proto.APLValueAuraRemainingTime{AuraId: spellID(51271)}}},
+						Rhs: constValue("2s"),
+					}}},
+					{Value: &proto.APLValue_GcdIsReady{GcdIsReady: &proto.APLValueGCDIsReady{}}},
+				}}}},
+			},
+		}},
+	}
+	if !googleProto.Equal(rot, expected) {
+		t.Fatalf("Unexpected rotation: %v", rot)
+	}
+
+	if text := Format(rot); text != "actions+=/cast_spell,id=49020,if=aura_remaining_time(51271)<2s&gcd_is_ready\n" {
+		t.Fatalf("Unexpected formatted rotation: %s", text)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	text := `# Opener
+actions.precombat+=/cast_spell,id=item:58088,at=-1.5s
+actions.precombat+=/activate_aura,id=other:OtherActionPotion.-2,at=-10s,hide=true
+actions+=/sequence,name=opener,actions=[cast_spell(id=1),cast_spell(id=2,target=target:1,if=!spell_is_ready(3))]
+actions+=/cast_spell,id=3,if=(current_mana_percent<20%|current_time>1m30s)&(dot_remaining_time(5)-1s)*2<=gcd_time_to_ready
+actions+=/multidot,id=4.-1,max_dots=3,max_overlap=0.5s,notes="Keep \"dots\" up"
+actions+=/cast_spell,id=5,if=aura_is_active(6,pet:0@player:2)&cmp(lhs=current_rage)&(and(gcd_is_ready)|"str"=="str")
+actions+=/change_target,new_target=current_target,if=number_targets>=3
+actions+=/call_action_list,name=aoe,if=variable(many_targets)
+actions.aoe+=/cast_spell,id=7
+actions.aoe+=/set_variable,name=many_targets,value=false,hide=true
+variable.many_targets=number_targets>=3
+`
+
+	rot, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rot.PrepullActions) != 2 || len(rot.PriorityList) != 6 {
+		t.Fatalf("Unexpected number of actions: %d prepull, %d priority", len(rot.PrepullActions), len(rot.PriorityList))
+	}
+	if len(rot.ActionLists) != 1 || len(rot.ActionLists[0].Items) != 2 || len(rot.Variables) != 1 {
+		t.Fatalf("Unexpected action lists or variables: %v, %v", rot.ActionLists, rot.Variables)
+	}
+
+	reparsed, err := Parse(Format(rot))
+	if err != nil {
+		t.Fatalf("Failed to parse formatted rotation: %v\n%s", err, Format(rot))
+	}
+	if !googleProto.Equal(rot, reparsed) {
+		t.Fatalf("Rotation did not round trip:\n%s\n%s", Format(rot), Format(reparsed))
+	}
+}
+
+func TestRoundTripShippedAPLs(t *testing.T) {
+	files, err := filepath.Glob("../../../ui/*/*/apls/*.apl.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("Failed to find the shipped APLs: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		rot := &proto.APLRotation{}
+		if err := protojson.Unmarshal(data, rot); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", file, err)
+		}
+
+		text := Format(rot)
+		reparsed, err := Parse(text)
+		if err != nil {
+			t.Fatalf("Failed to parse formatted %s: %v\n%s", file, err, text)
+		}
+		if !googleProto.Equal(rot, reparsed) {
+			t.Fatalf("%s did not round trip:\n%s\n%s", file, text, Format(reparsed))
+		}
+	}
+}
+
+// Fills every field of msg with a non-default value, so that round trip
+// tests exercise all the arguments of each action and value.
+func populate(msg protoreflect.Message, depth int) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.ContainingOneof() != nil && msg.WhichOneof(fd.ContainingOneof()) != nil {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Mutable(fd).List()
+			for j := 0; j < 2; j++ {
+				list.Append(sampleValue(fd, list.NewElement, depth))
+			}
+			continue
+		}
+		msg.Set(fd, sampleValue(fd, func() protoreflect.Value { return msg.NewField(fd) }, depth))
+	}
+}
+
+func sampleValue(fd protoreflect.FieldDescriptor, newValue func() protoreflect.Value, depth int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		switch fd.Message().FullName() {
+		case valueDescriptor.FullName():
+			return protoreflect.ValueOfMessage(constValue("3s").ProtoReflect())
+		case actionDescriptor.FullName():
+			action := &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: spellID(1)}}}
+			return protoreflect.ValueOfMessage(action.ProtoReflect())
+		case actionIDDescriptor.FullName():
+			return protoreflect.ValueOfMessage(spellID(7).ProtoReflect())
+		case unitReferenceDescriptor.FullName():
+			ref := &proto.UnitReference{Type: proto.UnitReference_Pet, Index: 1, Owner: &proto.UnitReference{Type: proto.UnitReference_Player}}
+			return protoreflect.ValueOfMessage(ref.ProtoReflect())
+		}
+		val := newValue()
+		if depth > 0 {
+			populate(val.Message(), depth-1)
+		}
+		return val
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(values.Len() - 1).Number())
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(true)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString("0s, 30s")
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(-3)
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(1.25)
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(1.25)
+	}
+	panic("Unsupported field kind " + fd.Kind().String())
+}
+
+func TestRoundTripAllOneofs(t *testing.T) {
+	rot := &proto.APLRotation{Type: proto.APLRotation_TypeAPL}
+
+	for i := 0; i < actionOneof.Fields().Len(); i++ {
+		fd := actionOneof.Fields().Get(i)
+		action := &proto.APLAction{Condition: constValue("true")}
+		populate(action.ProtoReflect().Mutable(fd).Message(), 2)
+		rot.PriorityList = append(rot.PriorityList, &proto.APLListItem{Action: action})
+	}
+
+	for i := 0; i < valueOneof.Fields().Len(); i++ {
+		fd := valueOneof.Fields().Get(i)
+		value := &proto.APLValue{}
+		populate(value.ProtoReflect().Mutable(fd).Message(), 2)
+		rot.PrepullActions = append(rot.PrepullActions, &proto.APLPrepullAction{
+			Action:    &proto.APLAction{Action: &proto.APLAction_Wait{Wait: &proto.APLActionWait{Duration: value}}},
+			DoAtValue: constValue("-1s"),
+		})
+	}
+
+	text := Format(rot)
+	reparsed, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Failed to parse formatted rotation: %v\n%s", err, text)
+	}
+	for i, item := range rot.PriorityList {
+		if !googleProto.Equal(item, reparsed.PriorityList[i]) {
+			t.Fatalf("Action did not round trip: %s", FormatAction(item.Action))
+		}
+	}
+	for i, item := range rot.PrepullActions {
+		if !googleProto.Equal(item, reparsed.PrepullActions[i]) {
+			t.Fatalf("Value did not round trip: %s", FormatAction(item.Action))
+		}
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		line int
+		col  int
+	}{
+		{text: "actions+=/cast_spel,id=1", line: 1, col: 11},
+		{text: "\n# comment\nactions+=/cast_spell,id=1,if=gcd_is_ready&", line: 3, col: 43},
+		{text: "actions+=/cast_spell,id=1,if=aura_is_active(1,bogus)", line: 1, col: 47},
+		{text: "actions+=/wait,duration=\"unterminated", line: 1, col: 25},
+	} {
+		_, err := Parse(tc.text)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected a ParseError for %q, got %v", tc.text, err)
+		}
+		if parseErr.Line != tc.line || parseErr.Col != tc.col {
+			t.Fatalf("Expected error at %d:%d for %q, got %v", tc.line, tc.col, tc.text, err)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package apl
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Format converts a rotation into APL text, one action per line. Only the
+// APL fields of the rotation are written; Parse(Format(rot)) returns an
+// equivalent APL rotation.
+func Format(rotation *proto.APLRotation) string {
+	var sb strings.Builder
+	for _, variable := range rotation.GetVariables() {
+		sb.WriteString(variableLineName + "." + variable.Name + "=")
+		if variable.Value != nil {
+			sb.WriteString(FormatValue(variable.Value))
+		}
+		sb.WriteString("\n")
+	}
+	for _, prepullAction := range rotation.GetPrepullActions() {
+		sb.WriteString(mainListName + "." + prepullListName + "+=/")
+		sb.WriteString(formatActionArgs(prepullAction.Action, ","))
+		if prepullAction.DoAtValue != nil {
+			sb.WriteString(",at=" + FormatValue(prepullAction.DoAtValue))
+		}
+		if prepullAction.Hide {
+			sb.WriteString(",hide=true")
+		}
+		sb.WriteString("\n")
+	}
+	formatListItems(&sb, mainListName, rotation.GetPriorityList())
+	for _, list := range rotation.GetActionLists() {
+		formatListItems(&sb, mainListName+"."+list.Name, list.Items)
+	}
+	return sb.String()
+}
+
+func formatListItems(sb *strings.Builder, prefix string, items []*proto.APLListItem) {
+	for _, listItem := range items {
+		sb.WriteString(prefix + "+=/")
+		sb.WriteString(formatActionArgs(listItem.Action, ","))
+		if listItem.Hide {
+			sb.WriteString(",hide=true")
+		}
+		if listItem.Notes != "" {
+			sb.WriteString(",notes=" + formatString(listItem.Notes))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// FormatAction converts a single action into its nested text form, e.g.
+// 'cast_spell(id=49020,if=gcd_is_ready)'.
+func FormatAction(action *proto.APLAction) string {
+	return formatActionArgs(action, "(")
+}
+
+// Writes the action name followed by its keyed arguments and condition. sep
+// is ',' for top-level lines and '(' for nested actions.
+func formatActionArgs(action *proto.APLAction, sep string) string {
+	msg := action.ProtoReflect()
+	fd := msg.WhichOneof(actionOneof)
+	if fd == nil {
+		return formatMessageLiteral(msg)
+	}
+
+	args := formatKeyedArgs(msg.Get(fd).Message(), fieldsByNumber(fd.Message()))
+	if action.Condition != nil {
+		args = append(args, "if="+FormatValue(action.Condition))
+	}
+
+	name := string(fd.Name())
+	if len(args) == 0 {
+		return name
+	}
+	if sep == "," {
+		return name + "," + strings.Join(args, ",")
+	}
+	return name + "(" + strings.Join(args, ",") + ")"
+}
+
+func formatKeyedArgs(msg protoreflect.Message, fields []protoreflect.FieldDescriptor) []string {
+	idField := actionIDField(msg.Descriptor())
+	var args []string
+	for _, fd := range fields {
+		if !msg.Has(fd) {
+			continue
+		}
+		key := string(fd.Name())
+		if fd == idField {
+			key = "id"
+		}
+		args = append(args, key+"="+formatField(fd, msg.Get(fd)))
+	}
+	return args
+}
+
+// Writes the leading set fields positionally, and the rest as keyed
+// arguments.
+func formatCallArgs(msg protoreflect.Message) []string {
+	fields := fieldsByNumber(msg.Descriptor())
+	var args []string
+	for i, fd := range fields {
+		if !msg.Has(fd) {
+			return append(args, formatKeyedArgs(msg, fields[i:])...)
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				args = append(args, formatSingular(fd, list.Get(j)))
+			}
+			// A positional repeated field consumes all remaining positional
+			// arguments, so anything after it must be keyed.
+			return append(args, formatKeyedArgs(msg, fields[i+1:])...)
+		}
+		args = append(args, formatSingular(fd, msg.Get(fd)))
+	}
+	return args
+}
+
+func formatField(fd protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	if !fd.IsList() {
+		return formatSingular(fd, val)
+	}
+	list := val.List()
+	elems := make([]string, list.Len())
+	for i := range elems {
+		elems[i] = formatSingular(fd, list.Get(i))
+	}
+	return "[" + strings.Join(elems, ",") + "]"
+}
+
+func formatSingular(fd protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := val.Message()
+		switch fd.Message().FullName() {
+		case valueDescriptor.FullName():
+			return FormatValue(msg.Interface().(*proto.APLValue))
+		case actionDescriptor.FullName():
+			return formatActionArgs(msg.Interface().(*proto.APLAction), "(")
+		case actionIDDescriptor.FullName():
+			if str, ok := formatActionID(msg.Interface().(*proto.ActionID)); ok {
+				return str
+			}
+		case unitReferenceDescriptor.FullName():
+			return formatUnitReference(msg.Interface().(*proto.UnitReference))
+		}
+		return formatMessageLiteral(msg)
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(val.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(val.Enum()))
+	case protoreflect.StringKind:
+		return formatString(val.String())
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 64)
+	default:
+		return val.String()
+	}
+}
+
+func formatMessageLiteral(msg protoreflect.Message) string {
+	return "{" + strings.Join(formatKeyedArgs(msg, fieldsByNumber(msg.Descriptor())), ",") + "}"
+}
+
+func formatString(str string) string {
+	if isIdentLiteral(str) && str != "true" && str != "false" {
+		return str
+	}
+	return strconv.Quote(str)
+}
+
+func formatActionID(actionID *proto.ActionID) (string, bool) {
+	var str string
+	switch id := actionID.RawId.(type) {
+	case *proto.ActionID_SpellId:
+		str = strconv.Itoa(int(id.SpellId))
+	case *proto.ActionID_ItemId:
+		str = "item:" + strconv.Itoa(int(id.ItemId))
+	case *proto.ActionID_OtherId:
+		str = "other:" + id.OtherId.String()
+	default:
+		return "", false
+	}
+	if actionID.Tag != 0 {
+		str += "." + strconv.Itoa(int(actionID.Tag))
+	}
+	return str, true
+}
+
+func formatUnitReference(ref *proto.UnitReference) string {
+	str := toSnakeCase(ref.Type.String())
+	if ref.Index != 0 {
+		str += ":" + strconv.Itoa(int(ref.Index))
+	}
+	if ref.Owner != nil {
+		str += "@" + formatUnitReference(ref.Owner)
+	}
+	return str
+}
+
+// Operator precedence levels, matching the grammar used by the parser.
+const (
+	precOr = iota + 1
+	precAnd
+	precCompare
+	precSum
+	precProduct
+	precUnary
+	precPrimary
+)
+
+var compareOpStrings = map[proto.APLValueCompare_ComparisonOperator]string{
+	proto.APLValueCompare_OpEq: "==",
+	proto.APLValueCompare_OpNe: "!=",
+	proto.APLValueCompare_OpLt: "<",
+	proto.APLValueCompare_OpLe: "<=",
+	proto.APLValueCompare_OpGt: ">",
+	proto.APLValueCompare_OpGe: ">=",
+}
+
+var mathOpStrings = map[proto.APLValueMath_MathOperator]string{
+	proto.APLValueMath_OpAdd: "+",
+	proto.APLValueMath_OpSub: "-",
+	proto.APLValueMath_OpMul: "*",
+	proto.APLValueMath_OpDiv: "/",
+}
+
+// FormatValue converts a value into an expression, e.g.
+// 'aura_remaining_time(51271)<2s&gcd_is_ready'.
+func FormatValue(value *proto.APLValue) string {
+	str, _ := formatValue(value)
+	return str
+}
+
+// Returns the expression text and its precedence level.
+func formatValue(value *proto.APLValue) (string, int) {
+	switch v := value.GetValue().(type) {
+	case *proto.APLValue_Const:
+		if isNumberLiteral(v.Const.Val) || v.Const.Val == "true" || v.Const.Val == "false" {
+			return v.Const.Val, precPrimary
+		}
+		return strconv.Quote(v.Const.Val), precPrimary
+	case *proto.APLValue_Or:
+		if len(v.Or.Vals) >= 2 && !hasNilValue(v.Or.Vals) {
+			return formatOperands(v.Or.Vals, "|", precAnd), precOr
+		}
+	case *proto.APLValue_And:
+		if len(v.And.Vals) >= 2 && !hasNilValue(v.And.Vals) {
+			return formatOperands(v.And.Vals, "&", precCompare), precAnd
+		}
+	case *proto.APLValue_Not:
+		if v.Not.Val != nil {
+			return "!" + formatOperand(v.Not.Val, precUnary), precUnary
+		}
+	case *proto.APLValue_Cmp:
+		if op, ok := compareOpStrings[v.Cmp.Op]; ok && v.Cmp.Lhs != nil && v.Cmp.Rhs != nil {
+			return formatOperand(v.Cmp.Lhs, precSum) + op + formatOperand(v.Cmp.Rhs, precSum), precCompare
+		}
+	case *proto.APLValue_Math:
+		if op, ok := mathOpStrings[v.Math.Op]; ok && v.Math.Lhs != nil && v.Math.Rhs != nil {
+			prec := precSum
+			if v.Math.Op == proto.APLValueMath_OpMul || v.Math.Op == proto.APLValueMath_OpDiv {
+				prec = precProduct
+			}
+			return formatOperand(v.Math.Lhs, prec) + op + formatOperand(v.Math.Rhs, prec+1), prec
+		}
+	case nil:
+		return "{}", precPrimary
+	}
+
+	// Everything else, including operators that can't be written infix, uses
+	// call syntax.
+	msg := value.ProtoReflect()
+	fd := msg.WhichOneof(valueOneof)
+	name := string(fd.Name())
+	args := formatCallArgs(msg.Get(fd).Message())
+	if len(args) == 0 {
+		return name, precPrimary
+	}
+	return name + "(" + strings.Join(args, ",") + ")", precPrimary
+}
+
+func hasNilValue(vals []*proto.APLValue) bool {
+	for _, val := range vals {
+		if val == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func formatOperand(value *proto.APLValue, minPrec int) string {
+	str, prec := formatValue(value)
+	if prec < minPrec {
+		return "(" + str + ")"
+	}
+	return str
+}
+
+func formatOperands(vals []*proto.APLValue, op string, minPrec int) string {
+	strs := make([]string, len(vals))
+	for i, val := range vals {
+		strs[i] = formatOperand(val, minPrec)
+	}
+	return strings.Join(strs, op)
+}
@@ -0,0 +1,163 @@
+package apl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string // For strings, this is the unquoted value.
+	line int
+	col  int
+}
+
+func (tok token) String() string {
+	switch tok.kind {
+	case tokenEOF:
+		return "end of line"
+	case tokenString:
+		return strconv.Quote(tok.text)
+	default:
+		return fmt.Sprintf("'%s'", tok.text)
+	}
+}
+
+// ParseError describes a syntax error in an APL text rotation. Line and Col
+// are 1-based.
+type ParseError struct {
+	Line int
+	Col  int
+	Msg  string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("line %d, col %d: %s", err.Line, err.Col, err.Msg)
+}
+
+var twoCharPuncts = []string{"<=", ">=", "==", "!="}
+
+const singleCharPuncts = "()[]{},=<>!&|+-*/:@."
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// Numbers may carry a unit suffix, e.g. '2s', '1m30s', '500ms' or '20%'. The
+// suffix is kept as part of the token so it can be stored verbatim in an
+// APLValueConst.
+func scanNumber(s string) int {
+	i := 0
+	for i < len(s) && (isIdentChar(s[i]) || s[i] == '.') {
+		i++
+	}
+	if i < len(s) && s[i] == '%' {
+		i++
+	}
+	return i
+}
+
+func isValidNumber(s string) bool {
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := time.ParseDuration(s); err == nil {
+		return true
+	}
+	if pct, ok := strings.CutSuffix(s, "%"); ok {
+		_, err := strconv.ParseFloat(pct, 64)
+		return err == nil
+	}
+	return false
+}
+
+// isNumberLiteral returns whether s can be written as a bare number token
+// (with an optional leading minus) and read back unchanged.
+func isNumberLiteral(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return s != "" && isDigit(s[0]) && scanNumber(s) == len(s) && isValidNumber(s)
+}
+
+func isIdentLiteral(s string) bool {
+	if s == "" || !isIdentStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isIdentChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func lexLine(text string, line int) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(text) {
+		c := text[i]
+		col := i + 1
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case isIdentStart(c):
+			start := i
+			for i < len(text) && isIdentChar(text[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: text[start:i], line: line, col: col})
+		case isDigit(c):
+			n := scanNumber(text[i:])
+			num := text[i : i+n]
+			if !isValidNumber(num) {
+				return nil, &ParseError{Line: line, Col: col, Msg: fmt.Sprintf("invalid number '%s'", num)}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: num, line: line, col: col})
+			i += n
+		case c == '"':
+			quoted, err := strconv.QuotedPrefix(text[i:])
+			if err != nil {
+				return nil, &ParseError{Line: line, Col: col, Msg: "unterminated string"}
+			}
+			val, _ := strconv.Unquote(quoted)
+			tokens = append(tokens, token{kind: tokenString, text: val, line: line, col: col})
+			i += len(quoted)
+		default:
+			punct := ""
+			for _, p := range twoCharPuncts {
+				if strings.HasPrefix(text[i:], p) {
+					punct = p
+					break
+				}
+			}
+			if punct == "" && strings.IndexByte(singleCharPuncts, c) != -1 {
+				punct = text[i : i+1]
+			}
+			if punct == "" {
+				return nil, &ParseError{Line: line, Col: col, Msg: fmt.Sprintf("unexpected character '%c'", c)}
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: punct, line: line, col: col})
+			i += len(punct)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, line: line, col: len(text) + 1}), nil
+}
@@ -0,0 +1,908 @@
+// Package apl converts between proto.APLRotation and a SimulationCraft-style
+// text format, e.g.
+//
+//	# Prepull actions use the precombat list and an 'at' time.
+//	actions.precombat+=/cast_spell,id=49020,at=-1s
+//	actions+=/cast_spell,id=49020,if=aura_remaining_time(51271)<2s&gcd_is_ready
+//	actions+=/sequence,name=opener,actions=[cast_spell(id=1),cast_spell(id=2)]
+//	actions+=/call_action_list,name=aoe,if=number_targets>=3
+//	actions.aoe+=/cast_spell,id=2,if=variable(pooling)
+//	variable.pooling=current_energy<60
+//
+// Named action lists are written as 'actions.<name>+=/...', and variables as
+// 'variable.<name>=<value>'.
+//
+// Action and value names are the field names of the APLAction and APLValue
+// oneofs. Arguments are either 'key=value' pairs using the proto field names,
+// or positional arguments assigned in field number order. 'id' is shorthand
+// for a message's only ActionID field, and 'if' sets the action condition.
+//
+// Conditions are written as expressions using '|', '&', comparisons, '+ - * /'
+// and '!', in increasing order of precedence. Literals such as '2s', '20%',
+// 'true' or "text" become APLValueConst.
+//
+// Field values are written as:
+//   - ActionID: '49020' (spell), 'item:40211' or 'other:OtherActionPotion',
+//     optionally followed by '.<tag>'.
+//   - UnitReference: the snake_case type with an optional index and owner,
+//     e.g. 'current_target', 'target:1' or 'pet:0@player'.
+//   - Enums: the value name, e.g. 'OpLt'.
+//   - Repeated fields: '[a,b,c]'.
+//   - Other messages: '{key=value,...}'.
+package apl
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	actionDescriptor        = (&proto.APLAction{}).ProtoReflect().Descriptor()
+	valueDescriptor         = (&proto.APLValue{}).ProtoReflect().Descriptor()
+	actionIDDescriptor      = (&proto.ActionID{}).ProtoReflect().Descriptor()
+	unitReferenceDescriptor = (&proto.UnitReference{}).ProtoReflect().Descriptor()
+
+	actionOneof = actionDescriptor.Oneofs().ByName("action")
+	valueOneof  = valueDescriptor.Oneofs().ByName("value")
+)
+
+const (
+	mainListName     = "actions"
+	prepullListName  = "precombat"
+	variableLineName = "variable"
+)
+
+// Parse converts APL text into a rotation. The returned rotation always has
+// type TypeAPL.
+func Parse(text string) (*proto.APLRotation, error) {
+	rotation := &proto.APLRotation{
+		Type: proto.APLRotation_TypeAPL,
+	}
+
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		tokens, err := lexLine(line, i+1)
+		if err != nil {
+			return nil, err
+		}
+		p := &parser{tokens: tokens}
+		if err := p.parseLine(rotation); err != nil {
+			return nil, err
+		}
+	}
+
+	return rotation, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunct(text string) bool {
+	tok := p.peek()
+	return tok.kind == tokenPunct && tok.text == text
+}
+
+func (p *parser) accept(text string) bool {
+	if p.isPunct(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.errorf(p.peek(), "expected '%s', found %s", text, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) expectIdent() (token, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent {
+		return tok, p.errorf(tok, "expected a name, found %s", tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return &ParseError{Line: tok.line, Col: tok.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parses a full line of the form 'actions[.<list>]+=/<action>,<args>' or
+// 'variable.<name>=<value>'.
+func (p *parser) parseLine(rotation *proto.APLRotation) error {
+	listTok, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	if listTok.text == variableLineName {
+		return p.parseVariable(rotation)
+	}
+	if listTok.text != mainListName {
+		return p.errorf(listTok, "expected '%s' or '%s', found %s", mainListName, variableLineName, listTok)
+	}
+
+	prepull := false
+	var actionList *proto.APLActionList
+	if p.accept(".") {
+		nameTok, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		if nameTok.text == prepullListName {
+			prepull = true
+		} else {
+			actionList = getOrAddActionList(rotation, nameTok.text)
+		}
+	}
+
+	p.accept("+")
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	p.accept("/")
+
+	action, inner, err := p.parseActionName()
+	if err != nil {
+		return err
+	}
+
+	var listItem *proto.APLListItem
+	var prepullAction *proto.APLPrepullAction
+	if prepull {
+		prepullAction = &proto.APLPrepullAction{Action: action}
+	} else {
+		listItem = &proto.APLListItem{Action: action}
+	}
+
+	err = p.parseArgs(inner, tokenEOF, "", func(key token) (bool, error) {
+		switch key.text {
+		case "if":
+			return true, p.parseInto(&action.Condition)
+		case "hide":
+			hide, err := p.parseBool()
+			if prepull {
+				prepullAction.Hide = hide
+			} else {
+				listItem.Hide = hide
+			}
+			return true, err
+		case "notes":
+			if prepull {
+				return false, nil
+			}
+			tok := p.next()
+			if tok.kind != tokenString && tok.kind != tokenIdent {
+				return true, p.errorf(tok, "expected a string, found %s", tok)
+			}
+			listItem.Notes = tok.text
+			return true, nil
+		case "at":
+			if !prepull {
+				return false, nil
+			}
+			return true, p.parseInto(&prepullAction.DoAtValue)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if prepull {
+		rotation.PrepullActions = append(rotation.PrepullActions, prepullAction)
+	} else if actionList != nil {
+		actionList.Items = append(actionList.Items, listItem)
+	} else {
+		rotation.PriorityList = append(rotation.PriorityList, listItem)
+	}
+	return nil
+}
+
+func getOrAddActionList(rotation *proto.APLRotation, name string) *proto.APLActionList {
+	for _, list := range rotation.ActionLists {
+		if list.Name == name {
+			return list
+		}
+	}
+	list := &proto.APLActionList{Name: name}
+	rotation.ActionLists = append(rotation.ActionLists, list)
+	return list
+}
+
+// Parses the rest of a 'variable.<name>=<value>' line.
+func (p *parser) parseVariable(rotation *proto.APLRotation) error {
+	if err := p.expect("."); err != nil {
+		return err
+	}
+	nameTok, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("="); err != nil {
+		return err
+	}
+
+	variable := &proto.APLVariable{Name: nameTok.text}
+	if err := p.parseInto(&variable.Value); err != nil {
+		return err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return p.errorf(tok, "unexpected %s after variable value", tok)
+	}
+	rotation.Variables = append(rotation.Variables, variable)
+	return nil
+}
+
+func (p *parser) parseInto(value **proto.APLValue) error {
+	val, err := p.parseExpr()
+	*value = val
+	return err
+}
+
+// Parses an action name and returns the action along with its (empty) inner
+// message, which is already attached to the action.
+func (p *parser) parseActionName() (*proto.APLAction, protoreflect.Message, error) {
+	if p.isPunct("{") {
+		// Actions without a type, e.g. ones that were added in the UI but not
+		// filled in yet.
+		action := &proto.APLAction{}
+		return action, action.ProtoReflect(), p.parseMessageLiteral(action.ProtoReflect())
+	}
+
+	nameTok, err := p.expectIdent()
+	if err != nil {
+		return nil, nil, err
+	}
+	fd := actionOneof.Fields().ByName(protoreflect.Name(nameTok.text))
+	if fd == nil {
+		return nil, nil, p.errorf(nameTok, "unknown action '%s'", nameTok.text)
+	}
+
+	action := &proto.APLAction{}
+	msg := action.ProtoReflect()
+	inner := msg.Mutable(fd).Message()
+	return action, inner, nil
+}
+
+// Parses a nested action, e.g. 'cast_spell(id=49020,if=gcd_is_ready)'.
+func (p *parser) parseActionCall() (*proto.APLAction, error) {
+	action, inner, err := p.parseActionName()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("(") {
+		return action, nil
+	}
+	err = p.parseArgs(inner, tokenPunct, ")", func(key token) (bool, error) {
+		if key.text == "if" {
+			return true, p.parseInto(&action.Condition)
+		}
+		return false, nil
+	})
+	return action, err
+}
+
+func fieldsByNumber(md protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, 0, md.Fields().Len())
+	for i := 0; i < md.Fields().Len(); i++ {
+		fields = append(fields, md.Fields().Get(i))
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+	return fields
+}
+
+// Returns the message's only ActionID field, if it has exactly one.
+func actionIDField(md protoreflect.MessageDescriptor) protoreflect.FieldDescriptor {
+	var result protoreflect.FieldDescriptor
+	for i := 0; i < md.Fields().Len(); i++ {
+		fd := md.Fields().Get(i)
+		if fd.Message() != nil && fd.Message().FullName() == actionIDDescriptor.FullName() && !fd.IsList() {
+			if result != nil {
+				return nil
+			}
+			result = fd
+		}
+	}
+	return result
+}
+
+func lookupField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if name == "id" {
+		if fd := actionIDField(md); fd != nil {
+			return fd
+		}
+	}
+	return md.Fields().ByName(protoreflect.Name(name))
+}
+
+// Parses a comma-separated argument list into msg, stopping at the given
+// terminator. Keys that are not fields of msg are passed to extra, which
+// reports whether it consumed the key.
+func (p *parser) parseArgs(msg protoreflect.Message, endKind tokenKind, endText string, extra func(key token) (bool, error)) error {
+	atEnd := func() bool {
+		tok := p.peek()
+		return tok.kind == endKind && (endText == "" || tok.text == endText)
+	}
+	fields := fieldsByNumber(msg.Descriptor())
+	positional := 0
+	sawKeyed := false
+	first := endKind != tokenEOF
+
+	for !atEnd() {
+		if !first {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		keyTok := p.peek()
+		if keyTok.kind == tokenIdent && p.peekAt(1).kind == tokenPunct && p.peekAt(1).text == "=" {
+			if extra != nil {
+				p.pos += 2
+				if ok, err := extra(keyTok); err != nil {
+					return err
+				} else if ok {
+					sawKeyed = true
+					continue
+				}
+				p.pos -= 2
+			}
+			if fd := lookupField(msg.Descriptor(), keyTok.text); fd != nil {
+				p.pos += 2
+				if err := p.parseField(msg, fd); err != nil {
+					return err
+				}
+				sawKeyed = true
+				continue
+			}
+			// Otherwise this is only valid as a positional comparison, e.g. 'gcd_is_ready=true'.
+			if valueOneof.Fields().ByName(protoreflect.Name(keyTok.text)) == nil {
+				return p.errorf(keyTok, "unknown argument '%s' for %s", keyTok.text, msg.Descriptor().Name())
+			}
+		}
+
+		if sawKeyed {
+			return p.errorf(keyTok, "positional argument after keyed argument")
+		}
+		if positional >= len(fields) {
+			return p.errorf(keyTok, "too many arguments for %s", msg.Descriptor().Name())
+		}
+		fd := fields[positional]
+		if err := p.parseField(msg, fd); err != nil {
+			return err
+		}
+		if !fd.IsList() {
+			positional++
+		}
+	}
+
+	if endKind != tokenEOF {
+		p.next()
+	}
+	return nil
+}
+
+// Parses a value for fd and stores it in msg. Repeated fields accept either a
+// single element, which is appended, or a '[a,b]' list.
+func (p *parser) parseField(msg protoreflect.Message, fd protoreflect.FieldDescriptor) error {
+	if fd.IsMap() {
+		return p.errorf(p.peek(), "map field '%s' is not supported", fd.Name())
+	}
+	if !fd.IsList() {
+		if msg.Has(fd) {
+			return p.errorf(p.peek(), "duplicate argument '%s'", fd.Name())
+		}
+		val, err := p.parseSingular(fd, func() protoreflect.Value { return msg.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, val)
+		return nil
+	}
+
+	list := msg.Mutable(fd).List()
+	appendOne := func() error {
+		val, err := p.parseSingular(fd, list.NewElement)
+		if err != nil {
+			return err
+		}
+		list.Append(val)
+		return nil
+	}
+	if !p.accept("[") {
+		return appendOne()
+	}
+	for !p.accept("]") {
+		if list.Len() > 0 {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		if err := appendOne(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseSingular(fd protoreflect.FieldDescriptor, newValue func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		switch fd.Message().FullName() {
+		case valueDescriptor.FullName():
+			val, err := p.parseExpr()
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfMessage(val.ProtoReflect()), nil
+		case actionDescriptor.FullName():
+			action, err := p.parseActionCall()
+			if err != nil {
+				return protoreflect.Value{}, err
+			}
+			return protoreflect.ValueOfMessage(action.ProtoReflect()), nil
+		}
+		val := newValue()
+		var err error
+		switch {
+		case p.isPunct("{"):
+			err = p.parseMessageLiteral(val.Message())
+		case fd.Message().FullName() == actionIDDescriptor.FullName():
+			err = p.parseActionID(val.Message().Interface().(*proto.ActionID))
+		case fd.Message().FullName() == unitReferenceDescriptor.FullName():
+			err = p.parseUnitReference(val.Message().Interface().(*proto.UnitReference))
+		default:
+			err = p.errorf(p.peek(), "expected '{', found %s", p.peek())
+		}
+		return val, err
+	case protoreflect.EnumKind:
+		tok := p.next()
+		if tok.kind == tokenIdent {
+			if ev := fd.Enum().Values().ByName(protoreflect.Name(tok.text)); ev != nil {
+				return protoreflect.ValueOfEnum(ev.Number()), nil
+			}
+		} else if tok.kind == tokenNumber {
+			if n, err := strconv.ParseInt(tok.text, 10, 32); err == nil {
+				return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+			}
+		}
+		return protoreflect.Value{}, p.errorf(tok, "invalid %s value %s", fd.Enum().Name(), tok)
+	case protoreflect.BoolKind:
+		val, err := p.parseBool()
+		return protoreflect.ValueOfBool(val), err
+	case protoreflect.StringKind:
+		tok := p.next()
+		if tok.kind != tokenString && tok.kind != tokenIdent {
+			return protoreflect.Value{}, p.errorf(tok, "expected a string, found %s", tok)
+		}
+		return protoreflect.ValueOfString(tok.text), nil
+	default:
+		return p.parseNumberField(fd)
+	}
+}
+
+func (p *parser) parseBool() (bool, error) {
+	tok := p.next()
+	if tok.kind == tokenIdent && (tok.text == "true" || tok.text == "false") {
+		return tok.text == "true", nil
+	}
+	return false, p.errorf(tok, "expected true or false, found %s", tok)
+}
+
+func (p *parser) parseNumberField(fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	startTok := p.peek()
+	text := ""
+	if p.accept("-") {
+		text = "-"
+	}
+	tok := p.next()
+	if tok.kind != tokenNumber {
+		return protoreflect.Value{}, p.errorf(tok, "expected a number, found %s", tok)
+	}
+	text += tok.text
+
+	var val protoreflect.Value
+	var err error
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int64
+		n, err = strconv.ParseInt(text, 10, 32)
+		val = protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var n int64
+		n, err = strconv.ParseInt(text, 10, 64)
+		val = protoreflect.ValueOfInt64(n)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var n uint64
+		n, err = strconv.ParseUint(text, 10, 32)
+		val = protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var n uint64
+		n, err = strconv.ParseUint(text, 10, 64)
+		val = protoreflect.ValueOfUint64(n)
+	case protoreflect.FloatKind:
+		var f float64
+		f, err = strconv.ParseFloat(text, 32)
+		val = protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		var f float64
+		f, err = strconv.ParseFloat(text, 64)
+		val = protoreflect.ValueOfFloat64(f)
+	default:
+		return protoreflect.Value{}, p.errorf(startTok, "unsupported field type %s for '%s'", fd.Kind(), fd.Name())
+	}
+	if err != nil {
+		return protoreflect.Value{}, p.errorf(startTok, "invalid %s value '%s'", fd.Kind(), text)
+	}
+	return val, nil
+}
+
+// Parses '{key=value,...}'.
+func (p *parser) parseMessageLiteral(msg protoreflect.Message) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for first := true; !p.accept("}"); first = false {
+		if !first {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		keyTok, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+		fd := lookupField(msg.Descriptor(), keyTok.text)
+		if fd == nil {
+			return p.errorf(keyTok, "unknown field '%s' for %s", keyTok.text, msg.Descriptor().Name())
+		}
+		if err := p.expect("="); err != nil {
+			return err
+		}
+		if err := p.parseField(msg, fd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parses '<spell id>', 'spell:<id>', 'item:<id>' or 'other:<OtherAction>',
+// each with an optional '.<tag>' suffix. Tags may be negative.
+func (p *parser) parseActionID(actionID *proto.ActionID) error {
+	tok := p.next()
+	idText := ""
+	kind := "spell"
+	switch tok.kind {
+	case tokenNumber:
+		idText = tok.text
+	case tokenIdent:
+		kind = tok.text
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		idTok := p.next()
+		if kind == "other" {
+			if idTok.kind != tokenIdent {
+				return p.errorf(idTok, "expected an OtherAction name, found %s", idTok)
+			}
+			ev := (proto.OtherAction(0)).Descriptor().Values().ByName(protoreflect.Name(idTok.text))
+			if ev == nil {
+				return p.errorf(idTok, "unknown OtherAction '%s'", idTok.text)
+			}
+			actionID.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(ev.Number())}
+			if p.accept(".") {
+				sign := ""
+				if p.accept("-") {
+					sign = "-"
+				}
+				tagTok := p.next()
+				tag, err := strconv.ParseInt(sign+tagTok.text, 10, 32)
+				if tagTok.kind != tokenNumber || err != nil {
+					return p.errorf(tagTok, "invalid action tag %s", tagTok)
+				}
+				actionID.Tag = int32(tag)
+			}
+			return nil
+		}
+		if idTok.kind != tokenNumber {
+			return p.errorf(idTok, "expected an ID, found %s", idTok)
+		}
+		idText = idTok.text
+	default:
+		return p.errorf(tok, "expected an action ID, found %s", tok)
+	}
+
+	idStr, tagStr, hasTag := strings.Cut(idText, ".")
+	if hasTag && tagStr == "" && p.accept("-") {
+		// The number token ends at the sign of a negative tag, e.g. '2825.-1'.
+		tagTok := p.next()
+		if tagTok.kind != tokenNumber {
+			return p.errorf(tagTok, "invalid action tag %s", tagTok)
+		}
+		tagStr = "-" + tagTok.text
+	}
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		return p.errorf(tok, "invalid action ID '%s'", idText)
+	}
+	if hasTag {
+		tag, err := strconv.ParseInt(tagStr, 10, 32)
+		if err != nil {
+			return p.errorf(tok, "invalid action ID '%s'", idText)
+		}
+		actionID.Tag = int32(tag)
+	}
+
+	switch kind {
+	case "spell":
+		actionID.RawId = &proto.ActionID_SpellId{SpellId: int32(id)}
+	case "item":
+		actionID.RawId = &proto.ActionID_ItemId{ItemId: int32(id)}
+	default:
+		return p.errorf(tok, "unknown action ID kind '%s', expected spell, item or other", kind)
+	}
+	return nil
+}
+
+// Parses '<type>[:<index>][@<owner>]'.
+func (p *parser) parseUnitReference(ref *proto.UnitReference) error {
+	tok, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	typeVal, ok := unitReferenceTypes[tok.text]
+	if !ok {
+		return p.errorf(tok, "unknown unit '%s'", tok.text)
+	}
+	ref.Type = typeVal
+
+	if p.accept(":") {
+		idxTok := p.next()
+		idx, err := strconv.ParseInt(idxTok.text, 10, 32)
+		if idxTok.kind != tokenNumber || err != nil {
+			return p.errorf(idxTok, "invalid unit index %s", idxTok)
+		}
+		ref.Index = int32(idx)
+	}
+
+	if p.accept("@") {
+		ref.Owner = &proto.UnitReference{}
+		return p.parseUnitReference(ref.Owner)
+	}
+	return nil
+}
+
+var unitReferenceTypes = func() map[string]proto.UnitReference_Type {
+	types := make(map[string]proto.UnitReference_Type)
+	for name, val := range proto.UnitReference_Type_value {
+		types[toSnakeCase(name)] = proto.UnitReference_Type(val)
+	}
+	return types
+}()
+
+func toSnakeCase(name string) string {
+	var sb strings.Builder
+	for i, c := range name {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+// Expression grammar, from lowest to highest precedence:
+//
+//	or      := and ('|' and)*
+//	and     := cmp ('&' cmp)*
+//	cmp     := sum [('<' | '<=' | '>' | '>=' | '=' | '==' | '!=') sum]
+//	sum     := product (('+' | '-') product)*
+//	product := unary (('*' | '/') unary)*
+//	unary   := '!' unary | primary
+//	primary := '(' or ')' | literal | name ['(' args ')'] | '{' fields '}'
+func (p *parser) parseExpr() (*proto.APLValue, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (*proto.APLValue, error) {
+	first, err := p.parseAnd()
+	if err != nil || !p.isPunct("|") {
+		return first, err
+	}
+	vals := []*proto.APLValue{first}
+	for p.accept("|") {
+		val, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}, nil
+}
+
+func (p *parser) parseAnd() (*proto.APLValue, error) {
+	first, err := p.parseCompare()
+	if err != nil || !p.isPunct("&") {
+		return first, err
+	}
+	vals := []*proto.APLValue{first}
+	for p.accept("&") {
+		val, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}, nil
+}
+
+var compareOps = map[string]proto.APLValueCompare_ComparisonOperator{
+	"==": proto.APLValueCompare_OpEq,
+	"=":  proto.APLValueCompare_OpEq,
+	"!=": proto.APLValueCompare_OpNe,
+	"<":  proto.APLValueCompare_OpLt,
+	"<=": proto.APLValueCompare_OpLe,
+	">":  proto.APLValueCompare_OpGt,
+	">=": proto.APLValueCompare_OpGe,
+}
+
+func (p *parser) parseCompare() (*proto.APLValue, error) {
+	lhs, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	tok := p.peek()
+	op, ok := compareOps[tok.text]
+	if tok.kind != tokenPunct || !ok {
+		return lhs, nil
+	}
+	p.next()
+	rhs, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{Op: op, Lhs: lhs, Rhs: rhs}}}, nil
+}
+
+var mathOps = map[string]proto.APLValueMath_MathOperator{
+	"+": proto.APLValueMath_OpAdd,
+	"-": proto.APLValueMath_OpSub,
+	"*": proto.APLValueMath_OpMul,
+	"/": proto.APLValueMath_OpDiv,
+}
+
+func (p *parser) parseSum() (*proto.APLValue, error) {
+	return p.parseMath(p.parseProduct, "+", "-")
+}
+
+func (p *parser) parseProduct() (*proto.APLValue, error) {
+	return p.parseMath(p.parseUnary, "*", "/")
+}
+
+func (p *parser) parseMath(operand func() (*proto.APLValue, error), ops ...string) (*proto.APLValue, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenPunct || (tok.text != ops[0] && tok.text != ops[1]) {
+			return lhs, nil
+		}
+		p.next()
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{Op: mathOps[tok.text], Lhs: lhs, Rhs: rhs}}}
+	}
+}
+
+func (p *parser) parseUnary() (*proto.APLValue, error) {
+	if p.accept("!") {
+		val, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: val}}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func constValue(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func (p *parser) parsePrimary() (*proto.APLValue, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokenNumber:
+		p.next()
+		return constValue(tok.text), nil
+	case tokenString:
+		p.next()
+		return constValue(tok.text), nil
+	case tokenIdent:
+		if tok.text == "true" || tok.text == "false" {
+			p.next()
+			return constValue(tok.text), nil
+		}
+		return p.parseValueCall()
+	case tokenPunct:
+		switch tok.text {
+		case "(":
+			p.next()
+			val, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return val, p.expect(")")
+		case "-":
+			p.next()
+			numTok := p.next()
+			if numTok.kind != tokenNumber {
+				return nil, p.errorf(tok, "unary '-' is only supported on number literals")
+			}
+			return constValue("-" + numTok.text), nil
+		case "{":
+			val := &proto.APLValue{}
+			return val, p.parseMessageLiteral(val.ProtoReflect())
+		}
+	}
+	return nil, p.errorf(tok, "expected a value, found %s", tok)
+}
+
+// Parses a value such as 'gcd_is_ready' or 'aura_remaining_time(51271)'.
+func (p *parser) parseValueCall() (*proto.APLValue, error) {
+	nameTok := p.next()
+	fd := valueOneof.Fields().ByName(protoreflect.Name(nameTok.text))
+	if fd == nil {
+		return nil, p.errorf(nameTok, "unknown value '%s'", nameTok.text)
+	}
+
+	val := &proto.APLValue{}
+	msg := val.ProtoReflect()
+	inner := msg.Mutable(fd).Message()
+	if !p.accept("(") {
+		return val, nil
+	}
+	return val, p.parseArgs(inner, tokenPunct, ")", nil)
+}
@@ -9,6 +9,9 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Only updated while an APL trace is being recorded.
+	traceCounts aplActionTraceCounts
 }
 
 func (action *APLAction) Finalize(rot *APLRotation) {
@@ -23,6 +26,9 @@ func (action *APLAction) IsReady(sim *Simulation) bool {
 }
 
 func (action *APLAction) Execute(sim *Simulation) {
+	if sim.aplTrace != nil {
+		sim.aplTrace.actionExecuted(action)
+	}
 	action.impl.Execute(sim)
 }
 
@@ -168,9 +174,19 @@ func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl
 	case *proto.APLAction_StrictSequence:
 		return rot.newActionStrictSequence(config.GetStrictSequence())
 
+	// Action lists and variables
+	case *proto.APLAction_CallActionList:
+		return rot.newActionCallActionList(config.GetCallActionList())
+	case *proto.APLAction_RunActionList:
+		return rot.newActionRunActionList(config.GetRunActionList())
+	case *proto.APLAction_SetVariable:
+		return rot.newActionSetVariable(config.GetSetVariable())
+
 	// Misc
 	case *proto.APLAction_ChangeTarget:
 		return rot.newActionChangeTarget(config.GetChangeTarget())
+	case *proto.APLAction_Retarget:
+		return rot.newActionRetarget(config.GetRetarget())
 	case *proto.APLAction_ActivateAura:
 		return rot.newActionActivateAura(config.GetActivateAura())
 	case *proto.APLAction_CancelAura:
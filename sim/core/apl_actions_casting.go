@@ -29,6 +29,9 @@ func (rot *APLRotation) newActionCastSpell(config *proto.APLActionCastSpell) APL
 func (action *APLActionCastSpell) IsReady(sim *Simulation) bool {
 	return action.spell.CanCastOrQueue(sim, action.target.Get()) && (!action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence)
 }
+func (action *APLActionCastSpell) notReadyReason(sim *Simulation) proto.APLNotReadyReason {
+	return aplSpellNotReadyReason(sim, action.spell, action.target.Get())
+}
 func (action *APLActionCastSpell) Execute(sim *Simulation) {
 	action.spell.CastOrQueue(sim, action.target.Get())
 }
@@ -38,8 +41,11 @@ func (action *APLActionCastSpell) String() string {
 
 type APLActionCastFriendlySpell struct {
 	defaultAPLActionImpl
-	spell  *Spell
-	target UnitReference
+	spell       *Spell
+	target      UnitReference
+	smartTarget bool
+
+	nextTarget *Unit
 }
 
 func (rot *APLRotation) newActionCastFriendlySpell(config *proto.APLActionCastFriendlySpell) APLActionImpl {
@@ -52,15 +58,47 @@ func (rot *APLRotation) newActionCastFriendlySpell(config *proto.APLActionCastFr
 		return nil
 	}
 	return &APLActionCastFriendlySpell{
-		spell:  spell,
-		target: target,
+		spell:       spell,
+		target:      target,
+		smartTarget: config.SmartTarget,
+	}
+}
+func (action *APLActionCastFriendlySpell) Reset(*Simulation) {
+	action.nextTarget = nil
+}
+
+// Returns the active ally with the most missing health, or the configured
+// target if no ally is injured.
+func (action *APLActionCastFriendlySpell) selectTarget(sim *Simulation) *Unit {
+	var bestTarget *Unit
+	bestMissing := 0.0
+	for _, unit := range sim.Raid.GetActiveAllyUnits() {
+		if !unit.HasHealthBar() {
+			continue
+		}
+		missing := unit.MaxHealth() - unit.CurrentHealth()
+		if missing > bestMissing {
+			bestTarget = unit
+			bestMissing = missing
+		}
+	}
+	if bestTarget == nil {
+		return action.target.Get()
 	}
+	return bestTarget
 }
 func (action *APLActionCastFriendlySpell) IsReady(sim *Simulation) bool {
-	return action.spell.CanCastOrQueue(sim, action.target.Get()) && (!action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence)
+	action.nextTarget = action.target.Get()
+	if action.smartTarget {
+		action.nextTarget = action.selectTarget(sim)
+	}
+	return action.spell.CanCastOrQueue(sim, action.nextTarget) && (!action.spell.Flags.Matches(SpellFlagMCD) || action.spell.Unit.GCD.IsReady(sim) || action.spell.Unit.Rotation.inSequence)
+}
+func (action *APLActionCastFriendlySpell) notReadyReason(sim *Simulation) proto.APLNotReadyReason {
+	return aplSpellNotReadyReason(sim, action.spell, action.nextTarget)
 }
 func (action *APLActionCastFriendlySpell) Execute(sim *Simulation) {
-	action.spell.CastOrQueue(sim, action.target.Get())
+	action.spell.CastOrQueue(sim, action.nextTarget)
 }
 func (action *APLActionCastFriendlySpell) String() string {
 	return fmt.Sprintf("Cast Friendly Spell(%s)", action.spell.ActionID)
@@ -109,6 +147,9 @@ func (action *APLActionChannelSpell) GetAPLValues() []APLValue {
 func (action *APLActionChannelSpell) IsReady(sim *Simulation) bool {
 	return action.spell.CanCastOrQueue(sim, action.target.Get())
 }
+func (action *APLActionChannelSpell) notReadyReason(sim *Simulation) proto.APLNotReadyReason {
+	return aplSpellNotReadyReason(sim, action.spell, action.target.Get())
+}
 func (action *APLActionChannelSpell) Execute(sim *Simulation) {
 	action.spell.CastOrQueue(sim, action.target.Get())
 	action.spell.Unit.Rotation.interruptChannelIf = action.interruptIf
@@ -175,8 +216,8 @@ func (action *APLActionMultidot) IsReady(sim *Simulation) bool {
 			}
 		}
 	} else {
-		for i := int32(0); i < action.maxDots; i++ {
-			target := sim.Encounter.TargetUnits[i]
+		for i := int32(0); i < min(action.maxDots, int32(len(sim.Encounter.ActiveTargetUnits))); i++ {
+			target := sim.Encounter.ActiveTargetUnits[i]
 			dot := action.spell.Dot(target)
 			if (!dot.IsActive() || dot.RemainingDuration(sim) < maxOverlap) && action.spell.CanCastOrQueue(sim, target) {
 				action.nextTarget = target
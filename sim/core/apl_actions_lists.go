@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+type aplActionList struct {
+	name    string
+	actions []*APLAction
+
+	// Whether this list (indirectly) calls itself. Such lists are never run.
+	inCycle bool
+
+	// Indices into the rotation config, used for attributing warnings.
+	configIdx  int
+	configIdxs []int
+}
+
+func (rot *APLRotation) getActionList(name string) *aplActionList {
+	for _, list := range rot.actionLists {
+		if list.name == name {
+			return list
+		}
+	}
+	return nil
+}
+
+// Returns the name of the action list referenced by action, if any.
+func referencedActionListName(action *APLAction) string {
+	switch impl := action.impl.(type) {
+	case *APLActionCallActionList:
+		return impl.name
+	case *APLActionRunActionList:
+		return impl.name
+	}
+	return ""
+}
+
+func (rot *APLRotation) markActionListCycles() {
+	for _, list := range rot.actionLists {
+		list.inCycle = rot.actionListReaches(list, list, make(map[*aplActionList]bool))
+	}
+}
+
+func (rot *APLRotation) actionListReaches(from *aplActionList, target *aplActionList, visited map[*aplActionList]bool) bool {
+	for _, action := range from.actions {
+		for _, innerAction := range action.GetAllActions() {
+			next := rot.getActionList(referencedActionListName(innerAction))
+			if next == nil || visited[next] {
+				continue
+			}
+			if next == target {
+				return true
+			}
+			visited[next] = true
+			if rot.actionListReaches(next, target, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type APLActionCallActionList struct {
+	defaultAPLActionImpl
+	rot  *APLRotation
+	name string
+	list *aplActionList
+
+	// The action chosen by the last call to IsReady.
+	nextAction *APLAction
+}
+
+func (rot *APLRotation) newActionCallActionList(config *proto.APLActionCallActionList) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationWarning("Call Action List must provide a list name")
+		return nil
+	}
+	return &APLActionCallActionList{
+		rot:  rot,
+		name: config.Name,
+	}
+}
+func (action *APLActionCallActionList) Finalize(rot *APLRotation) {
+	list := rot.getActionList(action.name)
+	if list == nil {
+		rot.ValidationWarning("No action list with name: '%s'", action.name)
+		return
+	}
+	if list.inCycle {
+		rot.ValidationWarning("Action list '%s' calls itself and will be ignored", action.name)
+		return
+	}
+	action.list = list
+}
+func (action *APLActionCallActionList) Reset(*Simulation) {
+	action.nextAction = nil
+}
+func (action *APLActionCallActionList) IsReady(sim *Simulation) bool {
+	if action.list == nil {
+		return false
+	}
+	action.nextAction = action.rot.getNextActionFromList(sim, action.list.actions)
+	return action.nextAction != nil
+}
+func (action *APLActionCallActionList) Execute(sim *Simulation) {
+	action.nextAction.Execute(sim)
+}
+func (action *APLActionCallActionList) String() string {
+	return fmt.Sprintf("Call Action List(name = '%s')", action.name)
+}
+
+type APLActionRunActionList struct {
+	APLActionCallActionList
+}
+
+func (rot *APLRotation) newActionRunActionList(config *proto.APLActionRunActionList) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationWarning("Run Action List must provide a list name")
+		return nil
+	}
+	return &APLActionRunActionList{
+		APLActionCallActionList: APLActionCallActionList{
+			rot:  rot,
+			name: config.Name,
+		},
+	}
+}
+func (action *APLActionRunActionList) IsReady(sim *Simulation) bool {
+	if action.APLActionCallActionList.IsReady(sim) {
+		return true
+	}
+	if action.list != nil {
+		// Unlike call_action_list, nothing after this action may run.
+		action.rot.haltActionLists = true
+	}
+	return false
+}
+func (action *APLActionRunActionList) String() string {
+	return fmt.Sprintf("Run Action List(name = '%s')", action.name)
+}
@@ -19,11 +19,13 @@ func (rot *APLRotation) newActionChangeTarget(config *proto.APLActionChangeTarge
 		return nil
 	}
 	return &APLActionChangeTarget{
+		unit:      rot.unit,
 		newTarget: newTarget,
 	}
 }
 func (action *APLActionChangeTarget) IsReady(sim *Simulation) bool {
-	return action.unit.CurrentTarget != action.newTarget.Get()
+	newTarget := action.newTarget.Get()
+	return action.unit.CurrentTarget != newTarget && newTarget.IsEnabled()
 }
 func (action *APLActionChangeTarget) Execute(sim *Simulation) {
 	if sim.Log != nil {
@@ -35,6 +37,65 @@ func (action *APLActionChangeTarget) String() string {
 	return fmt.Sprintf("Change Target(%s)", action.newTarget.Get().Label)
 }
 
+type APLActionRetarget struct {
+	defaultAPLActionImpl
+	unit  *Unit
+	value APLValue
+	mode  proto.APLActionRetarget_RetargetMode
+}
+
+func (rot *APLRotation) newActionRetarget(config *proto.APLActionRetarget) APLActionImpl {
+	if config.Mode == proto.APLActionRetarget_ModeUnknown {
+		rot.ValidationWarning("No retarget mode selected")
+		return nil
+	}
+	value := rot.coerceTo(rot.newTargetQuantifierValue(config.Value), proto.APLValueType_ValueTypeFloat)
+	if value == nil {
+		return nil
+	}
+	return &APLActionRetarget{
+		unit:  rot.unit,
+		value: value,
+		mode:  config.Mode,
+	}
+}
+func (action *APLActionRetarget) GetAPLValues() []APLValue {
+	return []APLValue{action.value}
+}
+
+// Returns the active target with the best value. The current target is kept
+// on ties, so that equally good targets don't cause target swapping.
+func (action *APLActionRetarget) bestTarget(sim *Simulation) *Unit {
+	takeMax := action.mode == proto.APLActionRetarget_ModeMax
+	var bestTarget *Unit
+	var bestValue float64
+	// forEachActiveTarget changes CurrentTarget while iterating.
+	currentTarget := action.unit.CurrentTarget
+	forEachActiveTarget(sim, action.unit, func(target *Unit) {
+		val := action.value.GetFloat(sim)
+		if bestTarget == nil || (takeMax && val > bestValue) || (!takeMax && val < bestValue) ||
+			(val == bestValue && target == currentTarget) {
+			bestTarget = target
+			bestValue = val
+		}
+	})
+	return bestTarget
+}
+func (action *APLActionRetarget) IsReady(sim *Simulation) bool {
+	newTarget := action.bestTarget(sim)
+	return newTarget != nil && newTarget != action.unit.CurrentTarget
+}
+func (action *APLActionRetarget) Execute(sim *Simulation) {
+	newTarget := action.bestTarget(sim)
+	if sim.Log != nil {
+		action.unit.Log(sim, "Changing target to %s", newTarget.Label)
+	}
+	action.unit.CurrentTarget = newTarget
+}
+func (action *APLActionRetarget) String() string {
+	return fmt.Sprintf("Retarget(%s %s)", action.mode, action.value)
+}
+
 type APLActionCancelAura struct {
 	defaultAPLActionImpl
 	aura *Aura
@@ -256,3 +317,60 @@ func (action *APLActionMoveDuration) IsReady(sim *Simulation) bool {
 func (action *APLActionMoveDuration) String() string {
 	return "MoveDuration()"
 }
+
+type APLActionSetVariable struct {
+	defaultAPLActionImpl
+	rot      *APLRotation
+	variable *aplVariable
+	value    APLValue
+
+	// Rotation evaluation in which the variable was last set.
+	lastEvaluation int
+}
+
+func (rot *APLRotation) newActionSetVariable(config *proto.APLActionSetVariable) APLActionImpl {
+	if config.Name == "" {
+		rot.ValidationWarning("Set Variable must provide a variable name")
+		return nil
+	}
+	variable := rot.getVariable(config.Name)
+	if variable == nil {
+		return nil
+	}
+	value := rot.coerceTo(rot.newAPLValue(config.Value), variable.value.Type())
+	if value == nil {
+		rot.ValidationWarning("Set Variable must provide a value")
+		return nil
+	}
+	return &APLActionSetVariable{
+		rot:      rot,
+		variable: variable,
+		value:    value,
+	}
+}
+func (action *APLActionSetVariable) GetAPLValues() []APLValue {
+	return []APLValue{action.value}
+}
+func (action *APLActionSetVariable) Reset(*Simulation) {
+	action.variable.override = nil
+	action.lastEvaluation = -1
+}
+
+// Only ready when the stored value would change, so that the rotation moves
+// on to the next action afterwards instead of setting the variable forever.
+// Values which refer to the variable itself, e.g. x = x + 1, change each
+// time, so the variable is set at most once per rotation evaluation.
+func (action *APLActionSetVariable) IsReady(sim *Simulation) bool {
+	if action.lastEvaluation == action.rot.numEvaluations {
+		return false
+	}
+	override := action.variable.override
+	return override == nil || *override != *snapshotAPLValue(sim, action.value)
+}
+func (action *APLActionSetVariable) Execute(sim *Simulation) {
+	action.variable.override = snapshotAPLValue(sim, action.value)
+	action.lastEvaluation = action.rot.numEvaluations
+}
+func (action *APLActionSetVariable) String() string {
+	return fmt.Sprintf("Set Variable(%s = %s)", action.variable.name, action.value)
+}
@@ -0,0 +1,205 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Lints are warnings for rotations which are valid, but probably don't do what
+// the user intended. Unlike validation warnings they need the whole rotation,
+// so they are computed once it has been finalized. Each list is indexed the
+// same way as the corresponding list in the rotation config.
+type aplLints struct {
+	prepull      [][]string
+	priorityList [][]string
+	actionLists  [][][]string
+	variables    [][]string
+}
+
+func (rot *APLRotation) lint() *aplLints {
+	lints := &aplLints{
+		prepull:      make([][]string, len(rot.prepullWarnings)),
+		priorityList: make([][]string, len(rot.priorityListWarnings)),
+		actionLists:  make([][][]string, len(rot.actionListItemWarnings)),
+		variables:    make([][]string, len(rot.variableWarnings)),
+	}
+	for i, itemWarnings := range rot.actionListItemWarnings {
+		lints.actionLists[i] = make([][]string, len(itemWarnings))
+	}
+
+	for i, action := range rot.prepullActions {
+		idx := rot.prepullConfigIdxs[i]
+		// Actions scheduled after the pull are dropped by validation, but a cast
+		// started before it can still run into the fight.
+		if castSpell, ok := action.impl.(*APLActionCastSpell); ok {
+			if castEnd := rot.prepullDoAts[i] + castSpell.spell.CastTime(); castEnd > 0 {
+				lints.prepull[idx] = append(lints.prepull[idx], fmt.Sprintf("Cast finishes at %s, after the pull", castEnd))
+			}
+		}
+		lints.prepull[idx] = append(lints.prepull[idx], lintAPLValues(action.GetAllAPLValues())...)
+		lints.prepull[idx] = append(lints.prepull[idx], rot.lintAutocastConflicts(action)...)
+	}
+
+	lintList := func(actions []*APLAction, configIdxs []int, itemLints [][]string) {
+		var blockedBy *APLAction
+		for i, action := range actions {
+			idx := configIdxs[i]
+			if blockedBy != nil {
+				itemLints[idx] = append(itemLints[idx], fmt.Sprintf("Never runs, because '%s' above it is always ready", blockedBy.impl))
+			}
+			if action.condition != nil && aplValueAlwaysFalse(action.condition) {
+				itemLints[idx] = append(itemLints[idx], "Condition is always false, so this action never runs")
+			}
+			itemLints[idx] = append(itemLints[idx], lintAPLValues(action.GetAllAPLValues())...)
+			itemLints[idx] = append(itemLints[idx], rot.lintAutocastConflicts(action)...)
+			if blockedBy == nil && aplActionAlwaysFires(action) {
+				blockedBy = action
+			}
+		}
+	}
+	lintList(rot.priorityList, rot.priorityListConfigIdxs, lints.priorityList)
+	for _, list := range rot.actionLists {
+		lintList(list.actions, list.configIdxs, lints.actionLists[list.configIdx])
+	}
+
+	for _, variable := range rot.variables {
+		if variable != nil {
+			values := []APLValue{variable.value}
+			for j := 0; j < len(values); j++ {
+				values = append(values, values[j].GetInnerValues()...)
+			}
+			lints.variables[variable.configIdx] = append(lints.variables[variable.configIdx], lintAPLValues(values)...)
+		}
+	}
+
+	return lints
+}
+
+// Returns whether the action is selected whenever it is reached, or stops the
+// actions after it from being considered.
+func aplActionAlwaysFires(action *APLAction) bool {
+	if action.condition != nil && !aplValueAlwaysTrue(action.condition) {
+		return false
+	}
+	switch impl := action.impl.(type) {
+	case *APLActionWait:
+		return isConstantAPLValue(impl.duration) && impl.duration.GetDuration(nil) > 0
+	case *APLActionRunActionList:
+		return impl.list != nil
+	case *APLActionCallActionList:
+		return impl.list != nil && slices.ContainsFunc(impl.list.actions, aplActionAlwaysFires)
+	}
+	return false
+}
+
+// Returns whether value only depends on constants, so it can be evaluated
+// without a sim.
+func isConstantAPLValue(value APLValue) bool {
+	switch value.(type) {
+	case *APLValueConst:
+		return true
+	case *APLValueCoerced, *APLValueCompare, *APLValueMath, *APLValueMax, *APLValueMin, *APLValueAnd, *APLValueOr, *APLValueNot:
+		for _, inner := range value.GetInnerValues() {
+			if inner == nil || !isConstantAPLValue(inner) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func aplValueAlwaysFalse(value APLValue) bool {
+	if isConstantAPLValue(value) {
+		return !value.GetBool(nil)
+	}
+	switch v := value.(type) {
+	case *APLValueAnd:
+		return slices.ContainsFunc(v.vals, aplValueAlwaysFalse)
+	case *APLValueOr:
+		return !slices.ContainsFunc(v.vals, func(val APLValue) bool { return !aplValueAlwaysFalse(val) })
+	case *APLValueNot:
+		return aplValueAlwaysTrue(v.val)
+	}
+	return false
+}
+
+func aplValueAlwaysTrue(value APLValue) bool {
+	if isConstantAPLValue(value) {
+		return value.GetBool(nil)
+	}
+	switch v := value.(type) {
+	case *APLValueAnd:
+		return !slices.ContainsFunc(v.vals, func(val APLValue) bool { return !aplValueAlwaysTrue(val) })
+	case *APLValueOr:
+		return slices.ContainsFunc(v.vals, aplValueAlwaysTrue)
+	case *APLValueNot:
+		return aplValueAlwaysFalse(v.val)
+	}
+	return false
+}
+
+// Returns the type of value before it was coerced for use in an operator.
+func uncoercedAPLValueType(value APLValue) proto.APLValueType {
+	switch v := value.(type) {
+	case *APLValueCoerced:
+		return uncoercedAPLValueType(v.inner)
+	case *APLValueConst:
+		// Coerced constants are copied with a new type, so parse them again.
+		return (&APLRotation{}).newValueConst(&proto.APLValueConst{Val: v.stringVal}).Type()
+	}
+	return value.Type()
+}
+
+func aplValueTypesCompatible(type1 proto.APLValueType, type2 proto.APLValueType) bool {
+	isNumeric := func(valType proto.APLValueType) bool {
+		return valType == proto.APLValueType_ValueTypeInt || valType == proto.APLValueType_ValueTypeFloat || valType == proto.APLValueType_ValueTypeDuration
+	}
+	return type1 == type2 || (isNumeric(type1) && isNumeric(type2))
+}
+
+func lintAPLValues(values []APLValue) []string {
+	var lints []string
+	for _, value := range values {
+		if compare, ok := value.(*APLValueCompare); ok {
+			lhsType, rhsType := uncoercedAPLValueType(compare.lhs), uncoercedAPLValueType(compare.rhs)
+			if !aplValueTypesCompatible(lhsType, rhsType) {
+				lints = append(lints, fmt.Sprintf("Comparing incompatible types %s and %s in %s", lhsType, rhsType, compare))
+			}
+		}
+	}
+	return lints
+}
+
+// Flags MCD spells which are cast by action, but are still left for Autocast
+// Other Cooldowns to use. Only Cast Spell and Cast Friendly Spell actions
+// remove their spell from the autocast list.
+func (rot *APLRotation) lintAutocastConflicts(action *APLAction) []string {
+	agent := rot.unit.Env.GetAgentFromUnit(rot.unit)
+	if agent == nil || !slices.ContainsFunc(rot.allAPLActions(), func(action *APLAction) bool {
+		_, ok := action.impl.(*APLActionAutocastOtherCooldowns)
+		return ok
+	}) {
+		return nil
+	}
+	character := agent.GetCharacter()
+
+	var lints []string
+	for _, innerAction := range action.GetAllActions() {
+		var spell *Spell
+		switch impl := innerAction.impl.(type) {
+		case *APLActionChannelSpell:
+			spell = impl.spell
+		case *APLActionMultidot:
+			spell = impl.spell
+		case *APLActionMultishield:
+			spell = impl.spell
+		}
+		if spell != nil && spell.Flags.Matches(SpellFlagMCD) && character.GetInitialMajorCooldown(spell.ActionID).Spell != nil {
+			lints = append(lints, fmt.Sprintf("%s is cast both explicitly and by Autocast Other Cooldowns", spell.ActionID))
+		}
+	}
+	return lints
+}
@@ -0,0 +1,422 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func newTestAPLRotation(t *testing.T, jsonString string) (*Simulation, *APLRotation) {
+	sim := SetupFakeSim()
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	// Prepull actions can only be registered before the environment is finalized.
+	sim.State = Initialized
+	rot := unit.newAPLRotation(APLRotationFromJsonString(jsonString))
+	sim.State = Finalized
+	rot.reset(sim)
+	return sim, rot
+}
+
+func setVariableName(action *APLAction) string {
+	if action == nil {
+		return ""
+	}
+	return action.impl.(*APLActionSetVariable).variable.name
+}
+
+func TestAPLVariables(t *testing.T) {
+	sim, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [
+			{"name": "above", "value": {"cmp": {"op": "OpGt", "lhs": {"variable": {"name": "threshold"}}, "rhs": {"const": {"val": "3"}}}}},
+			{"name": "threshold", "value": {"const": {"val": "5"}}}
+		],
+		"priorityList": [
+			{"action": {
+				"condition": {"variable": {"name": "above"}},
+				"setVariable": {"name": "threshold", "value": {"const": {"val": "1"}}}
+			}}
+		]
+	}`)
+
+	next := rot.getNextAction(sim)
+	if setVariableName(next) != "threshold" {
+		t.Fatalf("Expected set_variable to be ready, got %v", next)
+	}
+	next.Execute(sim)
+
+	// The override makes 'above' false, and set_variable is not ready again
+	// because the value would not change.
+	if next := rot.getNextAction(sim); next != nil {
+		t.Fatalf("Expected no action after overriding the variable, got %v", next)
+	}
+
+	rot.reset(sim)
+	if next := rot.getNextAction(sim); setVariableName(next) != "threshold" {
+		t.Fatalf("Expected reset to clear the variable override, got %v", next)
+	}
+}
+
+func TestAPLSelfReferentialVariable(t *testing.T) {
+	sim, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [{"name": "x", "value": {"const": {"val": "0"}}}],
+		"priorityList": [
+			{"action": {"setVariable": {"name": "x", "value": {"math": {"op": "OpAdd", "lhs": {"variable": {"name": "x"}}, "rhs": {"const": {"val": "1"}}}}}}}
+		]
+	}`)
+
+	// x = x + 1 always changes the value, so it is only set once per
+	// evaluation instead of looping until the infinite loop check panics.
+	for i := 1; i <= 3; i++ {
+		sim.CurrentTime = max(sim.CurrentTime, rot.unit.RotationTimer.ReadyAt())
+		rot.DoNextAction(sim)
+		if x := rot.variables["x"].current().GetDuration(sim); x != time.Duration(i)*time.Second {
+			t.Fatalf("Expected x to be %ds after %d evaluations, got %s", i, i, x)
+		}
+	}
+}
+
+func TestAPLActionLists(t *testing.T) {
+	for _, tc := range []struct {
+		kind     string
+		expected string
+	}{
+		{kind: "callActionList", expected: "fallback"},
+		{kind: "runActionList", expected: ""},
+	} {
+		sim, rot := newTestAPLRotation(t, `{
+			"type": "TypeAPL",
+			"variables": [
+				{"name": "fallback", "value": {"const": {"val": "0"}}},
+				{"name": "inner", "value": {"const": {"val": "0"}}}
+			],
+			"actionLists": [
+				{"name": "nothing_ready", "items": [
+					{"action": {"condition": {"const": {"val": "false"}}, "setVariable": {"name": "inner", "value": {"const": {"val": "1"}}}}}
+				]}
+			],
+			"priorityList": [
+				{"action": {"`+tc.kind+`": {"name": "nothing_ready"}}},
+				{"action": {"setVariable": {"name": "fallback", "value": {"const": {"val": "1"}}}}}
+			]
+		}`)
+
+		if next := rot.getNextAction(sim); setVariableName(next) != tc.expected {
+			t.Fatalf("%s: expected next action to set '%s', got %v", tc.kind, tc.expected, next)
+		}
+	}
+}
+
+func TestAPLCallActionList(t *testing.T) {
+	sim, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [{"name": "x", "value": {"const": {"val": "2"}}}],
+		"actionLists": [
+			{"name": "inner", "items": [
+				{"action": {"setVariable": {"name": "x", "value": {"const": {"val": "1"}}}}}
+			]}
+		],
+		"priorityList": [
+			{"action": {"callActionList": {"name": "inner"}}}
+		]
+	}`)
+
+	next := rot.getNextAction(sim)
+	if _, ok := next.impl.(*APLActionCallActionList); !ok {
+		t.Fatalf("Expected call_action_list to be ready, got %v", next)
+	}
+	next.Execute(sim)
+	if x := rot.variables["x"].current().GetInt(sim); x != 1 {
+		t.Fatalf("Expected inner list to set x to 1, got %d", x)
+	}
+}
+
+func TestAPLListAndVariableWarnings(t *testing.T) {
+	_, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [
+			{"name": "a", "value": {"variable": {"name": "b"}}},
+			{"name": "b", "value": {"variable": {"name": "a"}}}
+		],
+		"actionLists": [
+			{"name": "loop1", "items": [{"action": {"callActionList": {"name": "loop2"}}}]},
+			{"name": "loop2", "items": [{"action": {"runActionList": {"name": "loop1"}}}]}
+		],
+		"priorityList": [
+			{"action": {"callActionList": {"name": "loop1"}}},
+			{"action": {"callActionList": {"name": "missing"}}},
+			{"action": {"condition": {"variable": {"name": "undefined"}}, "callActionList": {"name": "loop2"}}}
+		]
+	}`)
+	stats := rot.getStats()
+
+	expectWarning := func(warnings []string, expected string) {
+		for _, warning := range warnings {
+			if strings.Contains(warning, expected) {
+				return
+			}
+		}
+		t.Fatalf("Expected a warning containing %q, got %v", expected, warnings)
+	}
+	expectWarning(stats.Variables[0].Warnings, "Variable 'a' refers to itself")
+	expectWarning(stats.PriorityList[0].Warnings, "Action list 'loop1' calls itself")
+	expectWarning(stats.PriorityList[1].Warnings, "No action list with name: 'missing'")
+	expectWarning(stats.PriorityList[2].Warnings, "No variable with name: 'undefined'")
+	expectWarning(stats.ActionLists[0].Items[0].Warnings, "Action list 'loop2' calls itself")
+}
+
+func TestAPLTrace(t *testing.T) {
+	sim, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [{"name": "x", "value": {"const": {"val": "2"}}}],
+		"priorityList": [
+			{"action": {"condition": {"and": {"vals": [{"const": {"val": "false"}}, {"const": {"val": "true"}}]}}, "setVariable": {"name": "x", "value": {"const": {"val": "3"}}}}},
+			{"action": {"setVariable": {"name": "x", "value": {"const": {"val": "1"}}}}}
+		]
+	}`)
+	sim.aplTrace = &aplTrace{recordEvaluations: true}
+
+	for next := rot.getNextAction(sim); next != nil; next = rot.getNextAction(sim) {
+		next.Execute(sim)
+	}
+
+	evaluations := sim.aplTrace.evaluations
+	if len(evaluations) != 2 || !evaluations[0].Executed || evaluations[1].SelectedAction != "" {
+		t.Fatalf("Unexpected evaluations: %v", evaluations)
+	}
+	checks := evaluations[0].Checks
+	if len(checks) != 2 || checks[0].Outcome != proto.APLTraceOutcome_APLTraceOutcomeConditionFalse || checks[1].Outcome != proto.APLTraceOutcome_APLTraceOutcomeSelected {
+		t.Fatalf("Unexpected checks: %v", checks)
+	}
+	// The second AND value is never evaluated.
+	if condition := checks[0].Condition; len(condition) != 2 || condition[0].Result != "false" || condition[1].Result != "false" || condition[1].Depth != 1 {
+		t.Fatalf("Unexpected condition values: %v", checks[0].Condition)
+	}
+
+	expected := []aplActionTraceCounts{
+		{evaluated: 2, conditionFalse: 2},
+		{evaluated: 2, notReady: 1, executed: 1},
+	}
+	for i, action := range rot.priorityList {
+		if action.traceCounts != expected[i] {
+			t.Fatalf("Unexpected counts for action %d: %+v", i, action.traceCounts)
+		}
+	}
+}
+
+func TestAPLLint(t *testing.T) {
+	sim, rot := newTestAPLRotation(t, `{
+		"type": "TypeAPL",
+		"variables": [{"name": "x", "value": {"const": {"val": "2"}}}],
+		"prepullActions": [
+			{"action": {"setVariable": {"name": "x", "value": {"const": {"val": "5"}}}}, "doAtValue": {"const": {"val": "1s"}}},
+			{"action": {"castSpell": {"spellId": {"spellId": 42}}}, "doAtValue": {"const": {"val": "-1s"}}}
+		],
+		"priorityList": [
+			{"action": {
+				"condition": {"and": {"vals": [{"variable": {"name": "x"}}, {"not": {"val": {"const": {"val": "true"}}}}]}},
+				"setVariable": {"name": "x", "value": {"const": {"val": "3"}}}
+			}},
+			{"action": {
+				"condition": {"cmp": {"op": "OpEq", "lhs": {"currentTime": {}}, "rhs": {"const": {"val": "abc"}}}},
+				"setVariable": {"name": "x", "value": {"const": {"val": "4"}}}
+			}},
+			{"action": {"wait": {"duration": {"const": {"val": "1s"}}}}},
+			{"action": {"setVariable": {"name": "x", "value": {"const": {"val": "1"}}}}}
+		]
+	}`)
+	sim.Raid.Parties[0].Players[0].(*FakeAgent).Spell.DefaultCast.CastTime = time.Second * 2
+	stats := rot.getStats()
+
+	expectLints := func(lints []string, expected ...string) {
+		if len(lints) != len(expected) {
+			t.Fatalf("Expected %d lints, got %v", len(expected), lints)
+		}
+		for i, lint := range lints {
+			if !strings.Contains(lint, expected[i]) {
+				t.Fatalf("Expected a lint containing %q, got %q", expected[i], lint)
+			}
+		}
+	}
+	// Prepull actions after the pull are dropped by validation, so aren't linted.
+	expectLints(stats.PrepullActions[0].Warnings, "Invalid time for 'Do At'")
+	expectLints(stats.PrepullActions[0].Lints)
+	expectLints(stats.PrepullActions[1].Lints, "Cast finishes at 1s, after the pull")
+	expectLints(stats.PriorityList[0].Lints, "Condition is always false")
+	expectLints(stats.PriorityList[1].Lints, "Comparing incompatible types ValueTypeDuration and ValueTypeString")
+	expectLints(stats.PriorityList[2].Lints)
+	expectLints(stats.PriorityList[3].Lints, "Never runs")
+
+	// Multidot leaves its spell on the autocast list, unlike Cast Spell, so it
+	// would be cast by both actions.
+	caster := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	sim.State = Initialized
+	caster.AddMajorCooldown(MajorCooldown{Spell: caster.Spell})
+	autocastRot := caster.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"priorityList": [
+			{"action": {"multidot": {"spellId": {"spellId": 42}, "maxDots": 1}}},
+			{"action": {"autocastOtherCooldowns": {}}}
+		]
+	}`))
+	sim.State = Finalized
+	autocastStats := autocastRot.getStats()
+	expectLints(autocastStats.PriorityList[0].Lints, "is cast both explicitly and by Autocast Other Cooldowns")
+	expectLints(autocastStats.PriorityList[1].Lints)
+}
+
+func TestAPLResourcePrediction(t *testing.T) {
+	sim := SetupFakeSim()
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	unit.energyBar = energyBar{
+		unit:                  unit,
+		maxEnergy:             100,
+		currentEnergy:         50,
+		nextEnergyTick:        sim.CurrentTime + time.Millisecond*50,
+		EnergyTickDuration:    time.Millisecond * 100,
+		EnergyPerTick:         1,
+		energyRegenMultiplier: 1,
+		hasteRatingMultiplier: 1.5,
+	}
+	unit.runicPowerBar = runicPowerBar{
+		unit:                unit,
+		runeCD:              time.Second * 10,
+		runeRegenMultiplier: 1,
+		runeStates:          isSpents[2] | isSpents[3],
+	}
+	for i := range unit.runeMeta {
+		unit.runeMeta[i].regenAt = NeverExpires
+	}
+	unit.runeMeta[2].regenAt = sim.CurrentTime + time.Second*4
+
+	rot := unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"variables": [
+			{"name": "energy_in_1s", "value": {"resourceAtTime": {"resource": "ResourceEnergy", "delay": {"const": {"val": "1s"}}}}},
+			{"name": "time_to_80", "value": {"resourceTimeToValue": {"resource": "ResourceEnergy", "amount": {"const": {"val": "80"}}}}},
+			{"name": "time_to_max", "value": {"resourceTimeToValue": {"resource": "ResourceEnergy", "amount": {"const": {"val": "101"}}}}},
+			{"name": "frost_in_5s", "value": {"resourceAtTime": {"resource": "ResourceFrostRunes", "delay": {"const": {"val": "5s"}}}}},
+			{"name": "time_to_2_frost", "value": {"resourceTimeToValue": {"resource": "ResourceFrostRunes", "amount": {"const": {"val": "2"}}}}},
+			{"name": "time_to_2_blood", "value": {"resourceTimeToValue": {"resource": "ResourceBloodRunes", "amount": {"const": {"val": "2"}}}}},
+			{"name": "focus", "value": {"resourceAtTime": {"resource": "ResourceFocus", "delay": {"const": {"val": "1s"}}}}}
+		],
+		"priorityList": [
+			{"action": {"waitUntil": {"condition": {"cmp": {"op": "OpLe",
+				"lhs": {"resourceTimeToValue": {"resource": "ResourceEnergy", "amount": {"const": {"val": "80"}}}},
+				"rhs": {"const": {"val": "1s"}}
+			}}}}}
+		]
+	}`))
+	rot.reset(sim)
+
+	if value := rot.variables["energy_in_1s"].value.GetFloat(sim); value != 65 {
+		t.Fatalf("Expected 65 energy after 1s, got %f", value)
+	}
+	if value := rot.variables["time_to_80"].value.GetDuration(sim); value != time.Millisecond*1950 {
+		t.Fatalf("Expected 1.95s until 80 energy, got %s", value)
+	}
+	if value := rot.variables["time_to_max"].value.GetDuration(sim); value != NeverExpires {
+		t.Fatalf("Expected energy above the maximum to never be reached, got %s", value)
+	}
+	if value := rot.variables["frost_in_5s"].value.GetInt(sim); value != 1 {
+		t.Fatalf("Expected 1 frost rune after 5s, got %d", value)
+	}
+	// The second frost rune only starts regenerating once the first is ready.
+	if value := rot.variables["time_to_2_frost"].value.GetDuration(sim); value != time.Second*14 {
+		t.Fatalf("Expected 14s until 2 frost runes, got %s", value)
+	}
+	if value := rot.variables["time_to_2_blood"].value.GetDuration(sim); value != 0 {
+		t.Fatalf("Expected blood runes to be ready, got %s", value)
+	}
+	if variable := rot.variables["focus"]; variable != nil && variable.value != nil {
+		t.Fatalf("Expected focus to be invalid for a unit without a focus bar")
+	}
+
+	if next := rot.getNextAction(sim); next == nil {
+		t.Fatalf("Expected to wait until energy is pooled")
+	} else if _, ok := next.impl.(*APLActionWaitUntil); !ok {
+		t.Fatalf("Expected to wait until energy is pooled, got %v", next)
+	}
+}
+
+func TestAPLTargetAggregates(t *testing.T) {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{RandomSeed: 100},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: []*proto.Player{{
+					Name:      "Caster",
+					Class:     proto.Class_ClassShaman,
+					Consumes:  &proto.Consumes{},
+					Buffs:     &proto.IndividualBuffs{},
+					Spec:      &proto.Player_ElementalShaman{},
+					Equipment: &proto.EquipmentSpec{},
+				}},
+				Buffs: &proto.PartyBuffs{},
+			}},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target 1", Level: 88},
+				{Name: "target 2", Level: 88},
+				{Name: "target 3", Level: 88},
+			},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+	for i, health := range []float64{100, 300, 200} {
+		target := sim.Encounter.TargetUnits[i]
+		target.healthBar = healthBar{unit: target, currentHealth: health}
+	}
+
+	unit := &sim.Raid.Parties[0].Players[0].GetCharacter().Unit
+	targetHealth := `{"currentHealth": {"sourceUnit": {"type": "CurrentTarget"}}}`
+	rot := unit.newAPLRotation(APLRotationFromJsonString(`{
+		"type": "TypeAPL",
+		"variables": [
+			{"name": "count", "value": {"countTargetsWhere": {"condition": {"cmp": {"op": "OpGt", "lhs": ` + targetHealth + `, "rhs": {"const": {"val": "150"}}}}}}},
+			{"name": "min", "value": {"minOverTargets": {"val": ` + targetHealth + `}}},
+			{"name": "max", "value": {"maxOverTargets": {"val": ` + targetHealth + `}}}
+		],
+		"priorityList": [
+			{"action": {"retarget": {"mode": "ModeMax", "value": ` + targetHealth + `}}}
+		]
+	}`))
+	rot.reset(sim)
+
+	oldTarget := unit.CurrentTarget
+	if value := rot.variables["count"].value.GetInt(sim); value != 2 {
+		t.Fatalf("Expected 2 targets above 150 health, got %d", value)
+	}
+	if value := rot.variables["min"].value.GetFloat(sim); value != 100 {
+		t.Fatalf("Expected min health of 100, got %f", value)
+	}
+	if value := rot.variables["max"].value.GetFloat(sim); value != 300 {
+		t.Fatalf("Expected max health of 300, got %f", value)
+	}
+	if unit.CurrentTarget != oldTarget {
+		t.Fatalf("Expected the current target to be restored after evaluation")
+	}
+
+	next := rot.getNextAction(sim)
+	if next == nil {
+		t.Fatalf("Expected retarget to be ready")
+	}
+	next.Execute(sim)
+	if unit.CurrentTarget != sim.Encounter.TargetUnits[1] {
+		t.Fatalf("Expected to retarget to the target with the most health, got %s", unit.CurrentTarget.Label)
+	}
+	if next := rot.getNextAction(sim); next != nil {
+		t.Fatalf("Expected retarget not to be ready on the best target, got %v", next)
+	}
+
+	// A later target with the same value doesn't cause a swap.
+	sim.Encounter.TargetUnits[2].healthBar.currentHealth = 300
+	if next := rot.getNextAction(sim); next != nil {
+		t.Fatalf("Expected retarget not to be ready when tied with the current target, got %v", next)
+	}
+}
@@ -0,0 +1,221 @@
+package core
+
+import (
+	"strconv"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Records APL rotation decisions, see SimOptions.apl_trace. Action counts are
+// kept over all iterations, but individual evaluations are only recorded for
+// the first one. sim.aplTrace is nil whenever tracing is disabled.
+type aplTrace struct {
+	recordEvaluations bool
+	evaluations       []*proto.APLTraceEvaluation
+
+	// The evaluation in progress, and the last one which selected an action
+	// that has not been executed yet.
+	current *proto.APLTraceEvaluation
+	pending *proto.APLTraceEvaluation
+
+	// Nesting level of the action list being evaluated.
+	depth int32
+}
+
+type aplActionTraceCounts struct {
+	evaluated      int64
+	conditionFalse int64
+	notReady       int64
+	executed       int64
+}
+
+// Implemented by action impls which can explain why IsReady returned false.
+type aplNotReadyReasoner interface {
+	notReadyReason(sim *Simulation) proto.APLNotReadyReason
+}
+
+func (trace *aplTrace) getNextAction(sim *Simulation, rot *APLRotation) *APLAction {
+	if !trace.recordEvaluations {
+		return trace.getNextActionFromList(sim, rot, rot.priorityList)
+	}
+
+	evaluation := &proto.APLTraceEvaluation{
+		Timestamp: sim.CurrentTime.Seconds(),
+		UnitIndex: rot.unit.UnitIndex,
+	}
+	trace.current = evaluation
+	action := trace.getNextActionFromList(sim, rot, rot.priorityList)
+	trace.current = nil
+
+	if action != nil {
+		evaluation.SelectedAction = action.impl.String()
+		trace.pending = evaluation
+	}
+	trace.evaluations = append(trace.evaluations, evaluation)
+	return action
+}
+
+// Same as APLRotation.getNextActionFromList, but counts and records the
+// outcome for each action.
+func (trace *aplTrace) getNextActionFromList(sim *Simulation, rot *APLRotation, actions []*APLAction) *APLAction {
+	depth := trace.depth
+	trace.depth++
+	defer func() { trace.depth-- }()
+
+	for _, action := range actions {
+		action.traceCounts.evaluated++
+
+		var check *proto.APLTraceCheck
+		if trace.current != nil {
+			// Added before checking readiness, so that checks from nested
+			// lists come after the action which called them.
+			check = &proto.APLTraceCheck{
+				Action: action.impl.String(),
+				Depth:  depth,
+			}
+			if action.condition != nil {
+				check.Condition = traceConditionValues(sim, action.condition)
+			}
+			trace.current.Checks = append(trace.current.Checks, check)
+		}
+
+		if action.condition != nil && !action.condition.GetBool(sim) {
+			action.traceCounts.conditionFalse++
+			if check != nil {
+				check.Outcome = proto.APLTraceOutcome_APLTraceOutcomeConditionFalse
+			}
+		} else if !action.impl.IsReady(sim) {
+			action.traceCounts.notReady++
+			if check != nil {
+				check.Outcome = proto.APLTraceOutcome_APLTraceOutcomeNotReady
+				if reasoner, ok := action.impl.(aplNotReadyReasoner); ok {
+					check.NotReadyReason = reasoner.notReadyReason(sim)
+				}
+			}
+		} else {
+			if check != nil {
+				check.Outcome = proto.APLTraceOutcome_APLTraceOutcomeSelected
+			}
+			return action
+		}
+
+		if rot.haltActionLists {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (trace *aplTrace) actionExecuted(action *APLAction) {
+	action.traceCounts.executed++
+	if trace.pending != nil {
+		trace.pending.Executed = true
+		trace.pending = nil
+	}
+}
+
+// Evaluates condition and its inner values, in depth-first order. Like the
+// real evaluation, AND and OR stop at the first inner value which decides
+// their result, so the values after it are left out.
+func traceConditionValues(sim *Simulation, condition APLValue) []*proto.APLTraceValue {
+	var values []*proto.APLTraceValue
+	var visit func(value APLValue, depth int32)
+	visit = func(value APLValue, depth int32) {
+		if value == nil {
+			return
+		}
+		values = append(values, &proto.APLTraceValue{
+			Value:  value.String(),
+			Result: formatAPLValueResult(sim, value),
+			Depth:  depth,
+		})
+		for _, inner := range value.GetInnerValues() {
+			visit(inner, depth+1)
+			if shortCircuits(sim, value, inner) {
+				break
+			}
+		}
+	}
+	visit(condition, 0)
+	return values
+}
+
+// Returns whether inner decides the result of value, so that the inner values
+// after it are never evaluated.
+func shortCircuits(sim *Simulation, value APLValue, inner APLValue) bool {
+	switch value.(type) {
+	case *APLValueAnd:
+		return !inner.GetBool(sim)
+	case *APLValueOr:
+		return inner.GetBool(sim)
+	}
+	return false
+}
+
+func formatAPLValueResult(sim *Simulation, value APLValue) string {
+	switch value.Type() {
+	case proto.APLValueType_ValueTypeBool:
+		return strconv.FormatBool(value.GetBool(sim))
+	case proto.APLValueType_ValueTypeInt:
+		return strconv.Itoa(int(value.GetInt(sim)))
+	case proto.APLValueType_ValueTypeFloat:
+		return strconv.FormatFloat(value.GetFloat(sim), 'g', 6, 64)
+	case proto.APLValueType_ValueTypeDuration:
+		return value.GetDuration(sim).String()
+	case proto.APLValueType_ValueTypeString:
+		return value.GetString(sim)
+	}
+	return ""
+}
+
+// Explains why CanCastOrQueue, or the extra GCD check for MCDs, failed.
+func aplSpellNotReadyReason(sim *Simulation, spell *Spell, target *Unit) proto.APLNotReadyReason {
+	if !spell.Unit.CanQueueSpell(sim) {
+		return proto.APLNotReadyReason_APLNotReadySpellQueued
+	}
+	switch spell.queueFailure(sim, target) {
+	case castFailureCastCondition:
+		return proto.APLNotReadyReason_APLNotReadyCastCondition
+	case castFailureCasting:
+		return proto.APLNotReadyReason_APLNotReadyCasting
+	case castFailureGCD:
+		return proto.APLNotReadyReason_APLNotReadyGCD
+	case castFailureCooldown:
+		return proto.APLNotReadyReason_APLNotReadyCooldown
+	case castFailureResource:
+		return proto.APLNotReadyReason_APLNotReadyResource
+	}
+	if spell.Flags.Matches(SpellFlagMCD) && !spell.Unit.GCD.IsReady(sim) {
+		return proto.APLNotReadyReason_APLNotReadyGCD
+	}
+	return proto.APLNotReadyReason_APLNotReadyUnknown
+}
+
+func (trace *aplTrace) fillResult(sim *Simulation, result *proto.RaidSimResult) {
+	result.AplTrace = &proto.APLTrace{
+		Evaluations: trace.evaluations,
+	}
+	addCounts := func(unit *Unit, list string, actions []*APLAction) {
+		for i, action := range actions {
+			result.AplTrace.ActionCounts = append(result.AplTrace.ActionCounts, &proto.APLTraceActionCounts{
+				UnitIndex:      unit.UnitIndex,
+				List:           list,
+				Index:          int32(i),
+				Action:         action.impl.String(),
+				Evaluated:      action.traceCounts.evaluated,
+				ConditionFalse: action.traceCounts.conditionFalse,
+				NotReady:       action.traceCounts.notReady,
+				Executed:       action.traceCounts.executed,
+			})
+		}
+	}
+	for _, unit := range sim.Raid.AllUnits {
+		if unit.Rotation == nil {
+			continue
+		}
+		addCounts(unit, "", unit.Rotation.priorityList)
+		for _, list := range unit.Rotation.actionLists {
+			addCounts(unit, list.name, list.actions)
+		}
+	}
+}
@@ -93,6 +93,12 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueIsExecutePhase(config.GetIsExecutePhase())
 	case *proto.APLValue_NumberTargets:
 		return rot.newValueNumberTargets(config.GetNumberTargets())
+	case *proto.APLValue_CountTargetsWhere:
+		return rot.newValueCountTargetsWhere(config.GetCountTargetsWhere())
+	case *proto.APLValue_MinOverTargets:
+		return rot.newValueMinOverTargets(config.GetMinOverTargets())
+	case *proto.APLValue_MaxOverTargets:
+		return rot.newValueMaxOverTargets(config.GetMaxOverTargets())
 
 	// Boss
 	case *proto.APLValue_BossSpellIsCasting:
@@ -119,6 +125,10 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 		return rot.newValueCurrentComboPoints(config.GetCurrentComboPoints())
 	case *proto.APLValue_CurrentRunicPower:
 		return rot.newValueCurrentRunicPower(config.GetCurrentRunicPower())
+	case *proto.APLValue_ResourceTimeToValue:
+		return rot.newValueResourceTimeToValue(config.GetResourceTimeToValue())
+	case *proto.APLValue_ResourceAtTime:
+		return rot.newValueResourceAtTime(config.GetResourceAtTime())
 
 	// Resources Runes
 	case *proto.APLValue_CurrentRuneCount:
@@ -200,6 +210,10 @@ func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	case *proto.APLValue_SequenceTimeToReady:
 		return rot.newValueSequenceTimeToReady(config.GetSequenceTimeToReady())
 
+	// Variables
+	case *proto.APLValue_Variable:
+		return rot.newValueVariable(config.GetVariable())
+
 	// Properties
 	case *proto.APLValue_ChannelClipDelay:
 		return rot.newValueChannelClipDelay(config.GetChannelClipDelay())
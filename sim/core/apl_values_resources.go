@@ -2,6 +2,8 @@ package core
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
 )
@@ -245,3 +247,154 @@ func (value *APLValueCurrentRunicPower) GetInt(sim *Simulation) int32 {
 func (value *APLValueCurrentRunicPower) String() string {
 	return "Current Runic Power"
 }
+
+// Returns whether the unit regenerates resource, warning if it doesn't.
+func (rot *APLRotation) validateRegenResource(resource proto.APLValueResourceType) bool {
+	unit := rot.unit
+	switch resource {
+	case proto.APLValueResourceType_ResourceEnergy:
+		if !unit.HasEnergyBar() {
+			rot.ValidationWarning("%s does not use Energy", unit.Label)
+			return false
+		}
+	case proto.APLValueResourceType_ResourceFocus:
+		if !unit.HasFocusBar() {
+			rot.ValidationWarning("%s does not use Focus", unit.Label)
+			return false
+		}
+	case proto.APLValueResourceType_ResourceBloodRunes, proto.APLValueResourceType_ResourceFrostRunes, proto.APLValueResourceType_ResourceUnholyRunes:
+		if !unit.HasRunicPowerBar() {
+			rot.ValidationWarning("%s does not use Runes", unit.Label)
+			return false
+		}
+	default:
+		rot.ValidationWarning("No resource selected")
+		return false
+	}
+	return true
+}
+
+// Returns the first rune slot of resource.
+func runeResourceSlot(resource proto.APLValueResourceType) int8 {
+	switch resource {
+	case proto.APLValueResourceType_ResourceFrostRunes:
+		return 2
+	case proto.APLValueResourceType_ResourceUnholyRunes:
+		return 4
+	default:
+		return 0
+	}
+}
+
+func isRuneResource(resource proto.APLValueResourceType) bool {
+	return resource == proto.APLValueResourceType_ResourceBloodRunes ||
+		resource == proto.APLValueResourceType_ResourceFrostRunes ||
+		resource == proto.APLValueResourceType_ResourceUnholyRunes
+}
+
+func regenResourceName(resource proto.APLValueResourceType) string {
+	switch resource {
+	case proto.APLValueResourceType_ResourceEnergy:
+		return "Energy"
+	case proto.APLValueResourceType_ResourceFocus:
+		return "Focus"
+	case proto.APLValueResourceType_ResourceBloodRunes:
+		return "Blood Runes"
+	case proto.APLValueResourceType_ResourceFrostRunes:
+		return "Frost Runes"
+	case proto.APLValueResourceType_ResourceUnholyRunes:
+		return "Unholy Runes"
+	default:
+		return "Unknown"
+	}
+}
+
+type APLValueResourceTimeToValue struct {
+	DefaultAPLValueImpl
+	unit     *Unit
+	resource proto.APLValueResourceType
+	amount   APLValue
+}
+
+func (rot *APLRotation) newValueResourceTimeToValue(config *proto.APLValueResourceTimeToValue) APLValue {
+	if !rot.validateRegenResource(config.Resource) {
+		return nil
+	}
+	amount := rot.coerceTo(rot.newAPLValue(config.Amount), proto.APLValueType_ValueTypeFloat)
+	if amount == nil {
+		return nil
+	}
+	return &APLValueResourceTimeToValue{
+		unit:     rot.unit,
+		resource: config.Resource,
+		amount:   amount,
+	}
+}
+func (value *APLValueResourceTimeToValue) GetInnerValues() []APLValue {
+	return []APLValue{value.amount}
+}
+func (value *APLValueResourceTimeToValue) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueResourceTimeToValue) GetDuration(sim *Simulation) time.Duration {
+	amount := value.amount.GetFloat(sim)
+	switch value.resource {
+	case proto.APLValueResourceType_ResourceEnergy:
+		return value.unit.TimeToEnergy(sim, amount)
+	case proto.APLValueResourceType_ResourceFocus:
+		return value.unit.TimeToFocus(sim, amount)
+	default:
+		return value.unit.TimeToRunes(sim, runeResourceSlot(value.resource), int32(math.Ceil(amount)))
+	}
+}
+func (value *APLValueResourceTimeToValue) String() string {
+	return fmt.Sprintf("Time To %s(%s)", regenResourceName(value.resource), value.amount)
+}
+
+type APLValueResourceAtTime struct {
+	DefaultAPLValueImpl
+	unit     *Unit
+	resource proto.APLValueResourceType
+	delay    APLValue
+}
+
+func (rot *APLRotation) newValueResourceAtTime(config *proto.APLValueResourceAtTime) APLValue {
+	if !rot.validateRegenResource(config.Resource) {
+		return nil
+	}
+	delay := rot.coerceTo(rot.newAPLValue(config.Delay), proto.APLValueType_ValueTypeDuration)
+	if delay == nil {
+		return nil
+	}
+	return &APLValueResourceAtTime{
+		unit:     rot.unit,
+		resource: config.Resource,
+		delay:    delay,
+	}
+}
+func (value *APLValueResourceAtTime) GetInnerValues() []APLValue {
+	return []APLValue{value.delay}
+}
+func (value *APLValueResourceAtTime) Type() proto.APLValueType {
+	if isRuneResource(value.resource) {
+		return proto.APLValueType_ValueTypeInt
+	}
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueResourceAtTime) GetInt(sim *Simulation) int32 {
+	at := sim.CurrentTime + max(value.delay.GetDuration(sim), 0)
+	return int32(value.unit.RunesAtTime(sim, runeResourceSlot(value.resource), at))
+}
+func (value *APLValueResourceAtTime) GetFloat(sim *Simulation) float64 {
+	if isRuneResource(value.resource) {
+		return float64(value.GetInt(sim))
+	}
+	at := sim.CurrentTime + max(value.delay.GetDuration(sim), 0)
+	if value.resource == proto.APLValueResourceType_ResourceEnergy {
+		return value.unit.EnergyAtTime(at)
+	}
+	return value.unit.FocusAtTime(at)
+}
+func (value *APLValueResourceAtTime) String() string {
+	return fmt.Sprintf("%s In(%s)", regenResourceName(value.resource), value.delay)
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Calls f once for each active target, with the unit's current target set to
+// that target. Values which refer to the current target are evaluated against
+// it, while values with a fixed unit reference are unaffected.
+func forEachActiveTarget(sim *Simulation, unit *Unit, f func(target *Unit)) {
+	oldTarget := unit.CurrentTarget
+	for _, target := range sim.Encounter.ActiveTargetUnits {
+		unit.CurrentTarget = target
+		f(target)
+	}
+	unit.CurrentTarget = oldTarget
+}
+
+// Returns the lowest or highest value over all active targets, or 0 if there
+// are none.
+func reduceOverTargets[T int32 | float64 | time.Duration](sim *Simulation, unit *Unit, getValue func(*Simulation) T, takeMax bool) T {
+	var result T
+	first := true
+	forEachActiveTarget(sim, unit, func(_ *Unit) {
+		val := getValue(sim)
+		if first || (takeMax && val > result) || (!takeMax && val < result) {
+			result = val
+			first = false
+		}
+	})
+	return result
+}
+
+func (rot *APLRotation) newTargetQuantifierValue(config *proto.APLValue) APLValue {
+	val := rot.newAPLValue(config)
+	if val == nil {
+		return nil
+	}
+	if val.Type() == proto.APLValueType_ValueTypeBool || val.Type() == proto.APLValueType_ValueTypeString {
+		rot.ValidationWarning("%s types not allowed in target aggregates!", val.Type())
+		return nil
+	}
+	return val
+}
+
+type APLValueCountTargetsWhere struct {
+	DefaultAPLValueImpl
+	unit      *Unit
+	condition APLValue
+}
+
+func (rot *APLRotation) newValueCountTargetsWhere(config *proto.APLValueCountTargetsWhere) APLValue {
+	condition := rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool)
+	if condition == nil {
+		return nil
+	}
+	return &APLValueCountTargetsWhere{
+		unit:      rot.unit,
+		condition: condition,
+	}
+}
+func (value *APLValueCountTargetsWhere) GetInnerValues() []APLValue {
+	return []APLValue{value.condition}
+}
+func (value *APLValueCountTargetsWhere) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueCountTargetsWhere) GetInt(sim *Simulation) int32 {
+	count := int32(0)
+	forEachActiveTarget(sim, value.unit, func(_ *Unit) {
+		if value.condition.GetBool(sim) {
+			count++
+		}
+	})
+	return count
+}
+func (value *APLValueCountTargetsWhere) String() string {
+	return fmt.Sprintf("Count Targets Where(%s)", value.condition)
+}
+
+type APLValueMinOverTargets struct {
+	DefaultAPLValueImpl
+	unit *Unit
+	val  APLValue
+}
+
+func (rot *APLRotation) newValueMinOverTargets(config *proto.APLValueMinOverTargets) APLValue {
+	val := rot.newTargetQuantifierValue(config.Val)
+	if val == nil {
+		return nil
+	}
+	return &APLValueMinOverTargets{
+		unit: rot.unit,
+		val:  val,
+	}
+}
+func (value *APLValueMinOverTargets) GetInnerValues() []APLValue {
+	return []APLValue{value.val}
+}
+func (value *APLValueMinOverTargets) Type() proto.APLValueType {
+	return value.val.Type()
+}
+func (value *APLValueMinOverTargets) GetInt(sim *Simulation) int32 {
+	return reduceOverTargets(sim, value.unit, value.val.GetInt, false)
+}
+func (value *APLValueMinOverTargets) GetFloat(sim *Simulation) float64 {
+	return reduceOverTargets(sim, value.unit, value.val.GetFloat, false)
+}
+func (value *APLValueMinOverTargets) GetDuration(sim *Simulation) time.Duration {
+	return reduceOverTargets(sim, value.unit, value.val.GetDuration, false)
+}
+func (value *APLValueMinOverTargets) String() string {
+	return fmt.Sprintf("Min Over Targets(%s)", value.val)
+}
+
+type APLValueMaxOverTargets struct {
+	DefaultAPLValueImpl
+	unit *Unit
+	val  APLValue
+}
+
+func (rot *APLRotation) newValueMaxOverTargets(config *proto.APLValueMaxOverTargets) APLValue {
+	val := rot.newTargetQuantifierValue(config.Val)
+	if val == nil {
+		return nil
+	}
+	return &APLValueMaxOverTargets{
+		unit: rot.unit,
+		val:  val,
+	}
+}
+func (value *APLValueMaxOverTargets) GetInnerValues() []APLValue {
+	return []APLValue{value.val}
+}
+func (value *APLValueMaxOverTargets) Type() proto.APLValueType {
+	return value.val.Type()
+}
+func (value *APLValueMaxOverTargets) GetInt(sim *Simulation) int32 {
+	return reduceOverTargets(sim, value.unit, value.val.GetInt, true)
+}
+func (value *APLValueMaxOverTargets) GetFloat(sim *Simulation) float64 {
+	return reduceOverTargets(sim, value.unit, value.val.GetFloat, true)
+}
+func (value *APLValueMaxOverTargets) GetDuration(sim *Simulation) time.Duration {
+	return reduceOverTargets(sim, value.unit, value.val.GetDuration, true)
+}
+func (value *APLValueMaxOverTargets) String() string {
+	return fmt.Sprintf("Max Over Targets(%s)", value.val)
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+type aplVariable struct {
+	name      string
+	value     APLValue
+	configIdx int
+
+	// Set by APLActionSetVariable, and cleared at the start of each iteration.
+	override *APLValueConst
+}
+
+func (variable *aplVariable) current() APLValue {
+	if variable.override != nil {
+		return variable.override
+	}
+	return variable.value
+}
+
+func (variable *aplVariable) finalize(rot *APLRotation) {
+	unprocessed := []APLValue{variable.value}
+	for len(unprocessed) > 0 {
+		next := unprocessed[len(unprocessed)-1]
+		unprocessed = unprocessed[:len(unprocessed)-1]
+		if next != nil {
+			next.Finalize(rot)
+			unprocessed = append(unprocessed, next.GetInnerValues()...)
+		}
+	}
+}
+
+// Returns the variable with the given name, parsing its definition if
+// needed. Returns nil and records a warning if the variable is undefined or
+// its definition refers back to itself.
+func (rot *APLRotation) getVariable(name string) *aplVariable {
+	if rot.resolvingVariables[name] {
+		rot.ValidationWarning("Variable '%s' refers to itself", name)
+		return nil
+	}
+	if variable, ok := rot.variables[name]; ok {
+		return variable
+	}
+
+	config, ok := rot.variableConfigs[name]
+	if !ok {
+		rot.ValidationWarning("No variable with name: '%s'", name)
+		return nil
+	}
+
+	rot.resolvingVariables[name] = true
+	value := rot.newAPLValue(config.Value)
+	delete(rot.resolvingVariables, name)
+
+	if value == nil {
+		if config.Value == nil {
+			rot.ValidationWarning("Variable '%s' must provide a value", name)
+		}
+		rot.variables[name] = nil
+		return nil
+	}
+
+	variable := &aplVariable{
+		name:  name,
+		value: value,
+	}
+	rot.variables[name] = variable
+	return variable
+}
+
+// Evaluates value and stores the result as a constant of the same type.
+func snapshotAPLValue(sim *Simulation, value APLValue) *APLValueConst {
+	snapshot := &APLValueConst{valType: value.Type()}
+	switch snapshot.valType {
+	case proto.APLValueType_ValueTypeBool:
+		snapshot.boolVal = value.GetBool(sim)
+	case proto.APLValueType_ValueTypeInt:
+		snapshot.intVal = value.GetInt(sim)
+	case proto.APLValueType_ValueTypeFloat:
+		snapshot.floatVal = value.GetFloat(sim)
+	case proto.APLValueType_ValueTypeDuration:
+		snapshot.durationVal = value.GetDuration(sim)
+	case proto.APLValueType_ValueTypeString:
+		snapshot.stringVal = value.GetString(sim)
+	}
+	return snapshot
+}
+
+type APLValueVariable struct {
+	DefaultAPLValueImpl
+	variable *aplVariable
+}
+
+func (rot *APLRotation) newValueVariable(config *proto.APLValueVariable) APLValue {
+	if config.Name == "" {
+		rot.ValidationWarning("Variable() must provide a variable name")
+		return nil
+	}
+	variable := rot.getVariable(config.Name)
+	if variable == nil {
+		return nil
+	}
+	return &APLValueVariable{
+		variable: variable,
+	}
+}
+func (value *APLValueVariable) Type() proto.APLValueType {
+	return value.variable.value.Type()
+}
+func (value *APLValueVariable) GetBool(sim *Simulation) bool {
+	return value.variable.current().GetBool(sim)
+}
+func (value *APLValueVariable) GetInt(sim *Simulation) int32 {
+	return value.variable.current().GetInt(sim)
+}
+func (value *APLValueVariable) GetFloat(sim *Simulation) float64 {
+	return value.variable.current().GetFloat(sim)
+}
+func (value *APLValueVariable) GetDuration(sim *Simulation) time.Duration {
+	return value.variable.current().GetDuration(sim)
+}
+func (value *APLValueVariable) GetString(sim *Simulation) string {
+	return value.variable.current().GetString(sim)
+}
+func (value *APLValueVariable) String() string {
+	return fmt.Sprintf("Variable(%s)", value.variable.name)
+}
@@ -196,6 +196,9 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 	if sim.Log != nil {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.auraStacks(sim, aura, oldStacks, newStacks)
+	}
 	aura.stacks = newStacks
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
@@ -623,6 +626,9 @@ func (aura *Aura) Activate(sim *Simulation) {
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
+	if sim.combatLog != nil && !aura.ActionID.IsEmptyAction() {
+		sim.combatLog.auraGain(sim, aura)
+	}
 
 	// don't invoke possible callbacks until the internal state is consistent
 	if aura.OnGain != nil {
@@ -645,13 +651,16 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 		}
 	}
 
-	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
+	if (sim.Log != nil || sim.combatLog != nil) && !aura.ActionID.IsEmptyAction() {
 		// fix logging timestamps for lazy aura expiration
 		oldTime := sim.CurrentTime
 		sim.CurrentTime = min(sim.CurrentTime, aura.expires)
 		if sim.Log != nil {
 			aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
 		}
+		if sim.combatLog != nil {
+			sim.combatLog.auraFade(sim, aura)
+		}
 		sim.CurrentTime = oldTime
 	}
 
@@ -143,6 +143,21 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		}
 	}
 
+	var reforger *gearOptimizer
+	if b.Request.BulkSettings.AutoReforge {
+		if b.Request.BulkSettings.EpWeights == nil {
+			return nil, fmt.Errorf("bulksim: auto reforge requires ep weights")
+		}
+		reforger = newGearOptimizer(&proto.OptimizeGearRequest{
+			Player:     player,
+			RaidBuffs:  b.Request.BaseSettings.Raid.Buffs,
+			PartyBuffs: b.Request.BaseSettings.Raid.Parties[0].Buffs,
+			Debuffs:    b.Request.BaseSettings.Raid.Debuffs,
+			Encounter:  b.Request.BaseSettings.Encounter,
+			Tanks:      b.Request.BaseSettings.Raid.Tanks,
+		}, &proto.StatWeightValues{EpValues: b.Request.BulkSettings.EpWeights})
+	}
+
 	iterations := b.Request.GetBulkSettings().GetIterationsPerCombo()
 	if iterations <= 0 {
 		iterations = defaultIterationsPerCombo
@@ -185,6 +200,9 @@ func (b *bulkSimRunner) Run(pctx context.Context, progress chan *proto.ProgressM
 		}
 		substitutedRequest, changeLog := createNewRequestWithSubstitution(b.Request.BaseSettings, sub, b.Request.BulkSettings.AutoEnchant)
 		if isValidEquipment(substitutedRequest.Raid.Parties[0].Players[0].Equipment) {
+			if reforger != nil {
+				reforger.reforgeAddedItems(substitutedRequest.Raid.Parties[0].Players[0].Equipment, changeLog)
+			}
 			// Need to sim base dps of gear loudout
 			validCombos = append(validCombos, singleBulkSim{req: substitutedRequest, cl: changeLog, eq: sub})
 			// Todo(Netzone-GehennasEU): Make this its own step?
@@ -681,6 +699,32 @@ func createNewRequestWithSubstitution(readonlyInputRequest *proto.RaidSimRequest
 	return request, changeLog
 }
 
+// reforgeAddedItems picks the best reforge for each added item which does not already specify
+// one, given the rest of the equipment. The chosen reforges are also recorded in the change log.
+func (opt *gearOptimizer) reforgeAddedItems(equipment *proto.EquipmentSpec, changeLog *raidSimRequestChangeLog) {
+	opt.equipment = Equipment{}
+	for slot, itemSpec := range ProtoToEquipmentSpec(equipment) {
+		if itemSpec.ID != 0 {
+			opt.equipment[slot] = NewItem(itemSpec)
+		}
+	}
+
+	for _, added := range changeLog.AddedItems {
+		if added.Item.Reforging != 0 {
+			continue
+		}
+		opt.optimizeSlotReforge(added.Slot)
+
+		// Clone, since the same ItemSpec is shared by every combo containing this item.
+		reforged := goproto.Clone(added.Item).(*proto.ItemSpec)
+		if reforging := opt.equipment[added.Slot].Reforging; reforging != nil {
+			reforged.Reforging = reforging.ID
+		}
+		equipment.Items[added.Slot] = reforged
+		added.Item = reforged
+	}
+}
+
 type ItemComboChecker map[int64]struct{}
 
 func (ic *ItemComboChecker) HasCombo(itema int32, itemb int32) bool {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -88,6 +89,41 @@ func createEquipmentFromItems(items ...*itemWithSlot) *proto.EquipmentSpec {
 	return spec
 }
 
+func TestReforgeAddedItems(t *testing.T) {
+	const itemCritChest = 99001
+	t.Cleanup(func() { delete(ItemsByID, itemCritChest) })
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: itemCritChest, Type: proto.ItemType_ItemTypeChest, Stats: stats.Stats{stats.MeleeCrit: 100}.ToFloatArray()},
+		},
+	})
+
+	reforger := &gearOptimizer{
+		weights: stats.Stats{stats.MeleeHit: 2, stats.MeleeCrit: 1},
+		reforges: []ReforgeStat{
+			{ID: 113, FromStat: []proto.Stat{proto.Stat_StatMeleeCrit}, ToStat: []proto.Stat{proto.Stat_StatMeleeHit}, Multiplier: 0.4},
+		},
+	}
+
+	added := &proto.ItemSpec{Id: itemCritChest}
+	equipment := createEquipmentFromItems(&itemWithSlot{Item: added, Slot: proto.ItemSlot_ItemSlotChest})
+	changeLog := &raidSimRequestChangeLog{
+		AddedItems: []*proto.ItemSpecWithSlot{{Item: added, Slot: proto.ItemSlot_ItemSlotChest}},
+	}
+
+	reforger.reforgeAddedItems(equipment, changeLog)
+
+	if got := equipment.Items[proto.ItemSlot_ItemSlotChest].Reforging; got != 113 {
+		t.Fatalf("Expected chest to be reforged with 113, got %d", got)
+	}
+	if got := changeLog.AddedItems[0].Item.Reforging; got != 113 {
+		t.Fatalf("Expected change log to report reforge 113, got %d", got)
+	}
+	if added.Reforging != 0 {
+		t.Fatalf("Shared bulk item spec should not be modified")
+	}
+}
+
 func TestBulkSim(t *testing.T) {
 	t.Skip("TODO: Implement")
 
@@ -154,6 +154,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
+			if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+				sim.combatLog.castStart(sim, spell, target, spell.CurCast)
+			}
 
 			spell.Unit.Hardcast = Hardcast{
 				Expires:  sim.CurrentTime + spell.CurCast.CastTime,
@@ -162,6 +165,9 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 					if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 						spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 					}
+					if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+						sim.combatLog.castComplete(sim, spell, target)
+					}
 
 					if spell.Cost != nil {
 						spell.Cost.SpendCost(sim, spell)
@@ -186,6 +192,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 				spell.ActionID, max(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.combatLog.castStart(sim, spell, target, spell.CurCast)
+			sim.combatLog.castComplete(sim, spell, target)
+		}
 
 		if spell.Cost != nil {
 			spell.Cost.SpendCost(sim, spell)
@@ -232,6 +242,10 @@ func (spell *Spell) makeCastFuncSimple() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.combatLog.castStart(sim, spell, target, Cast{})
+			sim.combatLog.castComplete(sim, spell, target)
+		}
 
 		spell.applyEffects(sim, target)
 
@@ -250,6 +264,10 @@ func (spell *Spell) makeCastFuncAutosOrProcs() CastSuccessFunc {
 				spell.ActionID, 0.0, "0s", "0s")
 			spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 		}
+		if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+			sim.combatLog.castStart(sim, spell, target, Cast{})
+			sim.combatLog.castComplete(sim, spell, target)
+		}
 
 		spell.applyEffects(sim, target)
 
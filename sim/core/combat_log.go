@@ -0,0 +1,179 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Records structured combat log events, see SimOptions.combat_log_format.
+// Like the text logs, this only covers the first iteration; sim.combatLog is
+// nil whenever nothing is being recorded.
+type combatLog struct {
+	events []*proto.CombatLogEvent
+}
+
+func (cl *combatLog) add(sim *Simulation, unit *Unit, event *proto.CombatLogEvent) {
+	event.Timestamp = sim.CurrentTime.Seconds()
+	event.UnitIndex = unit.UnitIndex
+	cl.events = append(cl.events, event)
+}
+
+func (cl *combatLog) castStart(sim *Simulation, spell *Spell, target *Unit, cast Cast) {
+	cl.add(sim, spell.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_CastStart{CastStart: &proto.CastStartEvent{
+			SpellId:       spell.ActionID.ToProto(),
+			TargetIndex:   combatLogTargetIndex(target),
+			Cost:          max(0, cast.Cost),
+			CastTime:      cast.CastTime.Seconds(),
+			EffectiveTime: cast.EffectiveTime().Seconds(),
+		}},
+	})
+}
+
+func (cl *combatLog) castComplete(sim *Simulation, spell *Spell, target *Unit) {
+	cl.add(sim, spell.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_CastComplete{CastComplete: &proto.CastCompleteEvent{
+			SpellId:     spell.ActionID.ToProto(),
+			TargetIndex: combatLogTargetIndex(target),
+		}},
+	})
+}
+
+func (cl *combatLog) spellHit(sim *Simulation, spell *Spell, result *SpellResult, isPeriodic bool, isHealing bool) {
+	cl.add(sim, spell.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_SpellHit{SpellHit: &proto.SpellHitEvent{
+			SpellId:       spell.ActionID.ToProto(),
+			TargetIndex:   result.Target.UnitIndex,
+			Outcome:       result.Outcome.ToProto(),
+			PartialResist: result.Outcome.PartialResistPercent(),
+			Amount:        result.Damage,
+			Threat:        result.Threat,
+			IsPeriodic:    isPeriodic,
+			IsHealing:     isHealing,
+		}},
+	})
+}
+
+func (cl *combatLog) auraGain(sim *Simulation, aura *Aura) {
+	cl.add(sim, aura.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_AuraGain{AuraGain: &proto.AuraGainEvent{
+			AuraId: aura.ActionID.ToProto(),
+			Label:  aura.Label,
+		}},
+	})
+}
+
+func (cl *combatLog) auraFade(sim *Simulation, aura *Aura) {
+	cl.add(sim, aura.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_AuraFade{AuraFade: &proto.AuraFadeEvent{
+			AuraId: aura.ActionID.ToProto(),
+			Label:  aura.Label,
+		}},
+	})
+}
+
+func (cl *combatLog) auraStacks(sim *Simulation, aura *Aura, oldStacks int32, newStacks int32) {
+	cl.add(sim, aura.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_AuraStacks{AuraStacks: &proto.AuraStacksEvent{
+			AuraId:    aura.ActionID.ToProto(),
+			Label:     aura.Label,
+			OldStacks: oldStacks,
+			NewStacks: newStacks,
+		}},
+	})
+}
+
+func (cl *combatLog) resource(sim *Simulation, unit *Unit, metrics *ResourceMetrics, amount float64, actualAmount float64, newValue float64) {
+	cl.add(sim, unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_Resource{Resource: &proto.ResourceEvent{
+			ActionId:     metrics.ActionID.ToProto(),
+			Type:         metrics.Type,
+			Amount:       amount,
+			ActualAmount: actualAmount,
+			NewValue:     newValue,
+		}},
+	})
+}
+
+func (cl *combatLog) petSummon(sim *Simulation, pet *Pet) {
+	cl.add(sim, &pet.Owner.Unit, &proto.CombatLogEvent{
+		Event: &proto.CombatLogEvent_PetSummon{PetSummon: &proto.PetSummonEvent{
+			PetUnitIndex: pet.UnitIndex,
+			Name:         pet.Name,
+		}},
+	})
+}
+
+// Records a resource event for resource bars implemented outside of core.
+func (sim *Simulation) CombatLogResource(unit *Unit, metrics *ResourceMetrics, amount float64, actualAmount float64, newValue float64) {
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, unit, metrics, amount, actualAmount, newValue)
+	}
+}
+
+// Copies the recorded events into the result, in the requested format.
+func (cl *combatLog) fillResult(result *proto.RaidSimResult, format proto.CombatLogFormat) error {
+	switch format {
+	case proto.CombatLogFormat_CombatLogFormatProto:
+		result.CombatLog = cl.events
+	case proto.CombatLogFormat_CombatLogFormatJsonl:
+		sb := &strings.Builder{}
+		for _, event := range cl.events {
+			line, err := protojson.Marshal(event)
+			if err != nil {
+				return err
+			}
+			sb.Write(line)
+			sb.WriteByte('\n')
+		}
+		result.CombatLogJsonl = sb.String()
+	}
+	return nil
+}
+
+func combatLogTargetIndex(target *Unit) int32 {
+	if target == nil {
+		return -1
+	}
+	return target.UnitIndex
+}
+
+func (ho HitOutcome) ToProto() proto.HitOutcome {
+	if ho.Matches(OutcomeMiss) {
+		return proto.HitOutcome_HitOutcomeMiss
+	} else if ho.Matches(OutcomeDodge) {
+		return proto.HitOutcome_HitOutcomeDodge
+	} else if ho.Matches(OutcomeParry) {
+		return proto.HitOutcome_HitOutcomeParry
+	} else if ho.Matches(OutcomeGlance) {
+		return proto.HitOutcome_HitOutcomeGlance
+	} else if ho.Matches(OutcomeBlock) {
+		if ho.Matches(OutcomeCrit) {
+			return proto.HitOutcome_HitOutcomeCritBlock
+		} else {
+			return proto.HitOutcome_HitOutcomeBlock
+		}
+	} else if ho.Matches(OutcomeCrit) {
+		return proto.HitOutcome_HitOutcomeCrit
+	} else if ho.Matches(OutcomeHit) {
+		return proto.HitOutcome_HitOutcomeHit
+	} else if ho.Matches(OutcomeCrush) {
+		return proto.HitOutcome_HitOutcomeCrush
+	} else {
+		return proto.HitOutcome_HitOutcomeUnknown
+	}
+}
+
+func (ho HitOutcome) PartialResistPercent() int32 {
+	if ho.Matches(OutcomePartial1) {
+		return 30
+	} else if ho.Matches(OutcomePartial2) {
+		return 20
+	} else if ho.Matches(OutcomePartial4) {
+		return 10
+	} else {
+		return 0
+	}
+}
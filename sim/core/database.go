@@ -177,14 +177,21 @@ type Gem struct {
 	Name  string
 	Stats stats.Stats
 	Color proto.GemColor
+
+	Unique             bool
+	RequiredProfession proto.Profession
+	MetaRequirements   *proto.MetaGemRequirements
 }
 
 func GemFromProto(pData *proto.SimGem) Gem {
 	return Gem{
-		ID:    pData.Id,
-		Name:  pData.Name,
-		Stats: stats.FromFloatArray(pData.Stats),
-		Color: pData.Color,
+		ID:                 pData.Id,
+		Name:               pData.Name,
+		Stats:              stats.FromFloatArray(pData.Stats),
+		Color:              pData.Color,
+		Unique:             pData.Unique,
+		RequiredProfession: pData.RequiredProfession,
+		MetaRequirements:   pData.MetaRequirements,
 	}
 }
 
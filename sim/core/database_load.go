@@ -58,10 +58,13 @@ func init() {
 
 	for i, gem := range db.Gems {
 		simDB.Gems[i] = &proto.SimGem{
-			Id:    gem.Id,
-			Name:  gem.Name,
-			Color: gem.Color,
-			Stats: gem.Stats,
+			Id:                 gem.Id,
+			Name:               gem.Name,
+			Color:              gem.Color,
+			Stats:              gem.Stats,
+			Unique:             gem.Unique,
+			RequiredProfession: gem.RequiredProfession,
+			MetaRequirements:   gem.MetaRequirements,
 		}
 	}
 
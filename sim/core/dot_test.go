@@ -176,3 +176,66 @@ func TestDotSnapshotSpellMultiplier(t *testing.T) {
 	fa.Dot.Rollover(sim)
 	expectDotTickDamage(t, sim, fa.Dot, 300) // (100) * 1.5 * 2
 }
+
+func TestDamageOutcomeMetrics(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.TargetUnits[0]
+
+	fa.Dot.Apply(sim)
+	fa.Dot.TickOnce(sim)
+	fa.Dot.TickOnce(sim)
+
+	fa.Spell.CalcAndDealDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit)
+	fa.Spell.CalcAndDealDamage(sim, target, 200, fa.Spell.OutcomeAlwaysHit)
+	fa.Spell.CalcAndDealDamage(sim, target, 200, fa.Spell.OutcomeAlwaysMiss)
+
+	outcomes := fa.Spell.SpellMetrics[target.UnitIndex].DamageOutcomes
+	expectDamageOutcome(t, "periodic hit", outcomes[1][0][proto.HitOutcome_HitOutcomeHit], 2, 300, 150, 150)
+	expectDamageOutcome(t, "direct hit", outcomes[0][0][proto.HitOutcome_HitOutcomeHit], 2, 450, 150, 300)
+	expectDamageOutcome(t, "direct crit", outcomes[0][0][proto.HitOutcome_HitOutcomeCrit], 0, 0, 0, 0)
+	expectDamageOutcome(t, "direct miss", outcomes[0][0][proto.HitOutcome_HitOutcomeMiss], 0, 0, 0, 0)
+}
+
+func TestDamageOutcomeMetricsPartialResists(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	target := sim.Encounter.TargetUnits[0]
+	target.AddStatDynamic(sim, stats.ShadowResistance, 20)
+	fa.Spell.Flags &^= SpellFlagIgnoreResists
+
+	const n = 200
+	for i := 0; i < n; i++ {
+		fa.Spell.CalcAndDealDamage(sim, target, 100, fa.Spell.OutcomeAlwaysHit)
+		fa.Spell.CalcAndDealDamage(sim, target, 100, func(_ *Simulation, result *SpellResult, _ *AttackTable) {
+			result.Outcome = OutcomeCrit
+			result.Damage *= 2
+		})
+	}
+
+	// Most rolls land in the 0% bracket, which must not count as resisted.
+	outcomes := fa.Spell.SpellMetrics[target.UnitIndex].DamageOutcomes[0]
+	hit, resistedHit := outcomes[0][proto.HitOutcome_HitOutcomeHit], outcomes[1][proto.HitOutcome_HitOutcomeHit]
+	crit, resistedCrit := outcomes[0][proto.HitOutcome_HitOutcomeCrit], outcomes[1][proto.HitOutcome_HitOutcomeCrit]
+	for name, dom := range map[string]DamageOutcomeMetrics{"hit": hit, "resisted hit": resistedHit, "crit": crit, "resisted crit": resistedCrit} {
+		if dom.Count == 0 {
+			t.Fatalf("Expected some %s outcomes", name)
+		}
+	}
+	expectDamageOutcome(t, "hit", hit, hit.Count, 150*float64(hit.Count), 150, 150)
+	expectDamageOutcome(t, "crit", crit, crit.Count, 300*float64(crit.Count), 300, 300)
+	if hit.Count+resistedHit.Count != n || crit.Count+resistedCrit.Count != n {
+		t.Fatalf("Expected %d hits and crits, got %d and %d", n, hit.Count+resistedHit.Count, crit.Count+resistedCrit.Count)
+	}
+	if resistedHit.MaxDamage >= 150 || resistedCrit.MaxDamage >= 300 {
+		t.Fatalf("Expected resisted outcomes to lose damage, got %+v and %+v", resistedHit, resistedCrit)
+	}
+}
+
+func expectDamageOutcome(t *testing.T, name string, dom DamageOutcomeMetrics, count int32, damage float64, minDamage float64, maxDamage float64) {
+	t.Helper()
+	if dom.Count != count || !WithinToleranceFloat64(damage, dom.Damage, 0.01) ||
+		!WithinToleranceFloat64(minDamage, dom.MinDamage, 0.01) || !WithinToleranceFloat64(maxDamage, dom.MaxDamage, 0.01) {
+		t.Fatalf("Unexpected %s metrics: %+v", name, dom)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
@@ -68,6 +69,38 @@ func (eb *energyBar) EnergyRegenPerSecond() float64 {
 	return 10.0 * eb.hasteRatingMultiplier * eb.energyRegenMultiplier
 }
 
+// EnergyAtTime returns the predicted energy at the given time, assuming the
+// current regen rate holds and nothing is spent before then.
+func (eb *energyBar) EnergyAtTime(at time.Duration) float64 {
+	return tickedResourceAtTime(eb.currentEnergy, eb.maxEnergy, eb.EnergyPerTick*eb.hasteRatingMultiplier*eb.energyRegenMultiplier, eb.nextEnergyTick, eb.EnergyTickDuration, at)
+}
+
+// TimeToEnergy returns how long until the unit has the given amount of energy,
+// assuming the current regen rate holds and nothing is spent before then.
+func (eb *energyBar) TimeToEnergy(sim *Simulation, amount float64) time.Duration {
+	return tickedResourceTimeToValue(sim, eb.currentEnergy, eb.maxEnergy, eb.EnergyPerTick*eb.hasteRatingMultiplier*eb.energyRegenMultiplier, eb.nextEnergyTick, eb.EnergyTickDuration, amount)
+}
+
+// Predicts a resource which gains perTick every tickDuration, starting at nextTick.
+func tickedResourceAtTime(current float64, maximum float64, perTick float64, nextTick time.Duration, tickDuration time.Duration, at time.Duration) float64 {
+	if at < nextTick {
+		return current
+	}
+	numTicks := int64((at-nextTick)/tickDuration) + 1
+	return min(current+float64(numTicks)*perTick, maximum)
+}
+
+func tickedResourceTimeToValue(sim *Simulation, current float64, maximum float64, perTick float64, nextTick time.Duration, tickDuration time.Duration, amount float64) time.Duration {
+	if current >= amount {
+		return 0
+	}
+	if amount > maximum || perTick <= 0 || nextTick == NeverExpires {
+		return NeverExpires
+	}
+	numTicks := math.Ceil((amount - current) / perTick)
+	return max(nextTick+time.Duration(numTicks-1)*tickDuration-sim.CurrentTime, 0)
+}
+
 func (eb *energyBar) AddEnergy(sim *Simulation, amount float64, metrics *ResourceMetrics) {
 	if amount < 0 {
 		panic("Trying to add negative energy!")
@@ -79,6 +112,9 @@ func (eb *energyBar) AddEnergy(sim *Simulation, amount float64, metrics *Resourc
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, eb.unit, metrics, amount, newEnergy-eb.currentEnergy, newEnergy)
+	}
 
 	eb.currentEnergy = newEnergy
 }
@@ -94,6 +130,9 @@ func (eb *energyBar) SpendEnergy(sim *Simulation, amount float64, metrics *Resou
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Spent %0.3f energy from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, eb.currentEnergy, newEnergy, eb.maxEnergy)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, eb.unit, metrics, -amount, -amount, newEnergy)
+	}
 
 	eb.currentEnergy = newEnergy
 }
@@ -141,6 +180,9 @@ func (eb *energyBar) AddComboPoints(sim *Simulation, pointsToAdd int32, metrics
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %d combo points from %s (%d --> %d) of %0.0f total.", pointsToAdd, metrics.ActionID, eb.comboPoints, newComboPoints, 5.0)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, eb.unit, metrics, float64(pointsToAdd), float64(newComboPoints-eb.comboPoints), float64(newComboPoints))
+	}
 
 	eb.comboPoints = newComboPoints
 }
@@ -150,6 +192,9 @@ func (eb *energyBar) SpendComboPoints(sim *Simulation, metrics *ResourceMetrics)
 		eb.unit.Log(sim, "Spent %d combo points from %s (%d --> %d) of %0.0f total.", eb.comboPoints, metrics.ActionID, eb.comboPoints, 0, 5.0)
 	}
 	metrics.AddEvent(float64(-eb.comboPoints), float64(-eb.comboPoints))
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, eb.unit, metrics, float64(-eb.comboPoints), float64(-eb.comboPoints), 0)
+	}
 	eb.comboPoints = 0
 }
 
@@ -236,6 +236,7 @@ func (env *Environment) reset(sim *Simulation) {
 	for _, target := range env.Encounter.Targets {
 		target.Reset(sim)
 	}
+	env.Encounter.updateActiveTargets()
 
 	env.Raid.reset(sim)
 }
@@ -77,6 +77,18 @@ func (fb *focusBar) FocusRegenPerSecond() float64 {
 	}
 }
 
+// FocusAtTime returns the predicted focus at the given time, assuming the
+// current regen rate holds and nothing is spent before then.
+func (fb *focusBar) FocusAtTime(at time.Duration) float64 {
+	return tickedResourceAtTime(fb.currentFocus, fb.maxFocus, fb.FocusRegenPerTick(), fb.nextFocusTick, fb.focusTickDuration, at)
+}
+
+// TimeToFocus returns how long until the unit has the given amount of focus,
+// assuming the current regen rate holds and nothing is spent before then.
+func (fb *focusBar) TimeToFocus(sim *Simulation, amount float64) time.Duration {
+	return tickedResourceTimeToValue(sim, fb.currentFocus, fb.maxFocus, fb.FocusRegenPerTick(), fb.nextFocusTick, fb.focusTickDuration, amount)
+}
+
 func (fb *focusBar) getTotalRegenMultiplier() float64 {
 	return fb.hasteRatingMultiplier * fb.focusRegenMultiplier
 }
@@ -92,6 +104,9 @@ func (fb *focusBar) AddFocus(sim *Simulation, amount float64, metrics *ResourceM
 			fb.unit.Log(sim, "Gained %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
 		}
 		metrics.AddEvent(amount, newFocus-fb.currentFocus)
+		if sim.combatLog != nil {
+			sim.combatLog.resource(sim, fb.unit, metrics, amount, newFocus-fb.currentFocus, newFocus)
+		}
 	}
 
 	if fb.OnFocusGain != nil {
@@ -112,6 +127,9 @@ func (fb *focusBar) SpendFocus(sim *Simulation, amount float64, metrics *Resourc
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Spent %0.3f focus from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, fb.currentFocus, newFocus, fb.maxFocus)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, fb.unit, metrics, -amount, -amount, newFocus)
+	}
 
 	fb.currentFocus = newFocus
 }
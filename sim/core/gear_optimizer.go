@@ -0,0 +1,438 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Maximum number of passes over the gear before giving up on convergence.
+const gearOptimizerMaxRounds = 10
+
+// EP lost for each gem missing from a meta gem requirement, when requirements
+// must be met. Large enough to outweigh any gem choice.
+const metaReqPenalty = 1e6
+
+// Returns the number of gems which count towards each color for meta gem
+// requirements. Hybrid and prismatic gems count towards every matching color.
+func (equipment *Equipment) gemColorCounts() (numRed int, numYellow int, numBlue int) {
+	for _, item := range equipment {
+		for _, gem := range item.Gems {
+			if gem.ID == 0 {
+				continue
+			}
+			if ColorIntersects(proto.GemColor_GemColorRed, gem.Color) {
+				numRed++
+			}
+			if ColorIntersects(proto.GemColor_GemColorYellow, gem.Color) {
+				numYellow++
+			}
+			if ColorIntersects(proto.GemColor_GemColorBlue, gem.Color) {
+				numBlue++
+			}
+		}
+	}
+	return
+}
+
+// Returns how many more gems are needed to activate the equipped meta gem.
+func (equipment *Equipment) numMissingMetaReqs() int {
+	for _, gem := range equipment.Head().Gems {
+		if gem.Color != proto.GemColor_GemColorMeta {
+			continue
+		}
+		if reqs := gem.MetaRequirements; reqs != nil {
+			numRed, numYellow, numBlue := equipment.gemColorCounts()
+			return max(0, int(reqs.MinRed)-numRed) + max(0, int(reqs.MinYellow)-numYellow) + max(0, int(reqs.MinBlue)-numBlue)
+		}
+	}
+	return 0
+}
+
+// Stats which stop adding value past a cap. Weight curve breakpoints in other
+// stats are diminishing returns rather than caps.
+var cappedStats = []stats.Stat{stats.MeleeHit, stats.SpellHit, stats.Expertise}
+
+// Returns the default hit and expertise caps, in rating, for a set of EP values.
+// Dual wielders keep gaining white hits up to the higher dual wield hit cap.
+func defaultStatCaps(weights stats.Stats, dualWield bool) stats.Stats {
+	caps := stats.Stats{}
+	if weights[stats.SpellHit] > weights[stats.MeleeHit] {
+		caps[stats.SpellHit] = 17 * SpellHitRatingPerHitChance
+	} else {
+		caps[stats.MeleeHit] = TernaryFloat64(dualWield, 27, 8) * MeleeHitRatingPerHitChance
+		caps[stats.Expertise] = 26 * ExpertisePerQuarterPercentReduction
+	}
+	return caps
+}
+
+type gearOptimizer struct {
+	equipment Equipment
+
+	weights stats.Stats
+	caps    stats.Stats
+	// Stats from sources other than gear, used to find how far gear is from each cap.
+	baseStats stats.Stats
+
+	gems     []Gem
+	enchants map[proto.ItemSlot][]Enchant
+	reforges []ReforgeStat
+
+	ensureMetaReqMet bool
+}
+
+// Returns the EP of the current gear, treating any stat above its cap as worthless.
+func (opt *gearOptimizer) score() float64 {
+	gearStats := opt.equipment.Stats()
+
+	total := 0.0
+	for stat, weight := range opt.weights {
+		if weight == 0 {
+			continue
+		}
+		value := gearStats[stat]
+		if opt.caps[stat] > 0 {
+			value = min(opt.baseStats[stat]+value, opt.caps[stat]) - opt.baseStats[stat]
+		}
+		total += weight * value
+	}
+
+	if opt.ensureMetaReqMet {
+		total -= metaReqPenalty * float64(opt.equipment.numMissingMetaReqs())
+	}
+	return total
+}
+
+// Returns true if the gem can be placed in a socket of the given color. When
+// matchOnly is set, the gem must also count towards the socket bonus.
+func gemFitsSocket(gem Gem, socketColor proto.GemColor, matchOnly bool) bool {
+	if socketColor == proto.GemColor_GemColorMeta || socketColor == proto.GemColor_GemColorCogwheel {
+		return gem.Color == socketColor
+	}
+	if gem.Color == proto.GemColor_GemColorMeta || gem.Color == proto.GemColor_GemColorCogwheel {
+		return false
+	}
+	return !matchOnly || ColorIntersects(socketColor, gem.Color)
+}
+
+// Fills each socket of the item in turn with the best fitting gem. Tries once
+// ignoring the socket bonus and once matching every socket, and keeps whichever
+// scores higher, if it beats the current gems.
+func (opt *gearOptimizer) optimizeItemGems(slot proto.ItemSlot) bool {
+	item := &opt.equipment[slot]
+
+	// Sockets beyond the item's own (e.g. belt buckles) accept any color.
+	sockets := slices.Clone(item.GemSockets)
+	for len(sockets) < len(item.Gems) {
+		sockets = append(sockets, proto.GemColor_GemColorPrismatic)
+	}
+	if len(sockets) == 0 {
+		return false
+	}
+	for len(item.Gems) < len(sockets) {
+		item.Gems = append(item.Gems, Gem{})
+	}
+
+	bestGems := slices.Clone(item.Gems)
+	bestScore := opt.score()
+	improved := false
+
+	for _, matchOnly := range []bool{false, true} {
+		for socketIdx, socketColor := range sockets {
+			socketBest := item.Gems[socketIdx]
+			socketBestScore := math.Inf(-1)
+			for _, gem := range opt.gems {
+				if !gemFitsSocket(gem, socketColor, matchOnly) {
+					continue
+				}
+				item.Gems[socketIdx] = gem
+				if score := opt.score(); score > socketBestScore {
+					socketBest = gem
+					socketBestScore = score
+				}
+			}
+			item.Gems[socketIdx] = socketBest
+		}
+
+		if score := opt.score(); score > bestScore+1e-6 {
+			bestGems = slices.Clone(item.Gems)
+			bestScore = score
+			improved = true
+		}
+		copy(item.Gems, bestGems)
+	}
+
+	return improved
+}
+
+func (opt *gearOptimizer) optimizeEnchants() bool {
+	improved := false
+	for slot := range opt.equipment {
+		candidates := opt.enchants[proto.ItemSlot(slot)]
+		item := &opt.equipment[slot]
+		if item.ID == 0 || len(candidates) == 0 {
+			continue
+		}
+
+		bestEnchant := item.Enchant
+		bestScore := opt.score()
+		for _, enchant := range candidates {
+			item.Enchant = enchant
+			if score := opt.score(); score > bestScore+1e-6 {
+				bestEnchant = enchant
+				bestScore = score
+				improved = true
+			}
+		}
+		item.Enchant = bestEnchant
+	}
+	return improved
+}
+
+// Picks the best reforge (or none) for the item in the given slot.
+func (opt *gearOptimizer) optimizeSlotReforge(slot proto.ItemSlot) bool {
+	item := &opt.equipment[slot]
+	if item.ID == 0 {
+		return false
+	}
+
+	bestReforging := item.Reforging
+	bestScore := opt.score()
+	improved := false
+
+	item.Reforging = nil
+	if score := opt.score(); score > bestScore+1e-6 {
+		bestReforging = nil
+		bestScore = score
+		improved = true
+	}
+
+	for i := range opt.reforges {
+		reforge := &opt.reforges[i]
+		if !validateReforging(item, *reforge) {
+			continue
+		}
+		item.Reforging = reforge
+		if score := opt.score(); score > bestScore+1e-6 {
+			bestReforging = reforge
+			bestScore = score
+			improved = true
+		}
+	}
+	item.Reforging = bestReforging
+
+	return improved
+}
+
+func (opt *gearOptimizer) optimizeReforges() bool {
+	improved := false
+	for slot := range opt.equipment {
+		improved = opt.optimizeSlotReforge(proto.ItemSlot(slot)) || improved
+	}
+	return improved
+}
+
+func (opt *gearOptimizer) optimize(optimizeGems bool, optimizeEnchants bool, optimizeReforges bool) {
+	for round := 0; round < gearOptimizerMaxRounds; round++ {
+		improved := false
+		if optimizeEnchants {
+			improved = opt.optimizeEnchants() || improved
+		}
+		if optimizeGems {
+			for slot := range opt.equipment {
+				if opt.equipment[slot].ID != 0 {
+					improved = opt.optimizeItemGems(proto.ItemSlot(slot)) || improved
+				}
+			}
+		}
+		if optimizeReforges {
+			improved = opt.optimizeReforges() || improved
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+func newGearOptimizer(request *proto.OptimizeGearRequest, weights *proto.StatWeightValues) *gearOptimizer {
+	opt := &gearOptimizer{
+		equipment:        ProtoToEquipment(request.Player.Equipment),
+		weights:          stats.FromFloatArray(weights.EpValues.GetStats()),
+		caps:             stats.Stats{},
+		enchants:         make(map[proto.ItemSlot][]Enchant),
+		ensureMetaReqMet: request.EnsureMetaReqMet,
+	}
+
+	raidProto := SinglePlayerRaidProto(request.Player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+	encounter := request.Encounter
+	if encounter == nil {
+		encounter = &proto.Encounter{}
+	}
+	env, raidStats, _ := NewEnvironment(raidProto, encounter, true)
+	finalStats := stats.FromFloatArray(raidStats.Parties[0].Players[0].FinalStats.Stats)
+	opt.baseStats = finalStats.Subtract(opt.equipment.Stats())
+
+	// Explicit caps take precedence, then caps detected by stat weight curves,
+	// then the defaults.
+	if request.StatCaps != nil {
+		opt.caps = stats.FromFloatArray(request.StatCaps.Stats)
+	}
+	for _, curve := range weights.Curves {
+		stat := stats.Stat(curve.Stat)
+		if len(curve.Breakpoints) > 0 && slices.Contains(cappedStats, stat) && opt.caps[stat] == 0 {
+			opt.caps[stat] = finalStats[stat] + curve.Breakpoints[0]
+		}
+	}
+	defaultCaps := defaultStatCaps(opt.weights, env.Raid.Parties[0].Players[0].GetCharacter().HasOHWeapon())
+	for stat := range opt.caps {
+		if opt.caps[stat] == 0 {
+			opt.caps[stat] = defaultCaps[stat]
+		}
+	}
+
+	if len(request.Gems) > 0 {
+		for _, gemID := range request.Gems {
+			if gem, ok := GemsByID[gemID]; ok {
+				opt.gems = append(opt.gems, gem)
+			}
+		}
+	} else {
+		for _, gem := range GemsByID {
+			if isDefaultOptimizerGem(gem) {
+				opt.gems = append(opt.gems, gem)
+			}
+		}
+	}
+	// Map iteration order is random, so sort to keep results deterministic.
+	slices.SortFunc(opt.gems, func(a, b Gem) int { return int(a.ID - b.ID) })
+
+	for _, slotEnchants := range request.Enchants {
+		for _, effectID := range slotEnchants.Enchants {
+			if enchant, ok := EnchantsByEffectID[effectID]; ok {
+				opt.enchants[slotEnchants.Slot] = append(opt.enchants[slotEnchants.Slot], enchant)
+			}
+		}
+	}
+
+	for _, reforge := range ReforgeStatsByID {
+		opt.reforges = append(opt.reforges, reforge)
+	}
+	slices.SortFunc(opt.reforges, func(a, b ReforgeStat) int { return int(a.ID - b.ID) })
+
+	return opt
+}
+
+// Returns true if the gem may be used in any number of sockets by any player,
+// for use when the request doesn't list which gems to consider. Meta gems are
+// unique, but only fit the single meta socket.
+func isDefaultOptimizerGem(gem Gem) bool {
+	if gem.RequiredProfession != proto.Profession_ProfessionUnknown {
+		return false
+	}
+	return !gem.Unique || gem.Color == proto.GemColor_GemColorMeta
+}
+
+// Computes EP values for the request with a stat weights sim.
+func calcOptimizerWeights(request *proto.OptimizeGearRequest) *proto.StatWeightValues {
+	swr := &proto.StatWeightsRequest{
+		Player:          googleProto.Clone(request.Player).(*proto.Player),
+		RaidBuffs:       request.RaidBuffs,
+		PartyBuffs:      request.PartyBuffs,
+		Debuffs:         request.Debuffs,
+		Encounter:       request.Encounter,
+		SimOptions:      googleProto.Clone(request.SimOptions).(*proto.SimOptions),
+		Tanks:           request.Tanks,
+		StatsToWeigh:    request.StatsToWeigh,
+		EpReferenceStat: request.EpReferenceStat,
+	}
+	result := CalcStatWeight(swr, stats.Stat(request.EpReferenceStat), nil)
+	return result.Dps.ToProto()
+}
+
+// Runs a raid sim of the request's player wearing the given gear, and returns the average DPS.
+func simGearDps(request *proto.OptimizeGearRequest, gear *proto.EquipmentSpec, seed int64) float64 {
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	player.Equipment = gear
+
+	raidProto := SinglePlayerRaidProto(player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+
+	simOptions := googleProto.Clone(request.SimOptions).(*proto.SimOptions)
+	simOptions.RandomSeed = seed
+
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  request.Encounter,
+		SimOptions: simOptions,
+	})
+	if result.ErrorResult != "" {
+		panic(result.ErrorResult)
+	}
+	return result.RaidMetrics.Dps.Avg
+}
+
+// If weights is nil, EP values are computed with a stat weights sim, and the
+// optimized gear is only returned if a raid sim confirms that it is better.
+func optimizeGear(request *proto.OptimizeGearRequest, weights *proto.StatWeightValues) (result *proto.OptimizeGearResult) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.OptimizeGearResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if request.Player == nil || request.Player.Equipment == nil {
+		return &proto.OptimizeGearResult{
+			ErrorResult: "optimizegear: request has no player equipment",
+		}
+	}
+	if request.Player.Database != nil {
+		addToDatabase(request.Player.Database)
+	}
+
+	simBased := weights == nil
+	if simBased {
+		if request.SimOptions == nil {
+			return &proto.OptimizeGearResult{
+				ErrorResult: "optimizegear: sim options are required when no weights are given",
+			}
+		}
+		weights = calcOptimizerWeights(request)
+	}
+
+	opt := newGearOptimizer(request, weights)
+
+	epBefore := opt.score()
+	opt.optimize(request.OptimizeGems, request.OptimizeEnchants, request.OptimizeReforges)
+
+	result = &proto.OptimizeGearResult{
+		Gear:     opt.equipment.ToEquipmentSpecProto(),
+		EpBefore: epBefore,
+		EpAfter:  opt.score(),
+	}
+
+	if simBased {
+		// Use the same seed for both sims so the comparison is not skewed by RNG.
+		seed := request.SimOptions.RandomSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		result.DpsBefore = simGearDps(request, request.Player.Equipment, seed)
+		result.DpsAfter = simGearDps(request, result.Gear, seed)
+		if result.DpsAfter < result.DpsBefore {
+			result.Gear = request.Player.Equipment
+			result.EpAfter = epBefore
+			result.DpsAfter = result.DpsBefore
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func TestGearOptimizerRespectsHitCap(t *testing.T) {
+	opt := &gearOptimizer{
+		weights: stats.Stats{stats.MeleeHit: 2, stats.MeleeCrit: 1},
+		caps:    stats.Stats{stats.MeleeHit: 40},
+		reforges: []ReforgeStat{
+			{ID: 113, FromStat: []proto.Stat{proto.Stat_StatMeleeCrit}, ToStat: []proto.Stat{proto.Stat_StatMeleeHit}, Multiplier: 0.4},
+		},
+	}
+	opt.equipment[proto.ItemSlot_ItemSlotChest] = Item{ID: 1, Type: proto.ItemType_ItemTypeChest, Stats: stats.Stats{stats.MeleeCrit: 100}}
+	opt.equipment[proto.ItemSlot_ItemSlotLegs] = Item{ID: 2, Type: proto.ItemType_ItemTypeLegs, Stats: stats.Stats{stats.MeleeCrit: 100}}
+
+	opt.optimize(false, false, true)
+
+	gearStats := opt.equipment.Stats()
+	if gearStats[stats.MeleeHit] != 40 {
+		t.Fatalf("Expected gear to reforge exactly up to the hit cap (40), got %0.0f", gearStats[stats.MeleeHit])
+	}
+	if gearStats[stats.MeleeCrit] != 160 {
+		t.Fatalf("Expected 160 crit to remain after reforging, got %0.0f", gearStats[stats.MeleeCrit])
+	}
+}
+
+func TestGearOptimizerDefaultHitCap(t *testing.T) {
+	weights := stats.Stats{stats.MeleeHit: 2, stats.MeleeCrit: 1}
+
+	// 10% hit is past the two-hand cap, but well below the dual wield cap.
+	gearHit := 10 * MeleeHitRatingPerHitChance
+	for _, tc := range []struct {
+		name      string
+		dualWield bool
+		keepsHit  bool
+	}{
+		{"two-hand", false, false},
+		{"dual wield", true, true},
+	} {
+		opt := &gearOptimizer{
+			weights: weights,
+			caps:    defaultStatCaps(weights, tc.dualWield),
+			reforges: []ReforgeStat{
+				{ID: 131, FromStat: []proto.Stat{proto.Stat_StatMeleeHit}, ToStat: []proto.Stat{proto.Stat_StatMeleeCrit}, Multiplier: 0.4},
+			},
+		}
+		opt.equipment[proto.ItemSlot_ItemSlotChest] = Item{ID: 1, Type: proto.ItemType_ItemTypeChest, Stats: stats.Stats{stats.MeleeHit: 8 * MeleeHitRatingPerHitChance}}
+		opt.equipment[proto.ItemSlot_ItemSlotLegs] = Item{ID: 2, Type: proto.ItemType_ItemTypeLegs, Stats: stats.Stats{stats.MeleeHit: 2 * MeleeHitRatingPerHitChance}}
+
+		opt.optimize(false, false, true)
+
+		if hit := opt.equipment.Stats()[stats.MeleeHit]; (hit == gearHit) != tc.keepsHit {
+			t.Fatalf("%s: expected keeping all %0.0f hit to be %t, got %0.0f hit", tc.name, gearHit, tc.keepsHit, hit)
+		}
+	}
+}
+
+func TestGearOptimizerSocketBonus(t *testing.T) {
+	redGem := Gem{ID: 1, Color: proto.GemColor_GemColorRed, Stats: stats.Stats{stats.Agility: 40}}
+	blueGem := Gem{ID: 2, Color: proto.GemColor_GemColorBlue, Stats: stats.Stats{stats.Stamina: 60}}
+
+	for _, tc := range []struct {
+		socketBonus float64
+		expected    []int32
+	}{
+		{socketBonus: 30, expected: []int32{1, 1}},
+		{socketBonus: 50, expected: []int32{1, 2}},
+	} {
+		opt := &gearOptimizer{
+			weights: stats.Stats{stats.Agility: 1},
+			gems:    []Gem{redGem, blueGem},
+		}
+		opt.equipment[proto.ItemSlot_ItemSlotHead] = Item{
+			ID:          1,
+			Type:        proto.ItemType_ItemTypeHead,
+			GemSockets:  []proto.GemColor{proto.GemColor_GemColorRed, proto.GemColor_GemColorBlue},
+			SocketBonus: stats.Stats{stats.Agility: tc.socketBonus},
+		}
+
+		opt.optimize(true, false, false)
+
+		gems := MapSlice(opt.equipment.Head().Gems, func(gem Gem) int32 { return gem.ID })
+		if len(gems) != len(tc.expected) || gems[0] != tc.expected[0] || gems[1] != tc.expected[1] {
+			t.Fatalf("Socket bonus %0.0f: expected gems %v, got %v", tc.socketBonus, tc.expected, gems)
+		}
+	}
+}
+
+func TestGearOptimizerMetaRequirements(t *testing.T) {
+	metaGem := Gem{ID: 52291, Color: proto.GemColor_GemColorMeta, MetaRequirements: &proto.MetaGemRequirements{MinRed: 3}}
+	redGem := Gem{ID: 1, Color: proto.GemColor_GemColorRed, Stats: stats.Stats{stats.Agility: 20}}
+	yellowGem := Gem{ID: 2, Color: proto.GemColor_GemColorYellow, Stats: stats.Stats{stats.Agility: 40}}
+
+	opt := &gearOptimizer{
+		weights:          stats.Stats{stats.Agility: 1},
+		gems:             []Gem{metaGem, redGem, yellowGem},
+		ensureMetaReqMet: true,
+	}
+	opt.equipment[proto.ItemSlot_ItemSlotHead] = Item{
+		ID:         1,
+		Type:       proto.ItemType_ItemTypeHead,
+		GemSockets: []proto.GemColor{proto.GemColor_GemColorMeta, proto.GemColor_GemColorYellow, proto.GemColor_GemColorYellow, proto.GemColor_GemColorYellow},
+		Gems:       []Gem{metaGem},
+	}
+
+	opt.optimize(true, false, false)
+
+	if missing := opt.equipment.numMissingMetaReqs(); missing != 0 {
+		t.Fatalf("Expected meta gem requirements to be met, still missing %d gems", missing)
+	}
+}
+
+func TestGearOptimizerDefaultGems(t *testing.T) {
+	for _, tc := range []struct {
+		gem      Gem
+		expected bool
+	}{
+		{Gem{ID: 52212, Name: "Delicate Inferno Ruby", Color: proto.GemColor_GemColorRed}, true},
+		{Gem{ID: 68778, Name: "Agile Shadowspirit Diamond", Color: proto.GemColor_GemColorMeta, Unique: true}, true},
+		{Gem{ID: 49110, Name: "Nightmare Tear", Color: proto.GemColor_GemColorPrismatic, Unique: true}, false},
+		{Gem{ID: 52255, Name: "Bold Chimera's Eye", Color: proto.GemColor_GemColorRed, RequiredProfession: proto.Profession_Jewelcrafting}, false},
+	} {
+		if actual := isDefaultOptimizerGem(tc.gem); actual != tc.expected {
+			t.Errorf("%s: expected %t, got %t", tc.gem.Name, tc.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Sets up the fake shaman alongside two target dummies which have health bars.
+func setupHealingTestSim() *Simulation {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Consumes:  &proto.Consumes{},
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			TargetDummies: 2,
+			DamageProfile: &proto.RaidDamageProfile{
+				Dtps: 1000,
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "target", Level: 83, MobType: proto.MobType_MobTypeDemon},
+			},
+			Duration: 180,
+		},
+	})
+	sim.Reset()
+
+	return sim
+}
+
+func TestAPLCastFriendlySpellSmartTarget(t *testing.T) {
+	sim := setupHealingTestSim()
+	party := sim.Raid.Parties[0]
+	player := &party.Players[0].GetCharacter().Unit
+	dummy1 := &party.Players[1].GetCharacter().Unit
+	dummy2 := &party.Players[2].GetCharacter().Unit
+
+	action := &APLActionCastFriendlySpell{
+		target:      UnitReference{fixedUnit: player},
+		smartTarget: true,
+	}
+
+	if target := action.selectTarget(sim); target != player {
+		t.Fatalf("Expected the configured target when nobody is injured, got %s", target.Label)
+	}
+
+	dummy1.RemoveHealth(sim, 2000)
+	dummy2.RemoveHealth(sim, 5000)
+	if target := action.selectTarget(sim); target != dummy2 {
+		t.Fatalf("Expected the most injured dummy, got %s", target.Label)
+	}
+
+	dummy1.RemoveHealth(sim, 4000)
+	if target := action.selectTarget(sim); target != dummy1 {
+		t.Fatalf("Expected to switch to the most injured dummy, got %s", target.Label)
+	}
+}
+
+func TestOverhealingMetrics(t *testing.T) {
+	sim := setupHealingTestSim()
+	spell := sim.Raid.Parties[0].Players[0].(*FakeAgent).Spell
+	dummy := &sim.Raid.Parties[0].Players[1].GetCharacter().Unit
+
+	heal := func(amount float64) {
+		result := spell.NewResult(dummy)
+		result.Damage = amount
+		spell.DealHealing(sim, result)
+	}
+
+	dummy.RemoveHealth(sim, 1000)
+	heal(1500)
+	if dummy.CurrentHealth() != dummy.MaxHealth() {
+		t.Fatalf("Expected the dummy to be healed to full, has %0.1f of %0.1f", dummy.CurrentHealth(), dummy.MaxHealth())
+	}
+
+	// Healing a unit at full health is entirely overhealing.
+	heal(700)
+
+	metrics := spell.SpellMetrics[dummy.UnitIndex]
+	if metrics.TotalHealing != 2200 {
+		t.Errorf("Expected 2200 healing, got %0.1f", metrics.TotalHealing)
+	}
+	if metrics.TotalOverhealing != 1200 {
+		t.Errorf("Expected 1200 overhealing, got %0.1f", metrics.TotalOverhealing)
+	}
+}
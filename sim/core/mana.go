@@ -99,6 +99,9 @@ func (unit *Unit) AddMana(sim *Simulation, amount float64, metrics *ResourceMetr
 	if sim.Log != nil {
 		unit.Log(sim, "Gained %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, oldMana, newMana, unit.MaxMana())
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, unit, metrics, amount, newMana-oldMana, newMana)
+	}
 
 	unit.currentMana = newMana
 	unit.Metrics.ManaGained += newMana - oldMana
@@ -115,6 +118,9 @@ func (unit *Unit) SpendMana(sim *Simulation, amount float64, metrics *ResourceMe
 	if sim.Log != nil {
 		unit.Log(sim, "Spent %0.3f mana from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, unit.CurrentMana(), newMana, unit.MaxMana())
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, unit, metrics, -amount, -amount, newMana)
+	}
 
 	unit.currentMana = newMana
 	unit.Metrics.ManaSpent += amount
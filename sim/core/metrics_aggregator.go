@@ -89,8 +89,14 @@ type UnitMetrics struct {
 	dtps   DistributionMetrics
 	tmi    DistributionMetrics
 	hps    DistributionMetrics
+	ehps   DistributionMetrics
 	tto    DistributionMetrics
 
+	// Only used for targets.
+	lifetime     time.Duration
+	lifetimeSum  float64
+	lifetimeDtps DistributionMetrics
+
 	tmiList   []tmiListItem
 	isTanking bool
 	tmiBin    int32
@@ -155,11 +161,44 @@ type SpellMetrics struct {
 	Parries int32
 	Blocks  int32
 
-	TotalDamage    float64 // Damage done by all casts of this spell.
-	TotalThreat    float64 // Threat generated by all casts of this spell.
-	TotalHealing   float64 // Healing done by all casts of this spell.
-	TotalShielding float64 // Shielding done by all casts of this spell.
-	TotalCastTime  time.Duration
+	TotalDamage      float64 // Damage done by all casts of this spell.
+	TotalThreat      float64 // Threat generated by all casts of this spell.
+	TotalHealing     float64 // Healing done by all casts of this spell.
+	TotalOverhealing float64 // Portion of TotalHealing which exceeded the target's missing health.
+	TotalShielding   float64 // Shielding done by all casts of this spell.
+	TotalCastTime    time.Duration
+
+	// Damage split by direct (0) or periodic (1), then by unresisted (0) or
+	// partially resisted (1), then by outcome.
+	DamageOutcomes [2][2][numHitOutcomes]DamageOutcomeMetrics
+}
+
+const numHitOutcomes = int(proto.HitOutcome_HitOutcomeCrush) + 1
+
+// Damage totals for a single outcome of a spell.
+type DamageOutcomeMetrics struct {
+	Count     int32
+	Damage    float64
+	MinDamage float64
+	MaxDamage float64
+}
+
+func (dom *DamageOutcomeMetrics) addHit(damage float64) {
+	dom.merge(&DamageOutcomeMetrics{Count: 1, Damage: damage, MinDamage: damage, MaxDamage: damage})
+}
+
+func (dom *DamageOutcomeMetrics) merge(other *DamageOutcomeMetrics) {
+	if other.Count == 0 {
+		return
+	}
+	if dom.Count == 0 || other.MinDamage < dom.MinDamage {
+		dom.MinDamage = other.MinDamage
+	}
+	if dom.Count == 0 || other.MaxDamage > dom.MaxDamage {
+		dom.MaxDamage = other.MaxDamage
+	}
+	dom.Count += other.Count
+	dom.Damage += other.Damage
 }
 
 type TargetedActionMetrics struct {
@@ -174,30 +213,56 @@ type TargetedActionMetrics struct {
 	Blocks  int32
 	Glances int32
 
-	Damage    float64
-	Threat    float64
-	Healing   float64
-	Shielding float64
-	CastTime  time.Duration
+	Damage      float64
+	Threat      float64
+	Healing     float64
+	Overhealing float64
+	Shielding   float64
+	CastTime    time.Duration
+
+	DamageOutcomes [2][2][numHitOutcomes]DamageOutcomeMetrics
 }
 
 func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
+	var damageOutcomes []*proto.DamageOutcomeMetrics
+	for periodic, resists := range tam.DamageOutcomes {
+		for partialResist, outcomes := range resists {
+			for outcome, dom := range outcomes {
+				if dom.Count == 0 {
+					continue
+				}
+				damageOutcomes = append(damageOutcomes, &proto.DamageOutcomeMetrics{
+					Outcome:         proto.HitOutcome(outcome),
+					IsPeriodic:      periodic == 1,
+					IsPartialResist: partialResist == 1,
+					Count:           dom.Count,
+					Damage:          dom.Damage,
+					MinDamage:       dom.MinDamage,
+					MaxDamage:       dom.MaxDamage,
+				})
+			}
+		}
+	}
+
 	return &proto.TargetedActionMetrics{
 		UnitIndex: tam.UnitIndex,
 
-		Casts:      tam.Casts,
-		Hits:       tam.Hits,
-		Crits:      tam.Crits,
-		Misses:     tam.Misses,
-		Dodges:     tam.Dodges,
-		Parries:    tam.Parries,
-		Blocks:     tam.Blocks,
-		Glances:    tam.Glances,
-		Damage:     tam.Damage,
-		Threat:     tam.Threat,
-		Healing:    tam.Healing,
-		Shielding:  tam.Shielding,
-		CastTimeMs: float64(tam.CastTime.Milliseconds()),
+		Casts:       tam.Casts,
+		Hits:        tam.Hits,
+		Crits:       tam.Crits,
+		Misses:      tam.Misses,
+		Dodges:      tam.Dodges,
+		Parries:     tam.Parries,
+		Blocks:      tam.Blocks,
+		Glances:     tam.Glances,
+		Damage:      tam.Damage,
+		Threat:      tam.Threat,
+		Healing:     tam.Healing,
+		Overhealing: tam.Overhealing,
+		Shielding:   tam.Shielding,
+		CastTimeMs:  float64(tam.CastTime.Milliseconds()),
+
+		DamageOutcomes: damageOutcomes,
 	}
 }
 
@@ -209,8 +274,11 @@ func NewUnitMetrics() UnitMetrics {
 		dtps:    NewDistributionMetrics(),
 		tmi:     NewDistributionMetrics(),
 		hps:     NewDistributionMetrics(),
+		ehps:    NewDistributionMetrics(),
 		tto:     NewDistributionMetrics(),
 		actions: make(map[ActionID]*ActionMetrics),
+
+		lifetimeDtps: NewDistributionMetrics(),
 	}
 }
 
@@ -333,8 +401,16 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 		tam.Damage += spellTargetMetrics.TotalDamage
 		tam.Threat += spellTargetMetrics.TotalThreat
 		tam.Healing += spellTargetMetrics.TotalHealing
+		tam.Overhealing += spellTargetMetrics.TotalOverhealing
 		tam.Shielding += spellTargetMetrics.TotalShielding
 		tam.CastTime += spellTargetMetrics.TotalCastTime
+		for periodic := range tam.DamageOutcomes {
+			for partialResist := range tam.DamageOutcomes[periodic] {
+				for outcome := range tam.DamageOutcomes[periodic][partialResist] {
+					tam.DamageOutcomes[periodic][partialResist][outcome].merge(&spellTargetMetrics.DamageOutcomes[periodic][partialResist][outcome])
+				}
+			}
+		}
 
 		target := spell.Unit.AttackTables[i].Defender
 		target.Metrics.dtps.Total += spellTargetMetrics.TotalDamage
@@ -344,6 +420,7 @@ func (unitMetrics *UnitMetrics) addSpellMetrics(spell *Spell, actionID ActionID,
 			unitMetrics.threat.Total += spellTargetMetrics.TotalThreat
 		} else {
 			unitMetrics.hps.Total += spellTargetMetrics.TotalHealing + spellTargetMetrics.TotalShielding
+			unitMetrics.ehps.Total += spellTargetMetrics.TotalHealing - spellTargetMetrics.TotalOverhealing + spellTargetMetrics.TotalShielding
 		}
 	}
 }
@@ -376,7 +453,10 @@ func (unitMetrics *UnitMetrics) reset() {
 	unitMetrics.tmi.reset()
 	unitMetrics.tmiList = nil
 	unitMetrics.hps.reset()
+	unitMetrics.ehps.reset()
 	unitMetrics.tto.reset()
+	unitMetrics.lifetime = 0
+	unitMetrics.lifetimeDtps.reset()
 	unitMetrics.CharacterIterationMetrics = CharacterIterationMetrics{}
 
 	for _, resourceMetrics := range unitMetrics.resources {
@@ -414,12 +494,22 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 		unitMetrics.tmi.Total *= sim.Duration.Seconds()
 	}
 
+	if unit.Type == EnemyUnit {
+		unitMetrics.lifetimeSum += unitMetrics.lifetime.Seconds()
+		if unitMetrics.lifetime > 0 {
+			// Hack because of the way DistributionMetrics does its calculations.
+			unitMetrics.lifetimeDtps.Total = unitMetrics.dtps.Total * sim.Duration.Seconds() / unitMetrics.lifetime.Seconds()
+		}
+		unitMetrics.lifetimeDtps.doneIteration(sim)
+	}
+
 	unitMetrics.dps.doneIteration(sim)
 	unitMetrics.dpasp.doneIteration(sim)
 	unitMetrics.threat.doneIteration(sim)
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
+	unitMetrics.ehps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
@@ -509,9 +599,12 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Dtps:          unitMetrics.dtps.ToProto(),
 		Tmi:           unitMetrics.tmi.ToProto(),
 		Hps:           unitMetrics.hps.ToProto(),
+		Ehps:          unitMetrics.ehps.ToProto(),
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+		LifetimeAvg:   unitMetrics.lifetimeSum / n,
+		LifetimeDtps:  unitMetrics.lifetimeDtps.ToProto(),
 	}
 
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
@@ -184,6 +184,9 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		pet.Log(sim, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats).FlatString())
 		pet.Log(sim, "Pet summoned")
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.petSummon(sim, pet)
+	}
 
 	sim.addTracker(&pet.auraTracker)
 
@@ -291,6 +291,9 @@ func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats
 		for playerIdx, player := range party.Players {
 			if playerIdx >= len(partyConfig.Players) {
 				// This happens for target dummies.
+				if dummy, ok := player.(*TargetDummy); ok {
+					dummy.applyRaidDamageProfile(raidConfig.DamageProfile)
+				}
 				continue
 			}
 			playerConfig := partyConfig.Players[playerIdx]
@@ -2,7 +2,10 @@ package core
 
 import (
 	"math"
+	"math/bits"
 	"math/rand"
+
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 // implementing Source or Source64 is possible, but adds too much overhead
@@ -16,6 +19,18 @@ type Rand interface {
 	rand.Source64
 }
 
+// Returns a new generator of the given type.
+func NewRand(generator proto.RandomGenerator, seed uint64) Rand {
+	switch generator {
+	case proto.RandomGenerator_RandomGeneratorPcg:
+		return NewPCG(seed)
+	case proto.RandomGenerator_RandomGeneratorXoshiro256StarStar:
+		return NewXoshiro256StarStar(seed)
+	default:
+		return NewSplitMix(seed)
+	}
+}
+
 // wraps go's default source; will panic if it's not a Source64
 func NewGoRand(seed uint64) *GoRand {
 	return &GoRand{rand.NewSource(int64(seed)).(rand.Source64)}
@@ -71,3 +86,113 @@ func (sm *SplitMix64) Int63() int64 {
 func (sm *SplitMix64) Uint64() uint64 {
 	return sm.Next()
 }
+
+func NewPCG(seed uint64) *PCG {
+	pcg := &PCG{}
+	pcg.Seed(int64(seed))
+	return pcg
+}
+
+// PCG with a 128-bit LCG state and the DXSM output function, as in go's math/rand/v2.
+// See https://www.pcg-random.org
+type PCG struct {
+	hi, lo uint64
+	start  uint64 // track starting seed
+}
+
+func (p *PCG) Next() uint64 {
+	const (
+		mulHi = 0x2360ed051fc65da4
+		mulLo = 0x4385df649fccf645
+		incHi = 0x5851f42d4c957f2d
+		incLo = 0x14057b7ef767814f
+	)
+
+	// state = state*mul + inc
+	hi, lo := bits.Mul64(p.lo, mulLo)
+	hi += p.hi*mulLo + p.lo*mulHi
+	lo, carry := bits.Add64(lo, incLo, 0)
+	hi, _ = bits.Add64(hi, incHi, carry)
+	p.hi, p.lo = hi, lo
+
+	const cheapMul = 0xda942042e4dd58b5
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> 48
+	hi *= lo | 1
+	return hi
+}
+
+func (p *PCG) NextFloat64() float64 {
+	return float64(p.Next()>>11) * 0x1p-53
+}
+
+// Expands the seed to the full state with SplitMix64, so that similar seeds give unrelated streams.
+func (p *PCG) Seed(s int64) {
+	sm := NewSplitMix(uint64(s))
+	p.hi, p.lo = sm.Next(), sm.Next()
+	p.start = uint64(s)
+}
+
+func (p *PCG) GetSeed() int64 {
+	return int64(p.start)
+}
+
+func (p *PCG) Int63() int64 {
+	return int64(p.Next() & math.MaxInt64)
+}
+
+func (p *PCG) Uint64() uint64 {
+	return p.Next()
+}
+
+func NewXoshiro256StarStar(seed uint64) *Xoshiro256StarStar {
+	x := &Xoshiro256StarStar{}
+	x.Seed(int64(seed))
+	return x
+}
+
+// adapted from https://prng.di.unimi.it/xoshiro256starstar.c
+type Xoshiro256StarStar struct {
+	s     [4]uint64
+	start uint64 // track starting seed
+}
+
+func (x *Xoshiro256StarStar) Next() uint64 {
+	result := bits.RotateLeft64(x.s[1]*5, 7) * 9
+	t := x.s[1] << 17
+
+	x.s[2] ^= x.s[0]
+	x.s[3] ^= x.s[1]
+	x.s[1] ^= x.s[2]
+	x.s[0] ^= x.s[3]
+
+	x.s[2] ^= t
+	x.s[3] = bits.RotateLeft64(x.s[3], 45)
+	return result
+}
+
+func (x *Xoshiro256StarStar) NextFloat64() float64 {
+	return float64(x.Next()>>11) * 0x1p-53
+}
+
+// Expands the seed to the full state with SplitMix64, as recommended by the authors.
+func (x *Xoshiro256StarStar) Seed(s int64) {
+	sm := NewSplitMix(uint64(s))
+	for i := range x.s {
+		x.s[i] = sm.Next()
+	}
+	x.start = uint64(s)
+}
+
+func (x *Xoshiro256StarStar) GetSeed() int64 {
+	return int64(x.start)
+}
+
+func (x *Xoshiro256StarStar) Int63() int64 {
+	return int64(x.Next() & math.MaxInt64)
+}
+
+func (x *Xoshiro256StarStar) Uint64() uint64 {
+	return x.Next()
+}
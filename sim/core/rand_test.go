@@ -2,7 +2,10 @@ package core
 
 import (
 	"math"
+	"slices"
 	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func TestSplitMix64(t *testing.T) {
@@ -34,6 +37,89 @@ func TestSplitMix64(t *testing.T) {
 	t.Logf("chiSquare = %.1f", chiSquare)
 }
 
+func TestRandGenerators(t *testing.T) {
+	// Expected values are from go's math/rand/v2 PCG and the reference
+	// xoshiro256** implementation, seeded with the SplitMix64 expansion of 12345.
+	for _, tc := range []struct {
+		generator proto.RandomGenerator
+		expected  []uint64
+	}{
+		{proto.RandomGenerator_RandomGeneratorPcg, []uint64{0x7abd78ade727fbc7, 0x9ddc8975598e2bf8, 0xcbf0c81f8f00dd9f}},
+		{proto.RandomGenerator_RandomGeneratorXoshiro256StarStar, []uint64{0xbe6a36374160d49b, 0x214aaa0637a688c6, 0xf69d16de9954d388}},
+	} {
+		t.Run(tc.generator.String(), func(t *testing.T) {
+			r := NewRand(tc.generator, 12345)
+			for i, expected := range tc.expected {
+				if actual := r.Next(); actual != expected {
+					t.Fatalf("value %d = %#x, expected %#x", i, actual, expected)
+				}
+			}
+
+			r.Seed(12345)
+			if actual := r.Next(); actual != tc.expected[0] || r.GetSeed() != 12345 {
+				t.Fatalf("reseeding gave %#x with seed %d, expected %#x with seed 12345", actual, r.GetSeed(), tc.expected[0])
+			}
+
+			distribution := make([]int, 500)
+			n := 10_000_000
+			for i := 0; i < n; i++ {
+				f := r.NextFloat64()
+				if f < 0 || f >= 1 {
+					t.Fatalf("NextFloat64() = %f, not in [0, 1)", f)
+				}
+				distribution[int(math.Trunc(f*500))]++
+			}
+			e := float64(n) / 500
+			var chiSquare float64
+			for _, v := range distribution {
+				chiSquare += (float64(v) - e) * (float64(v) - e) / e
+			}
+			if chiSquare > 602.3 {
+				t.Fatalf("fails chi-square (k = 500) at a = 0.001 (%.1f >= 602.3)", chiSquare)
+			}
+		})
+	}
+}
+
+func TestCommonRandomNumbers(t *testing.T) {
+	newSim := func(commonRandomNumbers bool) *Simulation {
+		return newSimWithEnv(&Environment{}, &proto.SimOptions{
+			RandomSeed:          101,
+			RandomGenerator:     proto.RandomGenerator_RandomGeneratorXoshiro256StarStar,
+			CommonRandomNumbers: commonRandomNumbers,
+		})
+	}
+
+	// A proc should see the same rolls in an iteration, however many other rolls happen.
+	procRolls := func(sim *Simulation, iteration int64, otherRolls int) []float64 {
+		sim.reseedRands(iteration)
+		var rolls []float64
+		for i := 0; i < 3; i++ {
+			for j := 0; j < otherRolls; j++ {
+				sim.RandomFloat("Damage Roll")
+			}
+			rolls = append(rolls, sim.RandomFloat("Proc"))
+		}
+		return rolls
+	}
+
+	sim := newSim(true)
+	expected := procRolls(sim, 5, 0)
+	if actual := procRolls(newSim(true), 5, 2); !slices.Equal(actual, expected) {
+		t.Fatalf("proc rolls with other rolls = %v, expected %v", actual, expected)
+	}
+	if actual := procRolls(sim, 6, 0); slices.Equal(actual, expected) {
+		t.Fatalf("proc rolls for the next iteration should be different, got %v", actual)
+	}
+	if actual := procRolls(sim, 5, 1); !slices.Equal(actual, expected) {
+		t.Fatalf("proc rolls after reseeding = %v, expected %v", actual, expected)
+	}
+
+	if actual := procRolls(newSim(false), 5, 2); slices.Equal(actual, expected) {
+		t.Fatalf("proc rolls without common random numbers should share a stream with other rolls")
+	}
+}
+
 var result float64
 
 func BenchmarkRnds(b *testing.B) {
@@ -101,6 +187,22 @@ func BenchmarkRnds(b *testing.B) {
 		result += sum
 	})
 
+	for _, generator := range []proto.RandomGenerator{proto.RandomGenerator_RandomGeneratorPcg, proto.RandomGenerator_RandomGeneratorXoshiro256StarStar} {
+		r := NewRand(generator, 444)
+		b.Run(generator.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			var sum float64
+			for i := 0; i < b.N; i++ {
+				sum += r.NextFloat64()
+				sum += r.NextFloat64()
+				sum += r.NextFloat64()
+				sum += r.NextFloat64()
+				sum += r.NextFloat64()
+			}
+			result += sum
+		})
+	}
+
 	b.Run("Addition", func(b *testing.B) {
 		var sum float64
 		for i := 0; i < b.N; i++ {
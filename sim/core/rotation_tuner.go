@@ -0,0 +1,440 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	goproto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+const (
+	defaultTuneInitialIterations = 1000
+	defaultTuneMaxCandidates     = 64
+	defaultTuneGridPoints        = 5
+
+	// Rounds allowed beyond plain successive halving, for candidates which are
+	// too close to the cut line to be dropped.
+	tuneExtraRounds = 2
+)
+
+// Runs a single raid sim for the tuner, replaceable for tests.
+type tuneSimRunner func(*proto.RaidSimRequest) *proto.RaidSimResult
+
+func runConcurrentTuneSim(request *proto.RaidSimRequest) *proto.RaidSimResult {
+	progress := make(chan *proto.ProgressMetrics, 10)
+	RunConcurrentRaidSimAsync(context.Background(), request, progress)
+	var result *proto.RaidSimResult
+	for msg := range progress {
+		if msg.FinalRaidResult != nil {
+			result = msg.FinalRaidResult
+		}
+	}
+	return result
+}
+
+func runTuneRotation(request *proto.RaidSimRequest, settings *proto.TuneSettings, progress chan *proto.ProgressMetrics) *proto.TuneRotationResult {
+	result, err := tuneRotation(request, settings, runConcurrentTuneSim, progress)
+	if err != nil {
+		result = &proto.TuneRotationResult{
+			ErrorResult: err.Error(),
+		}
+	}
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			FinalTuneResult: result,
+		}
+		close(progress)
+	}
+
+	return result
+}
+
+type tunableConst struct {
+	format func(float64) string
+	values []float64
+	orig   string
+
+	// The original value in the constant's units, so that e.g. '1.50s' and
+	// '1.5s' are recognized as the same value.
+	origValue float64
+}
+
+type tuneCandidate struct {
+	values   []string
+	original bool
+
+	// Running totals over all rounds, combined with the parallel variance algorithm.
+	iterations int32
+	mean       float64
+	m2         float64
+	rounds     int32
+
+	request *proto.RaidSimRequest
+}
+
+func (c *tuneCandidate) addResult(iterations int32, dps *proto.DistributionMetrics) {
+	n, k := float64(c.iterations), float64(iterations)
+	delta := dps.Avg - c.mean
+	c.iterations += iterations
+	c.mean += delta * k / (n + k)
+	c.m2 += dps.Stdev*dps.Stdev*k + delta*delta*n*k/(n+k)
+}
+
+func (c *tuneCandidate) stdev() float64 {
+	return math.Sqrt(c.m2 / float64(c.iterations))
+}
+
+// Returns the bounds of the 95% confidence interval for the mean DPS.
+func (c *tuneCandidate) confidenceInterval() (float64, float64) {
+	margin := 1.96 * c.stdev() / math.Sqrt(float64(c.iterations))
+	return c.mean - margin, c.mean + margin
+}
+
+func (c *tuneCandidate) toProto() *proto.TunedCandidate {
+	ciLower, ciUpper := c.confidenceInterval()
+	return &proto.TunedCandidate{
+		Values:     c.values,
+		Dps:        c.mean,
+		DpsStdev:   c.stdev(),
+		Iterations: c.iterations,
+		DpsCiLower: ciLower,
+		DpsCiUpper: ciUpper,
+		Rounds:     c.rounds,
+		Original:   c.original,
+	}
+}
+
+// Searches for the best values of the tunable constants with successive
+// halving: every round sims all remaining candidates, keeps the better half,
+// and doubles the iterations for the next round. Candidates below the cut
+// whose confidence interval still overlaps the worst kept candidate are kept
+// as well, for up to tuneExtraRounds more rounds than plain halving needs.
+// All candidates in a round use the same seed, so that their differences are
+// not dominated by RNG. Each round starts from the seeds after the ones used by
+// the previous round, since every iteration uses the next seed and results are
+// pooled across rounds as independent samples.
+func tuneRotation(request *proto.RaidSimRequest, settings *proto.TuneSettings, runSim tuneSimRunner, progress chan *proto.ProgressMetrics) (result *proto.TuneRotationResult, resultErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			result = &proto.TuneRotationResult{
+				ErrorResult: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack())),
+			}
+		}
+	}()
+
+	if request.GetSimOptions() == nil {
+		return nil, errors.New("tunerotation: request has no sim options")
+	}
+	if len(settings.GetTunables()) == 0 {
+		return nil, errors.New("tunerotation: no tunable constants")
+	}
+
+	tunables := make([]*tunableConst, len(settings.Tunables))
+	for i, config := range settings.Tunables {
+		constant, err := findTunableConst(request, config)
+		if err != nil {
+			return nil, err
+		}
+		tunable, err := newTunableConst(config, constant.Val)
+		if err != nil {
+			return nil, err
+		}
+		tunables[i] = tunable
+	}
+
+	candidates := makeTuneCandidates(request, settings, tunables)
+
+	initialIterations := settings.InitialIterations
+	if initialIterations <= 0 {
+		initialIterations = defaultTuneInitialIterations
+	}
+	nextSeed := request.SimOptions.RandomSeed
+	if nextSeed == 0 {
+		nextSeed = time.Now().UnixNano()
+	}
+
+	maxRounds := int32(len(halvingRoundSizes(len(candidates), math.MaxInt32))) + tuneExtraRounds
+	completedSims := 0
+
+	remaining := candidates
+	for round := int32(1); ; round++ {
+		// Assumes the remaining rounds halve the candidates, so the total grows
+		// when candidates are carried over.
+		totalSims := completedSims
+		for _, n := range halvingRoundSizes(len(remaining), int(maxRounds-round+1)) {
+			totalSims += n
+		}
+
+		iterations := initialIterations << (round - 1)
+		for _, candidate := range remaining {
+			candidate.request.SimOptions.Iterations = iterations
+			candidate.request.SimOptions.RandomSeed = nextSeed
+			simResult := runSim(candidate.request)
+			if simResult == nil {
+				return nil, errors.New("tunerotation: sim returned no result")
+			}
+			if simResult.ErrorResult != "" {
+				return nil, fmt.Errorf("tunerotation: sim failed with values %s: %s", strings.Join(candidate.values, ", "), simResult.ErrorResult)
+			}
+			candidate.addResult(iterations, simResult.RaidMetrics.Dps)
+			candidate.rounds = round
+
+			completedSims++
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					CompletedSims: int32(completedSims),
+					TotalSims:     int32(totalSims),
+					Dps:           slices.MaxFunc(remaining, compareTuneCandidates).mean,
+				}
+			}
+		}
+
+		nextSeed += int64(iterations)
+
+		slices.SortStableFunc(remaining, func(a, b *tuneCandidate) int { return compareTuneCandidates(b, a) })
+		if len(remaining) == 1 || round == maxRounds {
+			break
+		}
+
+		cut := (len(remaining) + 1) / 2
+		cutLower, _ := remaining[cut-1].confidenceInterval()
+		// Copied, since remaining shares its array with candidates.
+		kept := slices.Clone(remaining[:cut])
+		for _, candidate := range remaining[cut:] {
+			if _, upper := candidate.confidenceInterval(); upper >= cutLower {
+				kept = append(kept, candidate)
+			}
+		}
+		remaining = kept
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *tuneCandidate) int {
+		if a.rounds != b.rounds {
+			return int(b.rounds - a.rounds)
+		}
+		return compareTuneCandidates(b, a)
+	})
+
+	best := candidates[0]
+	bestSettings := goproto.Clone(request).(*proto.RaidSimRequest)
+	for i, config := range settings.Tunables {
+		constant, _ := findTunableConst(bestSettings, config)
+		constant.Val = best.values[i]
+	}
+
+	return &proto.TuneRotationResult{
+		Best:         best.toProto(),
+		Candidates:   MapSlice(candidates, (*tuneCandidate).toProto),
+		BestSettings: bestSettings,
+	}, nil
+}
+
+// Returns the number of candidates simmed in each round of plain successive
+// halving, starting from n candidates and limited to maxRounds rounds.
+func halvingRoundSizes(n int, maxRounds int) []int {
+	var sizes []int
+	for len(sizes) < maxRounds {
+		sizes = append(sizes, n)
+		if n == 1 {
+			break
+		}
+		n = (n + 1) / 2
+	}
+	return sizes
+}
+
+func compareTuneCandidates(a, b *tuneCandidate) int {
+	if a.mean < b.mean {
+		return -1
+	} else if a.mean > b.mean {
+		return 1
+	}
+	return 0
+}
+
+// Returns one candidate per combination of tunable values, sampled randomly
+// if there are too many. The original values are always included.
+func makeTuneCandidates(request *proto.RaidSimRequest, settings *proto.TuneSettings, tunables []*tunableConst) []*tuneCandidate {
+	maxCandidates := int(settings.MaxCandidates)
+	if maxCandidates <= 0 {
+		maxCandidates = defaultTuneMaxCandidates
+	}
+
+	numCombinations := 1
+	for _, tunable := range tunables {
+		numCombinations *= len(tunable.values)
+		if numCombinations > math.MaxInt32 {
+			numCombinations = math.MaxInt32
+		}
+	}
+
+	var combinations []int
+	if numCombinations <= maxCandidates {
+		for i := 0; i < numCombinations; i++ {
+			combinations = append(combinations, i)
+		}
+	} else {
+		rng := rand.New(rand.NewSource(request.SimOptions.RandomSeed))
+		seen := map[int]bool{}
+		for len(combinations) < maxCandidates {
+			if i := rng.Intn(numCombinations); !seen[i] {
+				seen[i] = true
+				combinations = append(combinations, i)
+			}
+		}
+		slices.Sort(combinations)
+	}
+
+	newCandidate := func(values []string) *tuneCandidate {
+		candidate := &tuneCandidate{
+			values:  values,
+			request: goproto.Clone(request).(*proto.RaidSimRequest),
+		}
+		for i, config := range settings.Tunables {
+			constant, _ := findTunableConst(candidate.request, config)
+			constant.Val = values[i]
+		}
+		return candidate
+	}
+
+	var candidates []*tuneCandidate
+	hasOriginal := false
+	for _, combination := range combinations {
+		values := make([]string, len(tunables))
+		isOriginal := true
+		for i, tunable := range tunables {
+			value := tunable.values[combination%len(tunable.values)]
+			combination /= len(tunable.values)
+			values[i] = tunable.format(value)
+			isOriginal = isOriginal && math.Abs(value-tunable.origValue) < 1e-9
+		}
+		candidate := newCandidate(values)
+		candidate.original = isOriginal
+		hasOriginal = hasOriginal || isOriginal
+		candidates = append(candidates, candidate)
+	}
+	if !hasOriginal {
+		candidate := newCandidate(MapSlice(tunables, func(tunable *tunableConst) string { return tunable.orig }))
+		candidate.original = true
+		candidates = append([]*tuneCandidate{candidate}, candidates...)
+	}
+	return candidates
+}
+
+func newTunableConst(config *proto.TunableConstant, orig string) (*tunableConst, error) {
+	if config.Max < config.Min {
+		return nil, fmt.Errorf("tunerotation: '%s' has max %g below min %g", config.Path, config.Max, config.Min)
+	}
+
+	// Values are written in the same units as the original constant.
+	formatFloat := func(value float64) string { return strconv.FormatFloat(value, 'f', -1, 64) }
+	tunable := &tunableConst{
+		format: formatFloat,
+		orig:   orig,
+	}
+	number := orig
+	if _, err := strconv.ParseFloat(orig, 64); err == nil {
+		// Plain number.
+	} else if strings.HasSuffix(orig, "%") {
+		number = strings.TrimSuffix(orig, "%")
+		tunable.format = func(value float64) string { return formatFloat(value) + "%" }
+	} else if _, err := time.ParseDuration(orig); err == nil {
+		// Durations keep their unit, e.g. a '500ms' constant is tuned in milliseconds.
+		unit := strings.TrimLeft(orig, "+-0123456789.")
+		if strings.ContainsAny(unit, "0123456789.") {
+			return nil, fmt.Errorf("tunerotation: '%s' mixes duration units: '%s'", config.Path, orig)
+		}
+		number = strings.TrimSuffix(orig, unit)
+		tunable.format = func(value float64) string { return formatFloat(value) + unit }
+	}
+	origValue, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return nil, fmt.Errorf("tunerotation: '%s' is not a numeric constant: '%s'", config.Path, orig)
+	}
+	tunable.origValue = origValue
+
+	if config.Step > 0 {
+		for i := 0; ; i++ {
+			// Rounded to avoid float artifacts such as 0.30000000000000004.
+			value := math.Round((config.Min+float64(i)*config.Step)*1e6) / 1e6
+			if value > config.Max+1e-9 {
+				break
+			}
+			tunable.values = append(tunable.values, value)
+		}
+	} else if config.Max == config.Min {
+		tunable.values = []float64{config.Min}
+	} else {
+		for i := 0; i < defaultTuneGridPoints; i++ {
+			value := config.Min + (config.Max-config.Min)*float64(i)/float64(defaultTuneGridPoints-1)
+			tunable.values = append(tunable.values, math.Round(value*1e6)/1e6)
+		}
+	}
+	return tunable, nil
+}
+
+// Follows a TunableConstant's path to the constant it refers to.
+func findTunableConst(request *proto.RaidSimRequest, config *proto.TunableConstant) (*proto.APLValueConst, error) {
+	parties := request.GetRaid().GetParties()
+	if int(config.PartyIndex) >= len(parties) || int(config.PlayerIndex) >= len(parties[config.PartyIndex].Players) {
+		return nil, fmt.Errorf("tunerotation: no player at party %d, index %d", config.PartyIndex, config.PlayerIndex)
+	}
+	rotation := parties[config.PartyIndex].Players[config.PlayerIndex].Rotation
+	if rotation == nil {
+		return nil, fmt.Errorf("tunerotation: player at party %d, index %d has no rotation", config.PartyIndex, config.PlayerIndex)
+	}
+
+	msg := rotation.ProtoReflect()
+	for _, segment := range strings.Split(config.Path, ".") {
+		name, idx := segment, -1
+		if open := strings.IndexByte(segment, '['); open != -1 && strings.HasSuffix(segment, "]") {
+			i, err := strconv.Atoi(segment[open+1 : len(segment)-1])
+			if err != nil {
+				return nil, fmt.Errorf("tunerotation: invalid index in '%s'", segment)
+			}
+			name, idx = segment[:open], i
+		}
+
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || fd.Message() == nil {
+			return nil, fmt.Errorf("tunerotation: %s has no message field '%s'", msg.Descriptor().Name(), name)
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			if idx < 0 || idx >= list.Len() {
+				return nil, fmt.Errorf("tunerotation: index out of range in '%s'", segment)
+			}
+			msg = list.Get(idx).Message()
+		} else {
+			if idx != -1 {
+				return nil, fmt.Errorf("tunerotation: '%s' is not a list", name)
+			}
+			if !msg.Has(fd) {
+				return nil, fmt.Errorf("tunerotation: '%s' is not set in '%s'", name, config.Path)
+			}
+			msg = msg.Get(fd).Message()
+		}
+	}
+
+	switch value := msg.Interface().(type) {
+	case *proto.APLValue:
+		if constant := value.GetConst(); constant != nil {
+			return constant, nil
+		}
+	case *proto.APLValueConst:
+		return value, nil
+	}
+	return nil, fmt.Errorf("tunerotation: '%s' is not a constant", config.Path)
+}
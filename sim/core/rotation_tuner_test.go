@@ -0,0 +1,223 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func makeTuneTestRequest() *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: []*proto.Player{{
+					Rotation: APLRotationFromJsonString(`{
+						"type": "TypeAPL",
+						"priorityList": [
+							{"action": {
+								"condition": {"cmp": {"op": "OpGt", "lhs": {"currentEnergy": {}}, "rhs": {"const": {"val": "50"}}}},
+								"castSpell": {"spellId": {"spellId": 1}}
+							}},
+							{"action": {
+								"condition": {"cmp": {"op": "OpLt", "lhs": {"remainingTime": {}}, "rhs": {"const": {"val": "4s"}}}},
+								"castSpell": {"spellId": {"spellId": 2}}
+							}}
+						]
+					}`),
+				}},
+			}},
+		},
+		SimOptions: &proto.SimOptions{RandomSeed: 1},
+	}
+}
+
+func TestTuneRotation(t *testing.T) {
+	request := makeTuneTestRequest()
+	settings := &proto.TuneSettings{
+		Tunables: []*proto.TunableConstant{
+			{Path: "priority_list[0].action.condition.cmp.rhs", Min: 0, Max: 100, Step: 10},
+			{Path: "priority_list[1].action.condition.cmp.rhs.const", Min: 2, Max: 6},
+		},
+		InitialIterations: 100,
+	}
+
+	// DPS peaks at an energy threshold of 70 and an execute window of 5s.
+	simmed := 0
+	runSim := func(request *proto.RaidSimRequest) *proto.RaidSimResult {
+		simmed++
+		energy, _ := findTunableConst(request, settings.Tunables[0])
+		window, _ := findTunableConst(request, settings.Tunables[1])
+		e, _ := strconv.ParseFloat(energy.Val, 64)
+		w, _ := strconv.ParseFloat(strings.TrimSuffix(window.Val, "s"), 64)
+		dps := 1000 - (e-70)*(e-70) - 10*(w-5)*(w-5)
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: dps, Stdev: 5}},
+		}
+	}
+
+	progress := make(chan *proto.ProgressMetrics, 1000)
+	result, err := tuneRotation(request, settings, runSim, progress)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 11 energy values * 5 window values, which include the original values.
+	if len(result.Candidates) != 55 {
+		t.Fatalf("Expected 55 candidates, got %d", len(result.Candidates))
+	}
+	if !slices.ContainsFunc(result.Candidates, func(c *proto.TunedCandidate) bool { return c.Original && c.Values[0] == "50" && c.Values[1] == "4s" }) {
+		t.Fatalf("Expected the original values to be a candidate")
+	}
+	if best := result.Best.Values; best[0] != "70" || best[1] != "5s" {
+		t.Fatalf("Expected best values [70 5s], got %v", best)
+	}
+	if result.Best.DpsCiLower >= result.Best.Dps || result.Best.DpsCiUpper <= result.Best.Dps {
+		t.Fatalf("Expected a confidence interval around %f, got [%f, %f]", result.Best.Dps, result.Best.DpsCiLower, result.Best.DpsCiUpper)
+	}
+	if constant, _ := findTunableConst(result.BestSettings, settings.Tunables[0]); constant.Val != "70" {
+		t.Fatalf("Expected best settings to use 70, got %s", constant.Val)
+	}
+	if constant, _ := findTunableConst(request, settings.Tunables[0]); constant.Val != "50" {
+		t.Fatalf("Expected the base request to be unchanged, got %s", constant.Val)
+	}
+
+	if len(progress) != simmed {
+		t.Fatalf("Expected %d progress updates, got %d", simmed, len(progress))
+	}
+	var last *proto.ProgressMetrics
+	for len(progress) > 0 {
+		last = <-progress
+	}
+	if last.CompletedSims != last.TotalSims {
+		t.Fatalf("Expected all sims to complete, got %d of %d", last.CompletedSims, last.TotalSims)
+	}
+}
+
+func TestTuneRotationInvalidPath(t *testing.T) {
+	request := makeTuneTestRequest()
+	for _, path := range []string{
+		"priority_list[5].action",
+		"priority_list[0].action.condition.cmp.lhs",
+		"priority_list[0].bogus",
+	} {
+		_, err := tuneRotation(request, &proto.TuneSettings{
+			Tunables: []*proto.TunableConstant{{Path: path, Min: 0, Max: 1}},
+		}, nil, nil)
+		if err == nil {
+			t.Fatalf("Expected an error for path %s", path)
+		}
+	}
+}
+
+func TestTunableConstKeepsDurationUnit(t *testing.T) {
+	tunable, err := newTunableConst(&proto.TunableConstant{Path: "delay", Min: 400, Max: 600, Step: 100}, "500ms")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	values := MapSlice(tunable.values, tunable.format)
+	if strings.Join(values, ",") != "400ms,500ms,600ms" {
+		t.Fatalf("Expected values in milliseconds, got %v", values)
+	}
+
+	if _, err := newTunableConst(&proto.TunableConstant{Path: "delay", Min: 60, Max: 90}, "1m30s"); err == nil {
+		t.Fatalf("Expected an error for a constant with mixed duration units")
+	}
+}
+
+func TestTuneCandidatesMatchOriginalByValue(t *testing.T) {
+	request := makeTuneTestRequest()
+	settings := &proto.TuneSettings{
+		Tunables: []*proto.TunableConstant{{Path: "priority_list[1].action.condition.cmp.rhs", Min: 1, Max: 2, Step: 0.5}},
+	}
+	tunable, err := newTunableConst(settings.Tunables[0], "1.50s")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	candidates := makeTuneCandidates(request, settings, []*tunableConst{tunable})
+	values := MapSlice(candidates, func(c *tuneCandidate) string { return c.values[0] })
+	if strings.Join(values, ",") != "1s,1.5s,2s" {
+		t.Fatalf("Expected the original value to be tuned once, got %v", values)
+	}
+	if !candidates[1].original || candidates[0].original || candidates[2].original {
+		t.Fatalf("Expected only 1.5s to be marked as the original")
+	}
+}
+
+func TestTuneRotationKeepsCloseCandidates(t *testing.T) {
+	request := makeTuneTestRequest()
+	settings := &proto.TuneSettings{
+		Tunables: []*proto.TunableConstant{
+			{Path: "priority_list[0].action.condition.cmp.rhs", Min: 48, Max: 51, Step: 1},
+		},
+		InitialIterations: 100,
+	}
+
+	// Each step is worth 10 DPS, which the first rounds can't tell apart.
+	runSim := func(request *proto.RaidSimRequest) *proto.RaidSimResult {
+		energy, _ := findTunableConst(request, settings.Tunables[0])
+		e, _ := strconv.ParseFloat(energy.Val, 64)
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: 1000 + 10*e, Stdev: 100}},
+		}
+	}
+
+	result, err := tuneRotation(request, settings, runSim, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Best.Values[0] != "51" {
+		t.Fatalf("Expected best value 51, got %v", result.Best.Values)
+	}
+
+	// Plain halving would drop 49 after the first round, and finish in 3 rounds.
+	rounds := map[string]int32{}
+	for _, candidate := range result.Candidates {
+		rounds[candidate.Values[0]] = candidate.Rounds
+	}
+	if rounds["49"] != result.Best.Rounds || result.Best.Rounds != 3+tuneExtraRounds {
+		t.Fatalf("Expected 49 to be kept until the last round, got rounds %v", rounds)
+	}
+	if rounds["48"] >= result.Best.Rounds {
+		t.Fatalf("Expected 48 to be dropped once its interval is clear of the cut, got rounds %v", rounds)
+	}
+}
+
+func TestTuneRotationUsesDisjointSeeds(t *testing.T) {
+	request := makeTuneTestRequest()
+	settings := &proto.TuneSettings{
+		Tunables: []*proto.TunableConstant{
+			{Path: "priority_list[0].action.condition.cmp.rhs", Min: 0, Max: 100, Step: 10},
+		},
+		InitialIterations: 100,
+	}
+
+	// The first and last seed used by each round.
+	type seedRange struct{ first, last int64 }
+	var rounds []seedRange
+	runSim := func(request *proto.RaidSimRequest) *proto.RaidSimResult {
+		options := request.SimOptions
+		seeds := seedRange{options.RandomSeed, options.RandomSeed + int64(options.Iterations) - 1}
+		if len(rounds) == 0 || rounds[len(rounds)-1] != seeds {
+			rounds = append(rounds, seeds)
+		}
+		return &proto.RaidSimResult{
+			RaidMetrics: &proto.RaidMetrics{Dps: &proto.DistributionMetrics{Avg: 1000, Stdev: 100}},
+		}
+	}
+
+	if _, err := tuneRotation(request, settings, runSim, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rounds) < 2 {
+		t.Fatalf("Expected several rounds, got %v", rounds)
+	}
+	for i := 1; i < len(rounds); i++ {
+		if rounds[i].first <= rounds[i-1].last {
+			t.Fatalf("Expected round %d to use seeds after round %d, got %v", i+1, i, rounds)
+		}
+	}
+}
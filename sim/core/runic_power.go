@@ -183,6 +183,9 @@ func (rp *runicPowerBar) addRunicPowerInternal(sim *Simulation, amount float64,
 	if sim.Log != nil {
 		rp.unit.Log(sim, "Gained %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, rp.unit, metrics, amount, newRunicPower-rp.currentRunicPower, newRunicPower)
+	}
 
 	rp.currentRunicPower = newRunicPower
 }
@@ -206,6 +209,9 @@ func (rp *runicPowerBar) spendRunicPower(sim *Simulation, amount float64, metric
 	if sim.Log != nil {
 		rp.unit.Log(sim, "Spent %0.3f runic power from %s (%0.3f --> %0.3f) of %0.0f total.", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower, rp.maxRunicPower)
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.resource(sim, rp.unit, metrics, -amount, -amount, newRunicPower)
+	}
 
 	rp.currentRunicPower = newRunicPower
 }
@@ -357,6 +363,57 @@ func (rp *runicPowerBar) AnyRuneReadyAt(sim *Simulation) time.Duration {
 	return rp.AnySpentRuneReadyAt()
 }
 
+// runePairReadyTimes returns when the first and second rune of the pair
+// starting at slot will be ready, assuming neither is spent before then. Runes
+// of a pair regenerate one at a time, so a rune waiting on its pair only starts
+// regenerating once the other one is ready.
+func (rp *runicPowerBar) runePairReadyTimes(sim *Simulation, slot int8) (time.Duration, time.Duration) {
+	readyAt := func(slot int8) time.Duration {
+		if rp.runeStates&isSpents[slot] == 0 {
+			return sim.CurrentTime
+		}
+		return rp.runeMeta[slot].regenAt
+	}
+
+	first, second := readyAt(slot), readyAt(slot+1)
+	if first > second {
+		first, second = second, first
+	}
+	if first != NeverExpires && second == NeverExpires {
+		second = first + DurationFromSeconds(rp.runeCD.Seconds()*rp.getTotalRegenMultiplier())
+	}
+	return first, second
+}
+
+// RunesAtTime returns how many runes of the pair starting at slot will be
+// ready at the given time, counting death runes.
+func (rp *runicPowerBar) RunesAtTime(sim *Simulation, slot int8, at time.Duration) int8 {
+	first, second := rp.runePairReadyTimes(sim, slot)
+	if second <= at {
+		return 2
+	} else if first <= at {
+		return 1
+	}
+	return 0
+}
+
+// TimeToRunes returns how long until the given number of runes of the pair
+// starting at slot are ready, counting death runes.
+func (rp *runicPowerBar) TimeToRunes(sim *Simulation, slot int8, amount int32) time.Duration {
+	if amount <= 0 {
+		return 0
+	} else if amount > 2 {
+		return NeverExpires
+	}
+
+	first, second := rp.runePairReadyTimes(sim, slot)
+	readyAt := TernaryDuration(amount == 1, first, second)
+	if readyAt == NeverExpires {
+		return NeverExpires
+	}
+	return readyAt - sim.CurrentTime
+}
+
 // ConvertFromDeath reverts the rune to its original type.
 func (rp *runicPowerBar) ConvertFromDeath(sim *Simulation, slot int8) {
 	if slices.Contains(rp.permanentDeaths, slot) {
@@ -625,6 +682,10 @@ func (rp *runicPowerBar) gainRuneMetrics(sim *Simulation, metrics *ResourceMetri
 		name, currRunes := rp.typeAmount(metrics)
 		rp.unit.Log(sim, "Gained %0.3f %s rune from %s (%d --> %d).", float64(gainAmount), name, metrics.ActionID, currRunes-gainAmount, currRunes)
 	}
+	if sim.combatLog != nil {
+		_, currRunes := rp.typeAmount(metrics)
+		sim.combatLog.resource(sim, rp.unit, metrics, float64(gainAmount), float64(gainAmount), float64(currRunes))
+	}
 }
 
 // spendRuneMetrics should be called after spending the rune
@@ -635,6 +696,10 @@ func (rp *runicPowerBar) spendRuneMetrics(sim *Simulation, metrics *ResourceMetr
 		name, currRunes := rp.typeAmount(metrics)
 		rp.unit.Log(sim, "Spent 1.000 %s rune from %s (%d --> %d).", name, metrics.ActionID, currRunes+spendAmount, currRunes)
 	}
+	if sim.combatLog != nil {
+		_, currRunes := rp.typeAmount(metrics)
+		sim.combatLog.resource(sim, rp.unit, metrics, -float64(spendAmount), -float64(spendAmount), float64(currRunes))
+	}
 }
 
 func (rp *runicPowerBar) regenRune(sim *Simulation, regenAt time.Duration, slot int8) {
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"hash/fnv"
 	"log"
 	"math"
 	"math/rand"
@@ -27,9 +28,10 @@ type Simulation struct {
 	rand  Rand
 	rseed int64
 
-	// Used for testing only, see RandomFloat().
-	isTest    bool
-	testRands map[string]Rand
+	// Used for testing and common random numbers, see RandomFloat().
+	isTest              bool
+	commonRandomNumbers bool
+	labelRands          map[string]Rand
 
 	// Current Simulation State
 	pendingActions []*PendingAction
@@ -42,6 +44,15 @@ type Simulation struct {
 
 	Log func(string, ...interface{})
 
+	// Structured combat log, nil unless it's being recorded.
+	combatLog *combatLog
+
+	// APL decision trace, nil unless it's being recorded.
+	aplTrace *aplTrace
+
+	// Time-bucketed metrics, nil unless they're being recorded.
+	timeSeries *timeSeriesRecorder
+
 	executePhase int32 // 20, 25, or 35 for the respective execute range, 100 otherwise
 
 	executePhaseCallbacks []func(*Simulation, int32) // 2nd parameter is 35 for 35%, 25 for 25% and 20 for 20%
@@ -200,11 +211,12 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions) *Simulation {
 		Environment: env,
 		Options:     simOptions,
 
-		rand:  NewSplitMix(uint64(rseed)),
+		rand:  NewRand(simOptions.RandomGenerator, uint64(rseed)),
 		rseed: rseed,
 
-		isTest:    simOptions.IsTest,
-		testRands: make(map[string]Rand),
+		isTest:              simOptions.IsTest,
+		commonRandomNumbers: simOptions.CommonRandomNumbers,
+		labelRands:          make(map[string]Rand),
 	}
 }
 
@@ -214,20 +226,24 @@ func newSimWithEnv(env *Environment, simOptions *proto.SimOptions) *Simulation {
 // sensitive to the exact order of RandomFloat() calls. To mitigate this, when
 // testing we use a separate rand object for each RandomFloat callsite,
 // distinguished by the label string.
+//
+// The common random numbers option does the same outside of tests, so that
+// e.g. a proc sees the same rolls in each iteration regardless of how many
+// other rolls were made, when simming a different configuration.
 func (sim *Simulation) RandomFloat(label string) float64 {
 	return sim.labelRand(label).NextFloat64()
 }
 
 func (sim *Simulation) labelRand(label string) Rand {
-	if !sim.isTest {
+	if !sim.isTest && !sim.commonRandomNumbers {
 		return sim.rand
 	}
 
-	labelRng, ok := sim.testRands[label]
+	labelRng, ok := sim.labelRands[label]
 	if !ok {
 		// Add rseed to the label, so we still have run-run variance for stat weights.
-		labelRng = NewSplitMix(uint64(makeTestRandSeed(sim.rand.GetSeed(), label)))
-		sim.testRands[label] = labelRng
+		labelRng = NewRand(sim.Options.RandomGenerator, uint64(sim.makeLabelRandSeed(sim.rand.GetSeed(), label)))
+		sim.labelRands[label] = labelRng
 	}
 	return labelRng
 }
@@ -236,17 +252,32 @@ func (sim *Simulation) reseedRands(i int64) {
 	rseed := sim.Options.RandomSeed + i
 	sim.rand.Seed(rseed)
 
+	for label, rng := range sim.labelRands {
+		rng.Seed(sim.makeLabelRandSeed(rseed, label))
+	}
+}
+
+func (sim *Simulation) makeLabelRandSeed(rseed int64, label string) int64 {
 	if sim.isTest {
-		for label, rng := range sim.testRands {
-			rng.Seed(makeTestRandSeed(rseed, label))
-		}
+		return makeTestRandSeed(rseed, label)
 	}
+	return makeCommonRandSeed(rseed, label)
 }
 
 func makeTestRandSeed(rseed int64, label string) int64 {
 	return int64(hash(label + strconv.FormatInt(rseed, 16)))
 }
 
+// Returns the seed of a label's stream for common random numbers. rseed is the
+// sim's seed plus the iteration, which stays the same for each iteration when
+// the iterations are split over concurrent sims. Unlike test seeds, this uses
+// a 64-bit hash so different labels don't share a stream.
+func makeCommonRandSeed(rseed int64, label string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(label))
+	return int64(NewSplitMix(h.Sum64() ^ uint64(rseed)).Next())
+}
+
 func (sim *Simulation) RandomExpFloat(label string) float64 {
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
@@ -291,12 +322,33 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		}
 	}
 
+	var cl *combatLog
+	if sim.Options.CombatLogFormat != proto.CombatLogFormat_CombatLogFormatNone {
+		cl = &combatLog{}
+		sim.combatLog = cl
+	}
+
+	var trace *aplTrace
+	if sim.Options.AplTrace {
+		trace = &aplTrace{recordEvaluations: true}
+		sim.aplTrace = trace
+	}
+
 	// Uncomment this to print logs directly to console.
 	// sim.Options.Debug = true
 	// sim.Log = func(message string, vals ...interface{}) {
 	// 	fmt.Printf(fmt.Sprintf("[%0.1f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
 	// }
 
+	var targetErrorMetrics *DistributionMetrics
+	if sim.Options.TargetError != nil {
+		targetErrorMetrics = sim.targetErrorMetrics()
+	}
+
+	if sim.Options.TimeSeriesBinSeconds > 0 {
+		sim.timeSeries = newTimeSeriesRecorder(sim, DurationFromSeconds(sim.Options.TimeSeriesBinSeconds))
+	}
+
 	sim.runOnce()
 	firstIterationDuration := sim.Duration
 	if sim.Encounter.EndFightAtHealth != 0 {
@@ -307,9 +359,19 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	if !sim.Options.Debug {
 		sim.Log = nil
 	}
+	sim.combatLog = nil
+	if trace != nil {
+		trace.recordEvaluations = false
+	}
 
 	var st time.Time
+	iterations := sim.Options.Iterations
 	for i := int32(1); i < sim.Options.Iterations; i++ {
+		if targetErrorMetrics != nil && i >= targetErrorMinIterations && sim.targetErrorAchieved(targetErrorMetrics) <= sim.Options.TargetError.RelativeError {
+			iterations = i
+			break
+		}
+
 		if sim.QuitChannel != nil {
 			select {
 			case <-sim.QuitChannel:
@@ -348,15 +410,33 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		Logs:                   logsBuffer.String(),
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterations),
+		Iterations:             iterations,
+	}
+	if targetErrorMetrics != nil {
+		result.AchievedError = sim.targetErrorAchieved(targetErrorMetrics)
+	}
+
+	if cl != nil {
+		if err := cl.fillResult(result, sim.Options.CombatLogFormat); err != nil {
+			result.ErrorResult = fmt.Sprintf("failed to export combat log: %s", err)
+		}
+	}
+	if trace != nil {
+		trace.fillResult(sim, result)
+		sim.aplTrace = nil
+	}
+	if sim.timeSeries != nil {
+		result.TimeSeries = sim.timeSeries.ToProto()
+		sim.timeSeries = nil
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterations; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
@@ -418,6 +498,9 @@ func (sim *Simulation) reset() {
 
 	sim.tasks = sim.tasks[:0]
 	sim.minTaskTime = NeverExpires
+	if sim.timeSeries != nil {
+		sim.timeSeries.reset(sim)
+	}
 
 	sim.Environment.reset(sim)
 
@@ -451,6 +534,14 @@ func (sim *Simulation) PrePull() {
 }
 
 func (sim *Simulation) Cleanup() {
+	if sim.timeSeries != nil {
+		endTime := sim.Duration
+		if sim.Encounter.EndFightAtHealth != 0 {
+			endTime = sim.CurrentTime
+		}
+		sim.timeSeries.doneIteration(sim, endTime)
+	}
+
 	// The last event loop will leave CurrentTime at some value close to but not
 	// quite at the Duration. Explicitly set this so that accesses to CurrentTime
 	// during the doneIteration phase will return the Duration value, which is
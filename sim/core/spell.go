@@ -489,6 +489,10 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if isDespawnedTarget(target) {
+		return false
+	}
+
 	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because of extra condition")
@@ -547,6 +551,9 @@ func (spell *Spell) Cast(sim *Simulation, target *Unit) bool {
 	if target == nil {
 		target = spell.Unit.CurrentTarget
 	}
+	if isDespawnedTarget(target) {
+		return spell.castFailureHelper(sim, "%s has despawned", target.Label)
+	}
 	return spell.castFn(sim, target)
 }
 
@@ -557,6 +564,10 @@ func (spell *Spell) SkipCastAndApplyEffects(sim *Simulation, target *Unit) {
 			spell.ActionID, spell.DefaultCast.Cost, time.Duration(0))
 		spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 	}
+	if sim.combatLog != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
+		sim.combatLog.castStart(sim, spell, target, Cast{Cost: spell.DefaultCast.Cost})
+		sim.combatLog.castComplete(sim, spell, target)
+	}
 	spell.applyEffects(sim, target)
 }
 
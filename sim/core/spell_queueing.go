@@ -72,6 +72,18 @@ func (unit *Unit) CanQueueSpell(sim *Simulation) bool {
 	return (unit.QueuedSpell == nil) || (unit.QueuedSpell.QueueInitiatedAt != sim.CurrentTime)
 }
 
+// Reasons for which Spell.CanQueue may return false.
+type castFailure byte
+
+const (
+	castFailureNone castFailure = iota
+	castFailureCastCondition
+	castFailureCasting
+	castFailureGCD
+	castFailureCooldown
+	castFailureResource
+)
+
 // Returns whether the spell could be queued by the player at the current time using the
 // game's spell queueing functionality. Assumes the maximum spell queue window of 400ms
 // that the game allows.
@@ -79,36 +91,40 @@ func (spell *Spell) CanQueue(sim *Simulation, target *Unit) bool {
 	if spell == nil {
 		return false
 	}
+	return spell.queueFailure(sim, target) == castFailureNone
+}
 
+// Returns the first check in CanQueue that fails, or castFailureNone.
+func (spell *Spell) queueFailure(sim *Simulation, target *Unit) castFailure {
 	// Same extra cast conditions apply as if we were casting right now
-	if spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target) {
-		return false
+	if isDespawnedTarget(target) || (spell.ExtraCastCondition != nil && !spell.ExtraCastCondition(sim, target)) {
+		return castFailureCastCondition
 	}
 
 	// Apply SQW leniency to any pending hardcasts
 	if spell.Unit.Hardcast.Expires > sim.CurrentTime+MaxSpellQueueWindow {
-		return false
+		return castFailureCasting
 	}
 
 	// Apply SQW leniency to GCD timer
 	if spell.DefaultCast.GCD > 0 && spell.Unit.GCD.TimeToReady(sim) > MaxSpellQueueWindow {
-		return false
+		return castFailureGCD
 	}
 
 	// Spells that are within one SQW of coming off cooldown can also be queued
 	if MaxTimeToReady(spell.CD.Timer, spell.SharedCD.Timer, sim) > MaxSpellQueueWindow {
-		return false
+		return castFailureCooldown
 	}
 
 	// By contrast, spells that are waiting on resources to cast *cannot* be queued
 	if spell.Cost != nil {
 		spell.CurCast.Cost = spell.DefaultCast.Cost
 		if !spell.Cost.MeetsRequirement(sim, spell) {
-			return false
+			return castFailureResource
 		}
 	}
 
-	return true
+	return castFailureNone
 }
 
 // Helper function for APL checks to prevent infinite loops
@@ -44,6 +44,12 @@ func (result *SpellResult) Landed() bool {
 	return result.Outcome.Matches(OutcomeLanded)
 }
 
+// Returns whether some of the damage was resisted. Resist rolls also happen in
+// the 0% bracket, so the outcome alone doesn't tell.
+func (result *SpellResult) PartiallyResisted() bool {
+	return result.Outcome.Matches(OutcomePartial) && result.ResistanceMultiplier < 1
+}
+
 func (result *SpellResult) DidCrit() bool {
 	return result.Outcome.Matches(OutcomeCrit)
 }
@@ -281,12 +287,16 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 	if sim.CurrentTime >= 0 {
 		spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+		if result.Landed() {
+			spell.SpellMetrics[result.Target.UnitIndex].DamageOutcomes[TernaryInt(isPeriodic, 1, 0)][TernaryInt(result.PartiallyResisted(), 1, 0)][result.Outcome.ToProto()].addHit(result.Damage)
+		}
 	}
 
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
 	if result.Target.Type == EnemyUnit {
 		sim.Encounter.DamageTaken += result.Damage
+		sim.Encounter.Targets[result.Target.Index].onDamageTaken(sim, result.Damage)
 	}
 
 	if sim.Log != nil {
@@ -296,6 +306,9 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), result.Threat)
 		}
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.spellHit(sim, spell, result, isPeriodic, false)
+	}
 
 	if !spell.Flags.Matches(SpellFlagNoOnDamageDealt) {
 		if isPeriodic {
@@ -402,6 +415,8 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
 	if result.Target.HasHealthBar() {
+		missingHealth := result.Target.MaxHealth() - result.Target.CurrentHealth()
+		spell.SpellMetrics[result.Target.UnitIndex].TotalOverhealing += max(0, result.Damage-missingHealth)
 		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
 
@@ -412,6 +427,9 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
 		}
 	}
+	if sim.combatLog != nil {
+		sim.combatLog.spellHit(sim, spell, result, isPeriodic, true)
+	}
 
 	if isPeriodic {
 		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
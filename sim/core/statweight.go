@@ -1,8 +1,10 @@
 package core
 
 import (
+	"context"
 	"math"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -51,6 +53,7 @@ type StatWeightValues struct {
 	WeightsStdev  UnitStats
 	EpValues      UnitStats
 	EpValuesStdev UnitStats
+	Curves        []*proto.StatWeightCurve
 }
 
 func NewStatWeightValues() StatWeightValues {
@@ -68,6 +71,7 @@ func (swv *StatWeightValues) ToProto() *proto.StatWeightValues {
 		WeightsStdev:  swv.WeightsStdev.ToProto(),
 		EpValues:      swv.EpValues.ToProto(),
 		EpValuesStdev: swv.EpValuesStdev.ToProto(),
+		Curves:        swv.Curves,
 	}
 }
 
@@ -103,6 +107,12 @@ func (swr *StatWeightsResult) ToProto() *proto.StatWeightsResult {
 }
 
 func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, progress chan *proto.ProgressMetrics) *StatWeightsResult {
+	return calcStatWeight(context.Background(), swr, referenceStat, progress)
+}
+
+// Like CalcStatWeight, but stops all sims early and returns an empty result if
+// ctx is canceled.
+func calcStatWeight(ctx context.Context, swr *proto.StatWeightsRequest, referenceStat stats.Stat, progress chan *proto.ProgressMetrics) *StatWeightsResult {
 	if swr.Player.BonusStats == nil {
 		swr.Player.BonusStats = &proto.UnitStats{}
 	}
@@ -143,7 +153,12 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		Encounter:  swr.Encounter,
 		SimOptions: simOptions,
 	}
-	baselineResult := RunRaidSim(baseSimRequest)
+
+	quit := make(chan bool)
+	stopQuit := context.AfterFunc(ctx, func() { close(quit) })
+	defer stopQuit()
+
+	baselineResult := RunSim(baseSimRequest, nil, quit)
 	if baselineResult.ErrorResult != "" {
 		// TODO: get stack trace out.
 		return &StatWeightsResult{}
@@ -170,16 +185,16 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		tickets <- struct{}{}
 	}
 
-	doStat := func(stat stats.UnitStat, value float64, isLow bool) {
-		defer waitGroup.Done()
+	runModSim := func(stat stats.UnitStat, value float64) *proto.RaidSimResult {
 		// wait until we have CPU time available.
 		<-tickets
+		defer func() { tickets <- struct{}{} }()
 
 		simRequest := googleProto.Clone(baseSimRequest).(*proto.RaidSimRequest)
 		stat.AddToStatsProto(simRequest.Raid.Parties[0].Players[0].BonusStats, value)
 
 		reporter := make(chan *proto.ProgressMetrics, 10)
-		go RunSim(simRequest, reporter, nil) // RunRaidSim(simRequest)
+		go RunSim(simRequest, reporter, quit)
 
 		var localIterations int32
 		var errorStr string
@@ -207,15 +222,21 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		}
 		// TODO: get stack trace out if final result error is set.
 		if errorStr != "" {
+			if ctx.Err() != nil {
+				return nil
+			}
 			panic("Stat weights error: " + errorStr)
 		}
+		return simResult
+	}
 
+	doStat := func(stat stats.UnitStat, value float64, isLow bool) {
+		defer waitGroup.Done()
 		if isLow {
-			resultsLow[stat] = simResult
+			resultsLow[stat] = runModSim(stat, value)
 		} else {
-			resultsHigh[stat] = simResult
+			resultsHigh[stat] = runModSim(stat, value)
 		}
-		tickets <- struct{}{}
 	}
 
 	const defaultStatMod = 20.0
@@ -259,8 +280,34 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		go doStat(stat, statModsHigh[stat], false)
 	}
 
+	// Sample each stat at the requested offsets, for the piecewise weight curves.
+	curveOffsets := curveSampleOffsets(swr.CurveOffsets)
+	resultsCurve := make([][]*proto.RaidSimResult, stats.UnitStatsLen)
+	if len(curveOffsets) > 0 {
+		for _, s := range statsToWeigh {
+			stat := stats.UnitStatFromStat(s)
+			resultsCurve[stat] = make([]*proto.RaidSimResult, len(curveOffsets))
+			for offsetIdx, offset := range curveOffsets {
+				if offset == 0 {
+					continue
+				}
+				waitGroup.Add(1)
+				atomic.AddInt32(&iterationsTotal, swr.SimOptions.Iterations)
+				atomic.AddInt32(&simsTotal, 1)
+
+				go func(offsetIdx int, offset float64) {
+					defer waitGroup.Done()
+					resultsCurve[stat][offsetIdx] = runModSim(stat, offset)
+				}(offsetIdx, offset)
+			}
+		}
+	}
+
 	// Wait for thread results.
 	waitGroup.Wait()
+	if ctx.Err() != nil {
+		return &StatWeightsResult{}
+	}
 
 	// Compute weight results.
 	result := NewStatWeightsResult()
@@ -336,5 +383,114 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		calcEpResults(&result.PDeath, DTPSReferenceStat)
 	}
 
+	// Compute piecewise weight curves.
+	for i, statResults := range resultsCurve {
+		if statResults == nil {
+			continue
+		}
+		stat := proto.Stat(i)
+		baselinePlayer := baselineResult.RaidMetrics.Parties[0].Players[0]
+
+		addCurve := func(getMetric func(*proto.UnitMetrics) *proto.DistributionMetrics, weightResults *StatWeightValues) {
+			deltas := make([]float64, len(curveOffsets))
+			deltasStdev := make([]float64, len(curveOffsets))
+			for offsetIdx, simResult := range statResults {
+				if simResult == nil {
+					continue
+				}
+				// RNG is fixed, so compare each iteration against the same baseline iteration.
+				var agg aggregator
+				baselineValues := getMetric(baselinePlayer).AllValues
+				modValues := getMetric(simResult.RaidMetrics.Parties[0].Players[0]).AllValues
+				for i := 0; i < int(simOptions.Iterations); i++ {
+					agg.add(modValues[i] - baselineValues[i])
+				}
+				mean, stdev := agg.meanAndStdDev()
+				deltas[offsetIdx] = mean
+				deltasStdev[offsetIdx] = stdev / math.Sqrt(float64(simOptions.Iterations))
+			}
+			if curve := NewStatWeightCurve(stat, curveOffsets, deltas, deltasStdev); curve != nil {
+				weightResults.Curves = append(weightResults.Curves, curve)
+			}
+		}
+
+		addCurve(func(um *proto.UnitMetrics) *proto.DistributionMetrics { return um.Dps }, &result.Dps)
+		addCurve(func(um *proto.UnitMetrics) *proto.DistributionMetrics { return um.Hps }, &result.Hps)
+		addCurve(func(um *proto.UnitMetrics) *proto.DistributionMetrics { return um.Threat }, &result.Tps)
+		addCurve(func(um *proto.UnitMetrics) *proto.DistributionMetrics { return um.Dtps }, &result.Dtps)
+		addCurve(func(um *proto.UnitMetrics) *proto.DistributionMetrics { return um.Tmi }, &result.Tmi)
+	}
+
 	return result
 }
+
+// Segments whose weight falls below this fraction of the previous segment's
+// weight are treated as crossing a cap, if the drop is also significant.
+const statWeightCurveBreakpointRatio = 0.75
+
+// Number of standard deviations by which a segment's weight must drop to be
+// treated as crossing a cap rather than noise.
+const statWeightCurveBreakpointSignificance = 2.0
+
+// Returns the sorted, de-duplicated offsets to sample, including the baseline (0).
+func curveSampleOffsets(requested []float64) []float64 {
+	if len(requested) == 0 {
+		return nil
+	}
+	offsets := append([]float64{0}, requested...)
+	slices.Sort(offsets)
+	return slices.Compact(offsets)
+}
+
+// Builds a piecewise weight curve from the metric change measured at each
+// offset, along with the standard deviation of each change, and detects cap
+// breakpoints where the marginal weight drops sharply. Returns nil if the
+// metric did not change at any offset.
+func NewStatWeightCurve(stat proto.Stat, offsets []float64, deltas []float64, deltasStdev []float64) *proto.StatWeightCurve {
+	if !slices.ContainsFunc(deltas, func(delta float64) bool { return delta != 0 }) {
+		return nil
+	}
+
+	curve := &proto.StatWeightCurve{
+		Stat:        stat,
+		Offsets:     offsets,
+		Deltas:      deltas,
+		DeltasStdev: deltasStdev,
+	}
+	weightsStdev := make([]float64, 0, len(offsets)-1)
+	for i := 1; i < len(offsets); i++ {
+		width := offsets[i] - offsets[i-1]
+		curve.SegmentWeights = append(curve.SegmentWeights, (deltas[i]-deltas[i-1])/width)
+		weightsStdev = append(weightsStdev, math.Hypot(deltasStdev[i], deltasStdev[i-1])/width)
+	}
+
+	weights := curve.SegmentWeights
+	for i := 1; i < len(weights); i++ {
+		capWeight := weights[i-1]
+		if capWeight <= 0 || weights[i] >= capWeight*statWeightCurveBreakpointRatio {
+			continue
+		}
+		if capWeight-weights[i] <= statWeightCurveBreakpointSignificance*math.Hypot(weightsStdev[i-1], weightsStdev[i]) {
+			continue
+		}
+
+		// The segment may only be partly past the cap. Assuming the weight is
+		// flat on both sides, its average weight tells how far into the
+		// segment the cap is. The weight past the cap comes from the next
+		// segment, or is assumed to be 0 at the end of the curve.
+		postCapWeight := 0.0
+		if i+1 < len(weights) {
+			postCapWeight = min(weights[i], weights[i+1])
+		}
+		fraction := 0.0
+		if capWeight > postCapWeight {
+			fraction = max(0, (weights[i]-postCapWeight)/(capWeight-postCapWeight))
+		}
+		curve.Breakpoints = append(curve.Breakpoints, offsets[i]+fraction*(offsets[i+1]-offsets[i]))
+
+		// The next segment is past the cap, so it can't be compared against this one.
+		i++
+	}
+
+	return curve
+}
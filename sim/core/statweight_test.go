@@ -0,0 +1,71 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestCurveSampleOffsets(t *testing.T) {
+	offsets := curveSampleOffsets([]float64{200, -100, 200})
+	expected := []float64{-100, 0, 200}
+	if !slices.Equal(offsets, expected) {
+		t.Fatalf("Expected offsets %v, got %v", expected, offsets)
+	}
+
+	if offsets := curveSampleOffsets(nil); offsets != nil {
+		t.Fatalf("Expected no offsets, got %v", offsets)
+	}
+}
+
+func TestStatWeightCurveDetectsCap(t *testing.T) {
+	// Weight of 2 per point, capped 50 points above the current value.
+	offsets := []float64{-200, -100, 0, 100, 200}
+	deltas := []float64{-400, -200, 0, 100, 100}
+
+	curve := NewStatWeightCurve(proto.Stat_StatMeleeHit, offsets, deltas, make([]float64, len(offsets)))
+	if curve == nil {
+		t.Fatalf("Expected a curve")
+	}
+	if expected := []float64{2, 2, 1, 0}; !slices.Equal(curve.SegmentWeights, expected) {
+		t.Fatalf("Expected segment weights %v, got %v", expected, curve.SegmentWeights)
+	}
+	if expected := []float64{50}; !slices.Equal(curve.Breakpoints, expected) {
+		t.Fatalf("Expected breakpoints %v, got %v", expected, curve.Breakpoints)
+	}
+}
+
+func TestStatWeightCurveIgnoresNoise(t *testing.T) {
+	// The same drop in weight as above, but well within the noise of the deltas.
+	offsets := []float64{-200, -100, 0, 100, 200}
+	deltas := []float64{-400, -200, 0, 100, 100}
+	deltasStdev := []float64{60, 60, 0, 60, 60}
+
+	curve := NewStatWeightCurve(proto.Stat_StatMeleeHit, offsets, deltas, deltasStdev)
+	if len(curve.Breakpoints) != 0 {
+		t.Fatalf("Expected no breakpoints for a noisy curve, got %v", curve.Breakpoints)
+	}
+
+	// With less noise, the drop is significant.
+	for i := range deltasStdev {
+		deltasStdev[i] /= 10
+	}
+	curve = NewStatWeightCurve(proto.Stat_StatMeleeHit, offsets, deltas, deltasStdev)
+	if expected := []float64{50}; !slices.Equal(curve.Breakpoints, expected) {
+		t.Fatalf("Expected breakpoints %v, got %v", expected, curve.Breakpoints)
+	}
+}
+
+func TestStatWeightCurveUncapped(t *testing.T) {
+	offsets := []float64{-200, -100, 0, 100, 200}
+
+	curve := NewStatWeightCurve(proto.Stat_StatMeleeCrit, offsets, []float64{-200, -100, 0, 100, 200}, make([]float64, len(offsets)))
+	if len(curve.Breakpoints) != 0 {
+		t.Fatalf("Expected no breakpoints for a linear curve, got %v", curve.Breakpoints)
+	}
+
+	if curve := NewStatWeightCurve(proto.Stat_StatMeleeCrit, offsets, make([]float64, len(offsets)), make([]float64, len(offsets))); curve != nil {
+		t.Fatalf("Expected no curve for a stat with no effect")
+	}
+}
@@ -14,6 +14,10 @@ type Encounter struct {
 	Targets           []*Target
 	TargetUnits       []*Unit
 
+	// Targets which are currently in the fight. Updated as targets spawn and
+	// despawn during the iteration.
+	ActiveTargetUnits []*Unit
+
 	ExecuteProportion_20 float64
 	ExecuteProportion_25 float64
 	ExecuteProportion_35 float64
@@ -58,6 +62,15 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		encounter.Targets = append(encounter.Targets, target)
 		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
 	}
+	if options.Script != nil {
+		if len(encounter.Targets) == 0 {
+			panic("Encounter script requires at least one target")
+		}
+		if scriptedAIFactory == nil {
+			panic("Encounter script requires the scripted target AI, which is registered by the encounters package")
+		}
+		encounter.Targets[0].AI = scriptedAIFactory(options.Script)
+	}
 	if len(encounter.Targets) == 0 {
 		// Add a dummy target. The only case where targets aren't specified is when
 		// computing character stats, and targets won't matter there.
@@ -72,7 +85,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		encounter.DurationIsEstimate = true
 	}
 
-	encounter.updateAOECapMultiplier()
+	encounter.updateActiveTargets()
 
 	return encounter
 }
@@ -80,8 +93,18 @@ func NewEncounter(options *proto.Encounter) Encounter {
 func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
-func (encounter *Encounter) updateAOECapMultiplier() {
-	encounter.aoeCapMultiplier = min(10/float64(len(encounter.Targets)), 1)
+
+// Builds a new slice rather than reusing the old one, since targets can spawn
+// or despawn while callers are ranging over it, e.g. an add dying to an AoE.
+func (encounter *Encounter) updateActiveTargets() {
+	active := make([]*Unit, 0, len(encounter.Targets))
+	for _, target := range encounter.Targets {
+		if target.IsEnabled() {
+			active = append(active, &target.Unit)
+		}
+	}
+	encounter.ActiveTargetUnits = active
+	encounter.aoeCapMultiplier = min(10/float64(max(len(encounter.ActiveTargetUnits), 1)), 1)
 }
 
 func (encounter *Encounter) doneIteration(sim *Simulation) {
@@ -110,6 +133,14 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	spawnTime      time.Duration
+	despawnTime    time.Duration
+	despawnOnDeath bool
+
+	// Values for the current iteration.
+	spawnedAt time.Duration
+	lifetime  time.Duration
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -133,6 +164,10 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			StatDependencyManager: stats.NewStatDependencyManager(),
 			ReactionTime:          time.Millisecond * 1620,
 		},
+
+		spawnTime:      DurationFromSeconds(options.SpawnTime),
+		despawnTime:    DurationFromSeconds(options.DespawnTime),
+		despawnOnDeath: options.DespawnOnDeath,
 	}
 	defaultRaidBossLevel := int32(CharacterLevel + 3)
 	target.GCD = target.NewTimer()
@@ -151,6 +186,10 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target.PseudoStats.InFrontOfTarget = true
 	target.PseudoStats.DamageSpread = options.DamageSpread
 
+	if target.despawnOnDeath {
+		target.EnableHealthBar()
+	}
+
 	preset := GetPresetTargetWithID(options.Id)
 	if preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
@@ -165,6 +204,131 @@ func (target *Target) Reset(sim *Simulation) {
 	if target.AI != nil {
 		target.AI.Reset(sim)
 	}
+
+	target.spawnedAt = 0
+	target.lifetime = 0
+
+	if target.spawnTime > 0 {
+		// Raid units are reset after targets, so wait until the pull to
+		// despawn, which also moves them off of this target.
+		sim.AddPendingAction(&PendingAction{
+			NextActionAt: 0,
+			Priority:     ActionPriorityPrePull,
+			OnAction:     target.Despawn,
+		})
+		sim.AddPendingAction(&PendingAction{
+			NextActionAt: target.spawnTime,
+			OnAction:     target.Spawn,
+		})
+	}
+	if target.despawnTime > 0 {
+		sim.AddPendingAction(&PendingAction{
+			NextActionAt: target.despawnTime,
+			OnAction:     target.Despawn,
+		})
+	}
+}
+
+func (target *Target) doneIteration(sim *Simulation) {
+	if target.enabled {
+		target.lifetime += sim.CurrentTime - target.spawnedAt
+	}
+	target.Metrics.lifetime = target.lifetime
+
+	target.Unit.doneIteration(sim)
+}
+
+// Tracks damage for targets with a health pool.
+func (target *Target) onDamageTaken(sim *Simulation, damage float64) {
+	if !target.HasHealthBar() || !target.enabled {
+		return
+	}
+
+	target.RemoveHealth(sim, damage)
+	if target.CurrentHealth() <= 0 {
+		if sim.Log != nil {
+			target.Log(sim, "Died")
+		}
+		target.Despawn(sim)
+	}
+}
+
+// Brings a despawned target back into the fight.
+func (target *Target) Spawn(sim *Simulation) {
+	if target.enabled {
+		return
+	}
+
+	target.enabled = true
+	target.spawnedAt = sim.CurrentTime
+	if target.HasHealthBar() && target.CurrentHealth() <= 0 {
+		// Adds which died come back at full health.
+		target.healthBar.reset(sim)
+	}
+	if target.rotationAction != nil {
+		target.SetGCDTimer(sim, sim.CurrentTime)
+	}
+	target.AutoAttacks.EnableAutoSwing(sim)
+	target.Env.Encounter.updateActiveTargets()
+
+	if sim.Log != nil {
+		target.Log(sim, "Spawned")
+	}
+
+	// Anyone parked on a despawned target picks up this one.
+	for _, unit := range target.Env.Raid.AllUnits {
+		if isDespawnedTarget(unit.CurrentTarget) {
+			unit.CurrentTarget = &target.Unit
+		}
+	}
+}
+
+// Removes the target from the fight until it is spawned again.
+func (target *Target) Despawn(sim *Simulation) {
+	if !target.enabled {
+		return
+	}
+
+	target.enabled = false
+	target.lifetime += max(sim.CurrentTime, 0) - target.spawnedAt
+	if target.rotationAction != nil {
+		target.CancelGCDTimer(sim)
+	}
+	target.AutoAttacks.CancelAutoSwing(sim)
+	target.Env.Encounter.updateActiveTargets()
+
+	if sim.Log != nil {
+		target.Log(sim, "Despawned")
+	}
+
+	// DoTs and debuffs from the raid fall off. Permanent auras, such as the
+	// configured raid debuffs, are kept for when the target spawns again.
+	var expiringAuras []*Aura
+	for _, aura := range target.activeAuras {
+		if aura.Duration != NeverExpires {
+			expiringAuras = append(expiringAuras, aura)
+		}
+	}
+	for _, aura := range expiringAuras {
+		aura.Deactivate(sim)
+	}
+
+	// Anyone attacking this target moves on to the next one still alive. If
+	// there is none, they stay parked on this target, which can't be attacked,
+	// until another target spawns.
+	if len(target.Env.Encounter.ActiveTargetUnits) > 0 {
+		newTarget := target.Env.Encounter.ActiveTargetUnits[0]
+		for _, unit := range target.Env.Raid.AllUnits {
+			if unit.CurrentTarget == &target.Unit {
+				unit.CurrentTarget = newTarget
+			}
+		}
+	}
+}
+
+// Whether unit is an enemy which has despawned, and so can't be attacked.
+func isDespawnedTarget(unit *Unit) bool {
+	return unit != nil && unit.Type == EnemyUnit && !unit.enabled
 }
 
 func (target *Target) NextTarget() *Target {
@@ -65,6 +65,14 @@ func (target *Target) ExecuteCustomRotation(sim *Simulation) {
 
 type AIFactory func() TargetAI
 
+// Builds the generic TargetAI which plays back an EncounterScript. This lives
+// in the encounters package, so it has to be registered from there.
+var scriptedAIFactory func(*proto.EncounterScript) TargetAI
+
+func RegisterScriptedAI(factory func(*proto.EncounterScript) TargetAI) {
+	scriptedAIFactory = factory
+}
+
 type PresetTarget struct {
 	// String in folder-structure format identifying a category for this unit, e.g. "Black Temple/Bosses".
 	PathPrefix string
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -24,6 +25,8 @@ func NewTargetDummy(dummyIndex int, party *Party, partyIndex int) *TargetDummy {
 				Metrics:     NewUnitMetrics(),
 
 				StatDependencyManager: stats.NewStatDependencyManager(),
+
+				ReactionTime: time.Millisecond * 10,
 			},
 			Name:       name,
 			Party:      party,
@@ -36,10 +39,46 @@ func NewTargetDummy(dummyIndex int, party *Party, partyIndex int) *TargetDummy {
 
 	td.Label = fmt.Sprintf("%s (#%d)", td.Name, td.Index+1)
 	td.GCD = td.NewTimer()
+	td.RotationTimer = td.NewTimer()
 
 	return td
 }
 
+// Gives the dummy a health bar and periodically damages it according to the
+// raid damage profile, so healers have something to heal.
+func (td *TargetDummy) applyRaidDamageProfile(profile *proto.RaidDamageProfile) {
+	if profile == nil || profile.Dtps <= 0 {
+		return
+	}
+
+	health := profile.DummyHealth
+	if health <= 0 {
+		health = 150000
+	}
+	interval := profile.Interval
+	if interval <= 0 {
+		interval = 2
+	}
+	period := DurationFromSeconds(interval)
+	avgDamage := profile.Dtps * interval
+
+	td.AddStat(stats.Health, health)
+	td.EnableHealthBar()
+
+	td.RegisterResetEffect(func(sim *Simulation) {
+		StartPeriodicAction(sim, PeriodicActionOptions{
+			Period: period,
+			OnAction: func(sim *Simulation) {
+				damage := avgDamage
+				if profile.Variance > 0 {
+					damage *= 1 + profile.Variance*(2*sim.RandomFloat("Raid Damage")-1)
+				}
+				td.RemoveHealth(sim, max(0, damage))
+			},
+		})
+	})
+}
+
 func (td *TargetDummy) GetCharacter() *Character {
 	return &td.Character
 }
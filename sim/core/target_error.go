@@ -0,0 +1,70 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Minimum number of iterations before the error is checked, so that the
+// estimated standard deviation is reasonable.
+const targetErrorMinIterations = 100
+
+// Returns the relative half-width of the confidence interval of the mean, for
+// n samples with the given mean and standard deviation.
+func relativeError(mean float64, stdev float64, n int, confidence float64) float64 {
+	if n < 2 || mean == 0 {
+		return math.Inf(1)
+	}
+	if confidence <= 0 || confidence >= 1 {
+		confidence = 0.95
+	}
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	return z * stdev / math.Sqrt(float64(n)) / math.Abs(mean)
+}
+
+// Returns the DPS metrics which target error is measured against.
+func (sim *Simulation) targetErrorMetrics() *DistributionMetrics {
+	ref := sim.Options.TargetError.Unit
+	if ref == nil || ref.Type == proto.UnitReference_Unknown {
+		return &sim.Raid.dpsMetrics
+	}
+	if ref.Type != proto.UnitReference_Player {
+		panic(fmt.Sprintf("[USER_ERROR] Target error unit must be a player, got %s", ref.Type))
+	}
+	unit := sim.Environment.GetUnit(ref, nil)
+	if unit == nil {
+		panic(fmt.Sprintf("[USER_ERROR] No player found for target error unit %d", ref.Index))
+	}
+	return &unit.Metrics.dps
+}
+
+// Returns the achieved relative error of the target error metrics.
+func (sim *Simulation) targetErrorAchieved(metrics *DistributionMetrics) float64 {
+	mean, stdev := metrics.meanAndStdDev()
+	return relativeError(mean, stdev, metrics.n, sim.Options.TargetError.Confidence)
+}
+
+// Returns the DPS metrics in result which target error is measured against, or
+// nil if the unit isn't a player in the result.
+func targetErrorDistribution(result *proto.RaidSimResult, targetError *proto.TargetError) *proto.DistributionMetrics {
+	ref := targetError.Unit
+	if ref == nil || ref.Type == proto.UnitReference_Unknown {
+		return result.RaidMetrics.Dps
+	}
+	partyIdx, playerIdx := int(ref.Index/5), int(ref.Index%5)
+	if ref.Type != proto.UnitReference_Player || ref.Index < 0 || partyIdx >= len(result.RaidMetrics.Parties) || playerIdx >= len(result.RaidMetrics.Parties[partyIdx].Players) {
+		return nil
+	}
+	return result.RaidMetrics.Parties[partyIdx].Players[playerIdx].Dps
+}
+
+// Returns the achieved relative error of a combined result.
+func targetErrorAchievedFromResult(result *proto.RaidSimResult, targetError *proto.TargetError) (float64, error) {
+	dist := targetErrorDistribution(result, targetError)
+	if dist == nil || dist.AggregatorData == nil {
+		return 0, fmt.Errorf("no player found for target error unit %s", targetError.Unit)
+	}
+	return relativeError(dist.Avg, dist.Stdev, int(dist.AggregatorData.N), targetError.Confidence), nil
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestRelativeError(t *testing.T) {
+	// 1.96 * 100 / sqrt(400) / 1000
+	if err := relativeError(1000, 100, 400, 0.95); math.Abs(err-0.0098) > 0.0001 {
+		t.Fatalf("Expected a relative error of 0.0098, got %f", err)
+	}
+	if err := relativeError(1000, 100, 400, 0); math.Abs(err-0.0098) > 0.0001 {
+		t.Fatalf("Expected confidence to default to 0.95, got %f", err)
+	}
+	if err := relativeError(1000, 100, 1, 0.95); !math.IsInf(err, 1) {
+		t.Fatalf("Expected an infinite error for a single sample, got %f", err)
+	}
+}
+
+func TestTargetErrorAchievedFromResult(t *testing.T) {
+	dist := func(avg float64, stdev float64, n int32) *proto.DistributionMetrics {
+		return &proto.DistributionMetrics{Avg: avg, Stdev: stdev, AggregatorData: &proto.AggregatorData{N: n}}
+	}
+	result := &proto.RaidSimResult{
+		RaidMetrics: &proto.RaidMetrics{
+			Dps: dist(2000, 100, 400),
+			Parties: []*proto.PartyMetrics{{
+				Players: []*proto.UnitMetrics{{}, {Dps: dist(1000, 100, 400)}},
+			}},
+		},
+	}
+
+	if err, _ := targetErrorAchievedFromResult(result, &proto.TargetError{}); math.Abs(err-0.0049) > 0.0001 {
+		t.Fatalf("Expected raid error of 0.0049, got %f", err)
+	}
+	player := &proto.UnitReference{Type: proto.UnitReference_Player, Index: 1}
+	if err, _ := targetErrorAchievedFromResult(result, &proto.TargetError{Unit: player}); math.Abs(err-0.0098) > 0.0001 {
+		t.Fatalf("Expected player error of 0.0098, got %f", err)
+	}
+	for _, index := range []int32{0, 2, 7} {
+		missing := &proto.UnitReference{Type: proto.UnitReference_Player, Index: index}
+		if _, err := targetErrorAchievedFromResult(result, &proto.TargetError{Unit: missing}); err == nil {
+			t.Fatalf("Expected an error for missing player %d", index)
+		}
+	}
+}
+
+func TestTargetErrorStopsAtMaxIterations(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Options.Iterations = 150
+	sim.Options.TargetError = &proto.TargetError{RelativeError: 0.01}
+
+	// Nothing deals damage, so the error is never reached.
+	result := sim.run()
+	if result.Iterations != 150 {
+		t.Fatalf("Expected 150 iterations, got %d", result.Iterations)
+	}
+	if !math.IsInf(result.AchievedError, 1) {
+		t.Fatalf("Expected an infinite error, got %f", result.AchievedError)
+	}
+}
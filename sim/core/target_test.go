@@ -0,0 +1,239 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func setupSpawnTestSim(targets []*proto.Target) *Simulation {
+	sim := NewSim(&proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			RandomSeed: 100,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{
+						{
+							Name:      "Caster",
+							Class:     proto.Class_ClassShaman,
+							Consumes:  &proto.Consumes{},
+							Buffs:     &proto.IndividualBuffs{},
+							Spec:      &proto.Player_ElementalShaman{},
+							Equipment: &proto.EquipmentSpec{},
+						},
+					},
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+		},
+		Encounter: &proto.Encounter{
+			Targets:  targets,
+			Duration: 60,
+		},
+	})
+	sim.reset()
+	sim.PrePull()
+	return sim
+}
+
+// Runs the sim until the given time, or the end of the fight.
+func runSimUntil(sim *Simulation, until time.Duration) {
+	if until != NeverExpires {
+		// Make sure there is an event to stop at.
+		sim.AddPendingAction(&PendingAction{
+			NextActionAt: until,
+			OnAction:     func(_ *Simulation) {},
+		})
+	}
+	for sim.CurrentTime < until {
+		if sim.Step() {
+			return
+		}
+	}
+}
+
+func expectActiveTargets(t *testing.T, sim *Simulation, expected ...int32) {
+	t.Helper()
+	var active []int32
+	for _, unit := range sim.Encounter.ActiveTargetUnits {
+		active = append(active, unit.Index)
+	}
+	if len(active) != len(expected) {
+		t.Fatalf("Expected active targets %v at %s, got %v", expected, sim.CurrentTime, active)
+	}
+	for i := range expected {
+		if active[i] != expected[i] {
+			t.Fatalf("Expected active targets %v at %s, got %v", expected, sim.CurrentTime, active)
+		}
+	}
+}
+
+func TestTargetSpawnAndDespawnTimes(t *testing.T) {
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Boss"},
+		{Name: "Late Add", SpawnTime: 10},
+		{Name: "Early Add", DespawnTime: 20},
+	})
+
+	runSimUntil(sim, time.Second)
+	expectActiveTargets(t, sim, 0, 2)
+
+	runSimUntil(sim, time.Second*15)
+	expectActiveTargets(t, sim, 0, 1, 2)
+
+	runSimUntil(sim, time.Second*25)
+	expectActiveTargets(t, sim, 0, 1)
+
+	runSimUntil(sim, NeverExpires)
+	sim.Cleanup()
+	for i, expected := range []time.Duration{time.Second * 60, time.Second * 50, time.Second * 20} {
+		if lifetime := sim.Encounter.Targets[i].lifetime; lifetime != expected {
+			t.Errorf("Expected target %d to live for %s, got %s", i, expected, lifetime)
+		}
+	}
+}
+
+func TestTargetDespawnRetargetsRaid(t *testing.T) {
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Boss", DespawnTime: 5},
+		{Name: "Add"},
+	})
+	player := sim.Raid.AllPlayerUnits[0]
+
+	runSimUntil(sim, time.Second)
+	if player.CurrentTarget != sim.GetTargetUnit(0) {
+		t.Fatalf("Expected the player to start on the boss")
+	}
+
+	runSimUntil(sim, time.Second*6)
+	expectActiveTargets(t, sim, 1)
+	if player.CurrentTarget != sim.GetTargetUnit(1) {
+		t.Fatalf("Expected the player to switch to the add after the boss despawned")
+	}
+}
+
+func TestTargetDespawnUpdatesAOECap(t *testing.T) {
+	targets := make([]*proto.Target, 12)
+	for i := range targets {
+		targets[i] = &proto.Target{}
+	}
+	sim := setupSpawnTestSim(targets)
+	runSimUntil(sim, time.Second)
+
+	if multiplier := sim.Encounter.AOECapMultiplier(); !WithinToleranceFloat64(10.0/12.0, multiplier, 0.0001) {
+		t.Fatalf("Expected an AOE cap multiplier of %f with 12 targets, got %f", 10.0/12.0, multiplier)
+	}
+
+	sim.Encounter.Targets[10].Despawn(sim)
+	sim.Encounter.Targets[11].Despawn(sim)
+	if multiplier := sim.Encounter.AOECapMultiplier(); multiplier != 1 {
+		t.Fatalf("Expected an AOE cap multiplier of 1 with 10 active targets, got %f", multiplier)
+	}
+
+	sim.Encounter.Targets[11].Spawn(sim)
+	expectActiveTargets(t, sim, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 11)
+}
+
+func TestTargetDespawnExpiresDots(t *testing.T) {
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Boss"},
+		{Name: "Add", DespawnTime: 5},
+	})
+	caster := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	add := sim.GetTargetUnit(1)
+
+	runSimUntil(sim, time.Second)
+	caster.Spell.Dot(add).Apply(sim)
+	runSimUntil(sim, time.Second*4)
+	if !caster.Spell.Dot(add).IsActive() {
+		t.Fatalf("Expected the dot to tick on the add before it despawns")
+	}
+
+	runSimUntil(sim, time.Second*6)
+	if caster.Spell.Dot(add).IsActive() {
+		t.Fatalf("Expected the dot to fall off the despawned add")
+	}
+	damage := caster.Spell.SpellMetrics[add.UnitIndex].TotalDamage
+	runSimUntil(sim, time.Second*20)
+	if newDamage := caster.Spell.SpellMetrics[add.UnitIndex].TotalDamage; newDamage != damage {
+		t.Fatalf("Expected no damage to the despawned add, got %0.1f more", newDamage-damage)
+	}
+}
+
+func TestTargetDespawnParksRaid(t *testing.T) {
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Boss", DespawnTime: 5},
+		{Name: "Add", SpawnTime: 10},
+	})
+	caster := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	player := &caster.Unit
+	boss := sim.GetTargetUnit(0)
+
+	runSimUntil(sim, time.Second*6)
+	expectActiveTargets(t, sim)
+	if player.CurrentTarget != boss {
+		t.Fatalf("Expected the player to stay parked on the boss with no other target active")
+	}
+	if caster.Spell.CanCast(sim, player.CurrentTarget) || caster.Spell.Cast(sim, nil) {
+		t.Fatalf("Expected the parked target not to be attackable")
+	}
+
+	runSimUntil(sim, time.Second*11)
+	if player.CurrentTarget != sim.GetTargetUnit(1) {
+		t.Fatalf("Expected the player to pick up the add once it spawns")
+	}
+}
+
+func TestTargetDespawnsOnDeath(t *testing.T) {
+	addStats := stats.Stats{stats.Health: 1000}
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Boss"},
+		{Name: "Add", Stats: addStats.ToFloatArray(), DespawnOnDeath: true},
+	})
+	caster := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	add := sim.GetTargetUnit(1)
+	runSimUntil(sim, time.Second)
+
+	damage := 0.0
+	for damage < 1000 {
+		expectActiveTargets(t, sim, 0, 1)
+		damage += caster.Spell.CalcAndDealDamage(sim, add, 300, caster.Spell.OutcomeAlwaysHit).Damage
+		if remaining := max(0, 1000-damage); add.CurrentHealth() != remaining {
+			t.Fatalf("Expected %0.1f health remaining, got %0.1f", remaining, add.CurrentHealth())
+		}
+	}
+	expectActiveTargets(t, sim, 0)
+	if add.IsActive() {
+		t.Fatalf("Expected the add to be dead")
+	}
+
+	sim.Encounter.Targets[1].Spawn(sim)
+	if add.CurrentHealth() != 1000 {
+		t.Fatalf("Expected the add to respawn at full health, got %0.1f", add.CurrentHealth())
+	}
+}
+
+func TestTargetDeathDuringAOE(t *testing.T) {
+	addStats := stats.Stats{stats.Health: 100}
+	sim := setupSpawnTestSim([]*proto.Target{
+		{Name: "Add A", Stats: addStats.ToFloatArray(), DespawnOnDeath: true},
+		{Name: "Add B"},
+		{Name: "Add C"},
+	})
+	caster := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	runSimUntil(sim, time.Second)
+
+	hits := map[int32]int{}
+	for _, target := range sim.Encounter.ActiveTargetUnits {
+		hits[target.Index]++
+		caster.Spell.CalcAndDealDamage(sim, target, 1000, caster.Spell.OutcomeAlwaysHit)
+	}
+	if hits[0] != 1 || hits[1] != 1 || hits[2] != 1 {
+		t.Fatalf("Expected each target to be hit once, got %v", hits)
+	}
+	expectActiveTargets(t, sim, 1, 2)
+}
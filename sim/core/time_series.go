@@ -0,0 +1,253 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Records metrics in fixed-size bins of fight time, summed across iterations.
+// Runs as a Task, so that each bin is sampled exactly at its end time.
+type timeSeriesRecorder struct {
+	binSize time.Duration
+
+	// State for the current iteration.
+	binIdx   int
+	binStart time.Duration
+
+	binIterations []int32
+	units         []*unitTimeSeries
+}
+
+type unitTimeSeries struct {
+	unit *Unit
+	name string
+
+	damage float64 // Damage done at the start of the current bin.
+
+	dps          []float64
+	targetHealth []float64
+	resources    []*resourceTimeSeries
+	auras        []*auraTimeSeries
+}
+
+type resourceTimeSeries struct {
+	resourceType proto.ResourceType
+	values       []float64
+}
+
+type auraTimeSeries struct {
+	aura   *Aura
+	uptime time.Duration // Uptime at the start of the current bin.
+	values []float64
+}
+
+func newTimeSeriesRecorder(sim *Simulation, binSize time.Duration) *timeSeriesRecorder {
+	tsr := &timeSeriesRecorder{
+		binSize: binSize,
+	}
+
+	for _, unit := range sim.Raid.AllPlayerUnits {
+		uts := &unitTimeSeries{unit: unit, name: unit.Label}
+		if agent := sim.Raid.GetPlayerFromUnit(unit); agent != nil {
+			uts.name = agent.GetCharacter().Name
+		}
+
+		for _, resourceMetrics := range unit.Metrics.resources {
+			if _, ok := unit.currentResourceValue(resourceMetrics.Type); !ok {
+				continue
+			}
+			if !slices.ContainsFunc(uts.resources, func(rts *resourceTimeSeries) bool { return rts.resourceType == resourceMetrics.Type }) {
+				uts.resources = append(uts.resources, &resourceTimeSeries{resourceType: resourceMetrics.Type})
+			}
+		}
+
+		for _, aura := range unit.auras {
+			if !aura.ActionID.IsEmptyAction() {
+				uts.auras = append(uts.auras, &auraTimeSeries{aura: aura})
+			}
+		}
+
+		tsr.units = append(tsr.units, uts)
+	}
+
+	return tsr
+}
+
+// Returns the current value of a resource, or false if the unit doesn't have it.
+func (unit *Unit) currentResourceValue(resourceType proto.ResourceType) (float64, bool) {
+	switch resourceType {
+	case proto.ResourceType_ResourceTypeHealth:
+		return unit.CurrentHealth(), unit.HasHealthBar()
+	case proto.ResourceType_ResourceTypeMana:
+		return unit.CurrentMana(), unit.HasManaBar()
+	case proto.ResourceType_ResourceTypeRage:
+		return unit.CurrentRage(), unit.HasRageBar()
+	case proto.ResourceType_ResourceTypeEnergy:
+		return unit.CurrentEnergy(), unit.HasEnergyBar()
+	case proto.ResourceType_ResourceTypeComboPoints:
+		return float64(unit.ComboPoints()), unit.HasEnergyBar()
+	case proto.ResourceType_ResourceTypeFocus:
+		return unit.CurrentFocus(), unit.HasFocusBar()
+	case proto.ResourceType_ResourceTypeRunicPower:
+		return unit.CurrentRunicPower(), unit.HasRunicPowerBar()
+	case proto.ResourceType_ResourceTypeBloodRune:
+		return float64(unit.CurrentBloodRunes()), unit.HasRunicPowerBar()
+	case proto.ResourceType_ResourceTypeFrostRune:
+		return float64(unit.CurrentFrostRunes()), unit.HasRunicPowerBar()
+	case proto.ResourceType_ResourceTypeUnholyRune:
+		return float64(unit.CurrentUnholyRunes()), unit.HasRunicPowerBar()
+	case proto.ResourceType_ResourceTypeDeathRune:
+		return float64(unit.CurrentDeathRunes()), unit.HasRunicPowerBar()
+	}
+	return 0, false
+}
+
+// Returns the damage done by this unit so far in the current iteration.
+func (unit *Unit) damageDoneThisIteration() float64 {
+	damage := 0.0
+	for _, spell := range unit.Spellbook {
+		if spell.Flags.Matches(SpellFlagNoMetrics) || len(unit.AttackTables) == 0 {
+			continue
+		}
+		for _, spellMetrics := range spell.splitSpellMetrics {
+			for i, spellTargetMetrics := range spellMetrics {
+				if unit.IsOpponent(unit.AttackTables[i].Defender) {
+					damage += spellTargetMetrics.TotalDamage
+				}
+			}
+		}
+	}
+	return damage
+}
+
+// Returns the remaining health of a unit, from 0 to 1. Targets without a
+// health pool share the encounter's remaining health in health-based fights.
+func (sim *Simulation) healthPercent(unit *Unit) float64 {
+	if unit.HasHealthBar() {
+		return unit.CurrentHealthPercent()
+	}
+	if unit.Type == EnemyUnit && sim.Encounter.EndFightAtHealth > 0 {
+		return max(0, sim.GetRemainingDurationPercent())
+	}
+	return 1
+}
+
+func (uts *unitTimeSeries) damageDone() float64 {
+	damage := uts.unit.damageDoneThisIteration()
+	for _, petAgent := range uts.unit.PetAgents {
+		damage += petAgent.GetPet().damageDoneThisIteration()
+	}
+	return damage
+}
+
+func (tsr *timeSeriesRecorder) reset(sim *Simulation) {
+	tsr.binIdx = 0
+	tsr.binStart = 0
+	for _, uts := range tsr.units {
+		uts.damage = 0
+		for _, ats := range uts.auras {
+			ats.uptime = 0
+		}
+	}
+	sim.AddTask(tsr)
+	sim.RescheduleTask(tsr.binSize)
+}
+
+func (tsr *timeSeriesRecorder) RunTask(sim *Simulation) time.Duration {
+	for sim.CurrentTime >= tsr.binStart+tsr.binSize {
+		tsr.recordBin(sim, tsr.binStart+tsr.binSize)
+	}
+	return tsr.binStart + tsr.binSize
+}
+
+// Records the final, possibly partial, bin of the iteration.
+func (tsr *timeSeriesRecorder) doneIteration(sim *Simulation, endTime time.Duration) {
+	if endTime > tsr.binStart {
+		tsr.recordBin(sim, endTime)
+	}
+}
+
+func (tsr *timeSeriesRecorder) recordBin(sim *Simulation, binEnd time.Duration) {
+	idx := tsr.binIdx
+	if idx == len(tsr.binIterations) {
+		tsr.binIterations = append(tsr.binIterations, 0)
+		for _, uts := range tsr.units {
+			uts.dps = append(uts.dps, 0)
+			uts.targetHealth = append(uts.targetHealth, 0)
+			for _, rts := range uts.resources {
+				rts.values = append(rts.values, 0)
+			}
+			for _, ats := range uts.auras {
+				ats.values = append(ats.values, 0)
+			}
+		}
+	}
+	tsr.binIterations[idx]++
+
+	binLength := binEnd - tsr.binStart
+	for _, uts := range tsr.units {
+		damage := uts.damageDone()
+		uts.dps[idx] += (damage - uts.damage) / binLength.Seconds()
+		uts.damage = damage
+
+		if target := uts.unit.CurrentTarget; target != nil {
+			uts.targetHealth[idx] += sim.healthPercent(target)
+		}
+
+		for _, rts := range uts.resources {
+			value, _ := uts.unit.currentResourceValue(rts.resourceType)
+			rts.values[idx] += value
+		}
+
+		for _, ats := range uts.auras {
+			uptime := ats.aura.metrics.Uptime
+			if ats.aura.active {
+				uptime += min(binEnd, ats.aura.expires) - max(ats.aura.startTime, 0)
+			}
+			ats.values[idx] += float64(uptime-ats.uptime) / float64(binLength)
+			ats.uptime = uptime
+		}
+	}
+
+	tsr.binIdx++
+	tsr.binStart = binEnd
+}
+
+func (tsr *timeSeriesRecorder) ToProto() *proto.TimeSeriesMetrics {
+	averages := func(sums []float64) []float64 {
+		avgs := make([]float64, len(sums))
+		for i, sum := range sums {
+			avgs[i] = sum / float64(tsr.binIterations[i])
+		}
+		return avgs
+	}
+
+	metrics := &proto.TimeSeriesMetrics{
+		BinSeconds:    tsr.binSize.Seconds(),
+		BinIterations: tsr.binIterations,
+	}
+	for _, uts := range tsr.units {
+		unitMetrics := &proto.UnitTimeSeries{
+			Name:                uts.name,
+			UnitIndex:           uts.unit.UnitIndex,
+			Dps:                 averages(uts.dps),
+			TargetHealthPercent: averages(uts.targetHealth),
+		}
+		for _, rts := range uts.resources {
+			unitMetrics.Resources = append(unitMetrics.Resources, &proto.ResourceTimeSeries{
+				Type:   rts.resourceType,
+				Values: averages(rts.values),
+			})
+		}
+		for _, ats := range uts.auras {
+			unitMetrics.Auras = append(unitMetrics.Auras, &proto.AuraTimeSeries{
+				Id:     ats.aura.ActionID.ToProto(),
+				Uptime: averages(ats.values),
+			})
+		}
+		metrics.Players = append(metrics.Players, unitMetrics)
+	}
+	return metrics
+}
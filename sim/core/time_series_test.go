@@ -0,0 +1,111 @@
+package core
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func TestTimeSeries(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.Options.Iterations = 20
+	sim.Options.TimeSeriesBinSeconds = 1
+	sim.BaseDuration = time.Millisecond * 2500
+
+	// Ticks at 0.7s, 1.4s and 2.1s, so every bin gets some damage.
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	fa.Dot.TickLength = time.Millisecond * 700
+	fa.Dot.OnReset = func(_ *Aura, sim *Simulation) {
+		fa.Dot.Apply(sim)
+	}
+
+	result := sim.run()
+	ts := result.TimeSeries
+	if ts == nil {
+		t.Fatalf("Expected time series metrics")
+	}
+	if !slices.Equal(ts.BinIterations, []int32{20, 20, 20}) {
+		t.Fatalf("Expected 3 bins reached by every iteration, got %v", ts.BinIterations)
+	}
+	if len(ts.Players) != 1 || ts.Players[0].Name != "Caster" {
+		t.Fatalf("Expected a single player series, got %v", ts.Players)
+	}
+
+	player := ts.Players[0]
+	if len(player.Dps) != 3 || len(player.TargetHealthPercent) != 3 {
+		t.Fatalf("Expected 3 bins of dps and target health, got %d and %d", len(player.Dps), len(player.TargetHealthPercent))
+	}
+	if len(player.Resources) != 1 || player.Resources[0].Type != proto.ResourceType_ResourceTypeHealth || len(player.Resources[0].Values) != 3 {
+		t.Fatalf("Expected health values for each bin, got %v", player.Resources)
+	}
+
+	// The last bin is only half a second long.
+	binDamage := player.Dps[0] + player.Dps[1] + player.Dps[2]*0.5
+	if player.Dps[0] == 0 || player.Dps[1] == 0 || player.Dps[2] == 0 {
+		t.Fatalf("Expected damage in every bin, got dps %v", player.Dps)
+	}
+	if totalDamage := result.RaidMetrics.Dps.Avg * 2.5; !WithinToleranceFloat64(binDamage, totalDamage, 0.0001) {
+		t.Fatalf("Expected the bins to add up to %f damage, got %f from dps %v", totalDamage, binDamage, player.Dps)
+	}
+}
+
+func TestTimeSeriesAuraUptime(t *testing.T) {
+	sim := SetupFakeSim()
+	aura := &Aura{
+		ActionID:  ActionID{SpellID: 1},
+		active:    true,
+		startTime: time.Millisecond * 500,
+		expires:   time.Millisecond * 1500,
+	}
+	tsr := &timeSeriesRecorder{
+		binSize: time.Second,
+		units: []*unitTimeSeries{{
+			unit:  sim.Raid.AllPlayerUnits[0],
+			auras: []*auraTimeSeries{{aura: aura}},
+		}},
+	}
+
+	tsr.recordBin(sim, time.Second)
+	tsr.recordBin(sim, time.Second*2)
+	aura.active = false
+	aura.metrics.Uptime = time.Second
+	tsr.recordBin(sim, time.Millisecond*2500)
+
+	if uptime := tsr.ToProto().Players[0].Auras[0].Uptime; !slices.Equal(uptime, []float64{0.5, 0.5, 0}) {
+		t.Fatalf("Expected uptime [0.5 0.5 0], got %v", uptime)
+	}
+}
+
+func TestCombineTimeSeries(t *testing.T) {
+	result := func(binIterations []int32, dps []float64) *proto.RaidSimResult {
+		return &proto.RaidSimResult{
+			RaidMetrics:      &proto.RaidMetrics{Dps: &proto.DistributionMetrics{}, Hps: &proto.DistributionMetrics{}},
+			EncounterMetrics: &proto.EncounterMetrics{},
+			TimeSeries: &proto.TimeSeriesMetrics{
+				BinSeconds:    1,
+				BinIterations: binIterations,
+				Players:       []*proto.UnitTimeSeries{{Name: "Caster", Dps: dps}},
+			},
+		}
+	}
+	results := []*proto.RaidSimResult{
+		result([]int32{10, 10}, []float64{100, 200}),
+		result([]int32{30, 30, 10}, []float64{200, 400, 600}),
+	}
+
+	rsrc := raidSimResultCombiner{}
+	rsrc.setBaseResult(results[0])
+	for _, r := range results {
+		rsrc.addTimeSeries(r.TimeSeries)
+	}
+
+	ts := rsrc.combined.TimeSeries
+	if !slices.Equal(ts.BinIterations, []int32{40, 40, 10}) {
+		t.Fatalf("Expected combined bin iterations [40 40 10], got %v", ts.BinIterations)
+	}
+	if dps := ts.Players[0].Dps; !slices.Equal(dps, []float64{175, 350, 600}) {
+		t.Fatalf("Expected combined dps [175 350 600], got %v", dps)
+	}
+}
@@ -188,14 +188,15 @@ func (unit *Unit) getSpellpowerValueImpl(spell *Spell) float64 {
 
 // Units can be disabled for several reasons:
 //  1. Downtime for temporary pets (e.g. Water Elemental)
-//  2. Enemy units in various phases (not yet implemented)
+//  2. Enemy units in various phases, see Target.Spawn() and Target.Despawn()
 //  3. Dead units (not yet implemented)
 func (unit *Unit) IsEnabled() bool {
 	return unit.enabled
 }
 
+// Units without a health bar, e.g. target dummies, can't die.
 func (unit *Unit) IsActive() bool {
-	return unit.IsEnabled() && unit.CurrentHealthPercent() > 0
+	return unit.IsEnabled() && (!unit.HasHealthBar() || unit.CurrentHealthPercent() > 0)
 }
 
 func (unit *Unit) IsOpponent(other *Unit) bool {
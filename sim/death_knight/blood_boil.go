@@ -31,7 +31,7 @@ func (dk *DeathKnight) registerBloodBoilSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			anyHit := false
-			for idx, aoeTarget := range sim.Encounter.TargetUnits {
+			for idx, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := dk.ClassSpellScaling*0.31700000167 + 0.08*spell.MeleeAttackPower()
 				baseDamage *= core.TernaryFloat64(dk.DiseasesAreActive(aoeTarget), 1.5, 1.0)
 				baseDamage *= sim.Encounter.AOECapMultiplier()
@@ -44,7 +44,7 @@ func (dk *DeathKnight) registerBloodBoilSpell() {
 				dk.AddRunicPower(sim, 10, rpMetric)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(sim.Encounter.ActiveTargetUnits)] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -59,7 +59,7 @@ func (dk *DeathKnight) registerDrwBloodBoilSpell() *core.Spell {
 		ProcMask:    core.ProcMaskSpellDamage,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx, aoeTarget := range sim.Encounter.TargetUnits {
+			for idx, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := dk.ClassSpellScaling*0.31700000167 + 0.08*spell.MeleeAttackPower()
 				baseDamage *= core.TernaryFloat64(dk.RuneWeapon.DiseasesAreActive(aoeTarget), 1.5, 1.0)
 				baseDamage *= sim.Encounter.AOECapMultiplier()
@@ -67,7 +67,7 @@ func (dk *DeathKnight) registerDrwBloodBoilSpell() *core.Spell {
 				results[idx] = spell.CalcDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(sim.Encounter.ActiveTargetUnits)] {
 				spell.DealDamage(sim, result)
 			}
 		},
@@ -37,7 +37,7 @@ func (dk *DeathKnight) registerHowlingBlastSpell() {
 		CritMultiplier: dk.DefaultMeleeCritMultiplier(),
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for idx, aoeTarget := range sim.Encounter.TargetUnits {
+			for idx, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := dk.ClassSpellScaling*1.17499995232 + 0.44*spell.MeleeAttackPower()
 
 				if aoeTarget != target {
@@ -53,7 +53,7 @@ func (dk *DeathKnight) registerHowlingBlastSpell() {
 				}
 			}
 
-			for _, result := range results {
+			for _, result := range results[:len(sim.Encounter.ActiveTargetUnits)] {
 				spell.DealDamage(sim, result)
 			}
 		},
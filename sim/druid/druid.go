@@ -280,6 +280,9 @@ func (druid *Druid) RegisterBalanceSpells() {
 	druid.registerWrathSpell()
 	druid.registerStarfallSpell()
 	druid.registerTyphoonSpell()
+	// Force of Nature (_force_of_nature.go) stays disabled until its treants are
+	// ported to Cataclysm; they still use Wrath stats and spell power scaling.
+	// It is a Balance talent, so the Guardian spec doesn't need it.
 	//druid.registerForceOfNatureCD()
 	druid.registerStarsurgeSpell()
 }
@@ -306,23 +309,24 @@ func (druid *Druid) RegisterFeralCatSpells() {
 	druid.registerTigersFurySpell()
 }
 
-// func (druid *Druid) RegisterFeralTankSpells() {
-// 	druid.registerBarkskinCD()
-// 	druid.registerBerserkCD()
-// 	druid.registerBearFormSpell()
-// 	druid.registerDemoralizingRoarSpell()
-// 	druid.registerEnrageSpell()
-// 	druid.registerFrenziedRegenerationCD()
-// 	druid.registerMangleBearSpell()
-// 	druid.registerMaulSpell()
-// 	druid.registerLacerateSpell()
-// 	druid.registerRakeSpell()
-// 	druid.registerRipSpell()
-// 	druid.registerSavageDefensePassive()
-// 	druid.registerSurvivalInstinctsCD()
-// 	druid.registerSwipeBearSpell()
-//  druid.registerThrashBearSpell()
-// }
+func (druid *Druid) RegisterFeralTankSpells() {
+	druid.registerBarkskinCD()
+	druid.registerBerserkCD()
+	druid.registerBearFormSpell()
+	druid.registerDemoralizingRoarSpell()
+	druid.registerEnrageSpell()
+	druid.registerFrenziedRegenerationCD()
+	druid.registerLacerateSpell()
+	druid.registerMangleBearSpell()
+	druid.registerMaulSpell()
+	druid.registerPulverizeSpell()
+	druid.registerRakeSpell()
+	druid.registerRipSpell()
+	druid.registerSavageDefensePassive()
+	druid.registerSurvivalInstinctsCD()
+	druid.registerSwipeBearSpell()
+	druid.registerThrashBearSpell()
+}
 
 func (druid *Druid) Reset(_ *core.Simulation) {
 
@@ -199,6 +199,7 @@ func (eb *eclipseEnergyBar) addLunarEnergy(amount float64, sim *core.Simulation,
 	}
 
 	metrics.AddEvent(amount, gain)
+	sim.CombatLogResource(&eb.druid.Unit, metrics, amount, gain, eb.lunarEnergy)
 }
 
 func (eb *eclipseEnergyBar) SetEclipse(eclipse Eclipse, sim *core.Simulation) {
@@ -269,6 +270,7 @@ func (eb *eclipseEnergyBar) addSolarEnergy(amount float64, sim *core.Simulation,
 	}
 
 	metrics.AddEvent(amount, gain)
+	sim.CombatLogResource(&eb.druid.Unit, metrics, amount, gain, eb.solarEnergy)
 }
 
 func (unit *Druid) NewSolarEnergyMetric(actionID core.ActionID) *core.ResourceMetrics {
@@ -0,0 +1,63 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// Mastery: Savage Defender
+func (druid *Druid) SavageDefenseMultiplier() float64 {
+	return 1.32 + 0.04*druid.GetMasteryPoints()
+}
+
+func (druid *Druid) registerSavageDefensePassive() {
+	if !druid.InForm(Bear) {
+		return
+	}
+
+	var shieldAmount float64
+
+	druid.SavageDefenseAura = druid.RegisterAura(core.Aura{
+		Label:    "Savage Defense",
+		ActionID: core.ActionID{SpellID: 62606},
+		Duration: 10 * time.Second,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			shieldAmount = 0.35 * druid.GetStat(stats.AttackPower) * druid.SavageDefenseMultiplier()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			shieldAmount = 0
+		},
+	})
+
+	druid.AddDynamicDamageTakenModifier(func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+		if druid.SavageDefenseAura.IsActive() && (result.Damage > 0) && spell.SpellSchool.Matches(core.SpellSchoolPhysical) {
+			absorbed := min(shieldAmount, result.Damage)
+			result.Damage -= absorbed
+			shieldAmount -= absorbed
+
+			if shieldAmount <= 0 {
+				druid.SavageDefenseAura.Deactivate(sim)
+			}
+		}
+	})
+
+	core.MakeProcTriggerAura(&druid.Unit, core.ProcTrigger{
+		Name:       "Savage Defense Trigger",
+		ActionID:   core.ActionID{SpellID: 62600},
+		Callback:   core.CallbackOnSpellHitDealt,
+		ProcMask:   core.ProcMaskMelee,
+		Outcome:    core.OutcomeCrit,
+		ProcChance: 0.5,
+		Handler: func(sim *core.Simulation, _ *core.Spell, _ *core.SpellResult) {
+			if !druid.InForm(Bear) {
+				return
+			}
+
+			// A new shield replaces the old one rather than stacking with it.
+			druid.SavageDefenseAura.Deactivate(sim)
+			druid.SavageDefenseAura.Activate(sim)
+		},
+	})
+}
@@ -28,13 +28,14 @@ func NewFeralTankDruid(character *core.Character, options *proto.Player) *FeralT
 	selfBuffs := druid.SelfBuffs{}
 
 	bear := &FeralTankDruid{
-		Druid:   druid.New(character, druid.Bear, selfBuffs, options.TalentsString),
-		Options: tankOptions.Options,
+		Druid:     druid.New(character, druid.Bear, selfBuffs, options.TalentsString),
+		Options:   tankOptions.Options,
+		vengeance: &core.VengeanceTracker{},
 	}
 
 	bear.SelfBuffs.InnervateTarget = &proto.UnitReference{}
-	if tankOptions.Options.InnervateTarget != nil {
-		bear.SelfBuffs.InnervateTarget = tankOptions.Options.InnervateTarget
+	if tankOptions.Options.ClassOptions != nil && tankOptions.Options.ClassOptions.InnervateTarget != nil {
+		bear.SelfBuffs.InnervateTarget = tankOptions.Options.ClassOptions.InnervateTarget
 	}
 
 	bear.EnableRageBar(core.RageBarOptions{
@@ -46,14 +47,12 @@ func NewFeralTankDruid(character *core.Character, options *proto.Player) *FeralT
 		// Base paw weapon.
 		MainHand:       bear.GetBearWeapon(),
 		AutoSwingMelee: true,
-		ReplaceMHSwing: bear.TryMaul,
 	})
-	bear.ReplaceBearMHFunc = bear.TryMaul
 
 	healingModel := options.HealingModel
 	if healingModel != nil {
 		if healingModel.InspirationUptime > 0.0 {
-			core.ApplyInspiration(bear.GetCharacter(), healingModel.InspirationUptime)
+			core.ApplyInspiration(&bear.Unit, healingModel.InspirationUptime)
 		}
 	}
 
@@ -64,6 +63,8 @@ type FeralTankDruid struct {
 	*druid.Druid
 
 	Options *proto.FeralTankDruid_Options
+
+	vengeance *core.VengeanceTracker
 }
 
 func (bear *FeralTankDruid) GetDruid() *druid.Druid {
@@ -75,6 +76,13 @@ func (bear *FeralTankDruid) Initialize() {
 	bear.RegisterFeralTankSpells()
 }
 
+func (bear *FeralTankDruid) ApplyTalents() {
+	bear.Druid.ApplyTalents()
+
+	// Vengeance
+	core.ApplyVengeanceEffect(&bear.Character, bear.vengeance, 84840)
+}
+
 func (bear *FeralTankDruid) Reset(sim *core.Simulation) {
 	bear.Druid.Reset(sim)
 	bear.Druid.ClearForm(sim)
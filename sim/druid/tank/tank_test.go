@@ -17,12 +17,12 @@ func TestFeralTank(t *testing.T) {
 		Class: proto.Class_ClassDruid,
 		Race:  proto.Race_RaceTauren,
 
-		GearSet:     core.GetGearSet("../../../ui/feral_tank_druid/gear_sets", "p1"),
+		GearSet:     core.GetGearSet("../../../ui/druid/feral_tank/gear_sets", "p1"),
 		Talents:     StandardTalents,
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
-		Rotation:    core.GetAplRotation("../../../ui/feral_tank_druid/apls", "default"),
+		Rotation:    core.GetAplRotation("../../../ui/druid/feral_tank/apls", "default"),
 
 		IsTank:          true,
 		InFrontOfTarget: true,
@@ -48,7 +48,7 @@ func BenchmarkSimulate(b *testing.B) {
 			&proto.Player{
 				Race:      proto.Race_RaceTauren,
 				Class:     proto.Class_ClassDruid,
-				Equipment: core.GetGearSet("../../../ui/feral_tank_druid/gear_sets", "p1").GearSet,
+				Equipment: core.GetGearSet("../../../ui/druid/feral_tank/gear_sets", "p1").GearSet,
 				Consumes:  FullConsumes,
 				Spec:      PlayerOptionsDefault,
 				Buffs:     core.FullIndividualBuffs,
@@ -70,28 +70,30 @@ func BenchmarkSimulate(b *testing.B) {
 	core.RaidBenchmark(b, rsr)
 }
 
-var StandardTalents = "-503232132322010353120300313511-20350001"
+var StandardTalents = "-2300322312310001220311-020331"
 var StandardGlyphs = &proto.Glyphs{
-	Major1: int32(proto.DruidMajorGlyph_GlyphOfMaul),
-	Major2: int32(proto.DruidMajorGlyph_GlyphOfSurvivalInstincts),
-	Major3: int32(proto.DruidMajorGlyph_GlyphOfFrenziedRegeneration),
+	Prime1: int32(proto.DruidPrimeGlyph_GlyphOfMangle),
+	Prime2: int32(proto.DruidPrimeGlyph_GlyphOfLacerate),
+	Prime3: int32(proto.DruidPrimeGlyph_GlyphOfBerserk),
+	Major1: int32(proto.DruidMajorGlyph_GlyphOfFrenziedRegeneration),
+	Major2: int32(proto.DruidMajorGlyph_GlyphOfMaul),
+	Major3: int32(proto.DruidMajorGlyph_GlyphOfBarkskin),
 }
 
 var PlayerOptionsDefault = &proto.Player_FeralTankDruid{
 	FeralTankDruid: &proto.FeralTankDruid{
 		Options: &proto.FeralTankDruid_Options{
-			InnervateTarget: &proto.UnitReference{}, // no Innervate
-			StartingRage:    20,
+			ClassOptions: &proto.DruidOptions{
+				InnervateTarget: &proto.UnitReference{}, // no Innervate
+			},
+			StartingRage: 20,
 		},
 	},
 }
 
 var FullConsumes = &proto.Consumes{
-	BattleElixir:    proto.BattleElixir_GurusElixir,
-	GuardianElixir:  proto.GuardianElixir_GiftOfArthas,
-	Food:            proto.Food_FoodBlackenedDragonfin,
-	DefaultPotion:   proto.Potions_IndestructiblePotion,
-	DefaultConjured: proto.Conjured_ConjuredHealthstone,
-	ThermalSapper:   true,
-	FillerExplosive: proto.Explosive_ExplosiveSaroniteBomb,
+	Flask:         proto.Flask_FlaskOfSteelskin,
+	Food:          proto.Food_FoodSkeweredEel,
+	DefaultPotion: proto.Potions_EarthenPotion,
+	PrepopPotion:  proto.Potions_EarthenPotion,
 }
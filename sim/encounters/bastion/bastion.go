@@ -0,0 +1,6 @@
+package bastion
+
+func Register() {
+	addHalfus("BoT 25")
+	addChogall("BoT 25")
+}
@@ -0,0 +1,233 @@
+package bastion
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addChogall(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        43324,
+			Name:      "Cho'gall",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      64_400_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    68000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     ChogallTargetInputs(),
+		},
+		AI: NewChogallAI(),
+	})
+	core.AddPresetEncounter("Cho'gall", []string{
+		bossPrefix + "/Cho'gall",
+	})
+}
+
+type ChogallAI struct {
+	Target *core.Target
+
+	IsHeroic        bool
+	IncludePhaseTwo bool
+
+	FuryOfChogall         *core.Spell
+	FuryOfChogallDebuff   *core.Aura
+	ShadowsOrders         *core.Spell
+	CorruptionOfTheOldGod *core.Spell
+
+	phaseTwoStart time.Duration
+}
+
+func ChogallTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Phase 2",
+			Tooltip:   "Model the ramping Corruption of the Old God raid damage once the boss drops below 25% health.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewChogallAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &ChogallAI{}
+	}
+}
+
+func (ai *ChogallAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludePhaseTwo = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.4
+	}
+
+	ai.registerFuryOfChogallSpell(target)
+	ai.registerShadowsOrdersSpell(target)
+	ai.registerCorruptionOfTheOldGodSpell(target)
+}
+
+func (ai *ChogallAI) Reset(sim *core.Simulation) {
+	ai.phaseTwoStart = core.NeverExpires
+	ai.FuryOfChogall.CD.Set(time.Second * 45)
+	ai.ShadowsOrders.CD.Set(time.Second * 5)
+}
+
+func (ai *ChogallAI) registerFuryOfChogallSpell(target *core.Target) {
+	actionID := core.ActionID{SpellID: 82524}
+
+	if ai.Target.CurrentTarget != nil {
+		// Each application increases physical and shadow damage taken by 20%.
+		ai.FuryOfChogallDebuff = ai.Target.CurrentTarget.GetOrRegisterAura(core.Aura{
+			Label:     "Fury of Cho'gall",
+			ActionID:  actionID,
+			MaxStacks: 10,
+			Duration:  time.Minute,
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+				for _, school := range []stats.SchoolIndex{stats.SchoolIndexPhysical, stats.SchoolIndexShadow} {
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[school] /= 1.0 + 0.2*float64(oldStacks)
+					aura.Unit.PseudoStats.SchoolDamageTakenMultiplier[school] *= 1.0 + 0.2*float64(newStacks)
+				}
+			},
+		})
+	}
+
+	ai.FuryOfChogall = target.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskMeleeMHSpecial,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 47,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.AutoAttacks.MH().EnemyWeaponDamage(sim, spell.MeleeAttackPower(), 0.4)
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeEnemyMeleeWhite)
+
+			ai.FuryOfChogallDebuff.Activate(sim)
+			ai.FuryOfChogallDebuff.AddStack(sim)
+		},
+	})
+}
+
+func (ai *ChogallAI) registerShadowsOrdersSpell(target *core.Target) {
+	ai.ShadowsOrders = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 81556},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Second * 2,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Empowered Shadows pulses through the raid shortly after the order is given.
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(23750, 26250), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ChogallAI) registerCorruptionOfTheOldGodSpell(target *core.Target) {
+	ai.CorruptionOfTheOldGod = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 82361},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 2,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Damage grows by 10% for every tick since the start of phase 2.
+			ticks := float64((sim.CurrentTime - ai.phaseTwoStart) / (time.Second * 2))
+			baseDamage := 4000 * (1 + 0.1*ticks)
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ChogallAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.IncludePhaseTwo && ai.phaseTwoStart == core.NeverExpires && sim.GetRemainingDurationPercent() < 0.25 {
+		ai.phaseTwoStart = sim.CurrentTime
+	}
+
+	inPhaseTwo := ai.phaseTwoStart != core.NeverExpires
+	if inPhaseTwo && ai.CorruptionOfTheOldGod.IsReady(sim) {
+		ai.CorruptionOfTheOldGod.Cast(sim, &ai.Target.Unit)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.Target.CurrentTarget != nil && ai.FuryOfChogall.IsReady(sim) {
+		ai.FuryOfChogall.Cast(sim, ai.Target.CurrentTarget)
+		return
+	}
+
+	// Cho'gall stops giving orders once phase 2 begins.
+	if !inPhaseTwo && ai.ShadowsOrders.IsReady(sim) {
+		ai.ShadowsOrders.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
@@ -0,0 +1,252 @@
+package bastion
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addHalfus(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        44600,
+			Name:      "Halfus Wyrmbreaker",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeHumanoid,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      37_570_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    62000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     HalfusTargetInputs(),
+		},
+		AI: NewHalfusAI(),
+	})
+	core.AddPresetEncounter("Halfus Wyrmbreaker", []string{
+		bossPrefix + "/Halfus Wyrmbreaker",
+	})
+}
+
+type HalfusAI struct {
+	Target *core.Target
+
+	IsHeroic              bool
+	IncludeProtoBehemoth  bool
+	healingReductionStack float64
+
+	MalevolentStrikes *core.Aura
+	ShadowNova        *core.Spell
+	FuriousRoar       *core.Spell
+	FireballBarrage   *core.Spell
+
+	roarsRemaining int
+}
+
+func HalfusTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values and healing reduction per Malevolent Strikes stack.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Proto-Behemoth",
+			Tooltip:   "Model the Fireball Barrage raid damage from the Proto-Behemoth.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewHalfusAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &HalfusAI{}
+	}
+}
+
+func (ai *HalfusAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeProtoBehemoth = config.TargetInputs[1].BoolValue
+
+	ai.healingReductionStack = 0.06
+	if ai.IsHeroic {
+		ai.healingReductionStack = 0.08
+		target.PseudoStats.DamageDealtMultiplier *= 1.35
+	}
+
+	ai.registerMalevolentStrikes(target)
+	ai.registerShadowNovaSpell(target)
+	ai.registerFuriousRoarSpell(target)
+	ai.registerFireballBarrageSpell(target)
+}
+
+func (ai *HalfusAI) Reset(sim *core.Simulation) {
+	ai.roarsRemaining = 0
+	ai.ShadowNova.CD.Set(core.DurationFromSeconds(8 + 4*sim.RandomFloat("Shadow Nova Timing")))
+	ai.FuriousRoar.CD.Set(time.Second * 30)
+	ai.FireballBarrage.CD.Set(time.Second * 15)
+}
+
+func (ai *HalfusAI) registerMalevolentStrikes(target *core.Target) {
+	if ai.Target.CurrentTarget == nil {
+		return
+	}
+
+	ai.MalevolentStrikes = ai.Target.CurrentTarget.GetOrRegisterAura(core.Aura{
+		Label:     "Malevolent Strikes",
+		ActionID:  core.ActionID{SpellID: 39171},
+		MaxStacks: 12,
+		Duration:  time.Second * 30,
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.HealingTakenMultiplier /= 1.0 - ai.healingReductionStack*float64(oldStacks)
+			aura.Unit.PseudoStats.HealingTakenMultiplier *= 1.0 - ai.healingReductionStack*float64(newStacks)
+		},
+	})
+
+	core.MakeProcTriggerAura(&target.Unit, core.ProcTrigger{
+		Name:     "Malevolent Strikes Trigger",
+		Callback: core.CallbackOnSpellHitDealt,
+		ProcMask: core.ProcMaskMelee,
+		Outcome:  core.OutcomeLanded,
+		Harmful:  true,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if result.Target != ai.MalevolentStrikes.Unit {
+				return
+			}
+			ai.MalevolentStrikes.Activate(sim)
+			ai.MalevolentStrikes.AddStack(sim)
+		},
+	})
+}
+
+func (ai *HalfusAI) registerShadowNovaSpell(target *core.Target) {
+	ai.ShadowNova = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86168},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 17,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Millisecond * 1500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(34125, 35875), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *HalfusAI) registerFuriousRoarSpell(target *core.Target) {
+	ai.FuriousRoar = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86169},
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagIgnoreResists,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(29250, 30750), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *HalfusAI) registerFireballBarrageSpell(target *core.Target) {
+	ai.FireballBarrage = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 83706},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 20,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// The Behemoth fires a volley of fireballs at random raid members.
+			players := sim.Raid.AllPlayerUnits
+			for i := 0; i < 5; i++ {
+				victim := players[int(sim.RandomFloat("Fireball Barrage Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, victim, sim.Roll(15600, 16400), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *HalfusAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.IncludeProtoBehemoth && ai.FireballBarrage.IsReady(sim) {
+		ai.FireballBarrage.Cast(sim, &ai.Target.Unit)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	// Furious Roar is always cast three times in a row.
+	if ai.roarsRemaining > 0 {
+		ai.roarsRemaining--
+		ai.FuriousRoar.SkipCastAndApplyEffects(sim, &ai.Target.Unit)
+		ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+		return
+	}
+
+	if ai.FuriousRoar.IsReady(sim) {
+		ai.roarsRemaining = 2
+		ai.FuriousRoar.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	if ai.ShadowNova.IsReady(sim) {
+		ai.ShadowNova.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
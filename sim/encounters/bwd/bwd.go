@@ -0,0 +1,6 @@
+package bwd
+
+func Register() {
+	addMagmaw("BWD 25")
+	addNefarian("BWD 25")
+}
@@ -0,0 +1,220 @@
+package bwd
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addMagmaw(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        41570,
+			Name:      "Magmaw",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      26_600_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    58000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     MagmawTargetInputs(),
+		},
+		AI: NewMagmawAI(),
+	})
+	core.AddPresetEncounter("Magmaw", []string{
+		bossPrefix + "/Magmaw",
+	})
+}
+
+type MagmawAI struct {
+	Target *core.Target
+
+	IsHeroic           bool
+	IncludeExposedHead bool
+
+	LavaSpew             *core.Spell
+	Mangle               *core.Spell
+	PointOfVulnerability *core.Aura
+
+	nextMassiveCrash time.Duration
+}
+
+func MagmawTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Exposed Head",
+			Tooltip:   "Model the window after each Mangle where Magmaw is pinned and takes double damage, and does not melee the tank.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewMagmawAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &MagmawAI{}
+	}
+}
+
+func (ai *MagmawAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeExposedHead = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.3
+	}
+
+	ai.registerLavaSpewSpell(target)
+	ai.registerMangleSpell(target)
+	ai.registerPointOfVulnerabilityAura(target)
+}
+
+func (ai *MagmawAI) Reset(sim *core.Simulation) {
+	ai.nextMassiveCrash = core.NeverExpires
+	ai.LavaSpew.CD.Set(core.DurationFromSeconds(18 + 4*sim.RandomFloat("Lava Spew Timing")))
+	ai.Mangle.CD.Set(time.Second * 90)
+}
+
+func (ai *MagmawAI) registerLavaSpewSpell(target *core.Target) {
+	ai.LavaSpew = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 77689},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 22,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Lava Spew",
+			},
+			NumberOfTicks: 3,
+			TickLength:    time.Second,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, sim.Roll(7650, 8450), dot.Spell.OutcomeAlwaysHit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.Dot(aoeTarget).Apply(sim)
+			}
+		},
+	})
+}
+
+func (ai *MagmawAI) registerMangleSpell(target *core.Target) {
+	ai.Mangle = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 89773},
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskMeleeMHSpecial,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 95,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Mangle",
+			},
+			NumberOfTicks: 10,
+			TickLength:    time.Second * 3,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, 18000, dot.Spell.OutcomeAlwaysHit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, sim.Roll(142500, 157500), spell.OutcomeAlwaysHit)
+			spell.Dot(target).Apply(sim)
+		},
+	})
+}
+
+func (ai *MagmawAI) registerPointOfVulnerabilityAura(target *core.Target) {
+	ai.PointOfVulnerability = target.GetOrRegisterAura(core.Aura{
+		Label:    "Point of Vulnerability",
+		ActionID: core.ActionID{SpellID: 79011},
+		Duration: time.Second * 30,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 2
+			aura.Unit.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+aura.Duration, false)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 2
+		},
+	})
+}
+
+func (ai *MagmawAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if sim.CurrentTime >= ai.nextMassiveCrash {
+		ai.nextMassiveCrash = core.NeverExpires
+		ai.PointOfVulnerability.Activate(sim)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.Target.CurrentTarget != nil && ai.Mangle.IsReady(sim) {
+		ai.Mangle.Cast(sim, ai.Target.CurrentTarget)
+
+		// Players have to hit the spiked chains to pin Magmaw's head, which takes a few seconds.
+		if ai.IncludeExposedHead {
+			ai.nextMassiveCrash = sim.CurrentTime + time.Second*12
+		}
+		return
+	}
+
+	if ai.LavaSpew.IsReady(sim) && !ai.PointOfVulnerability.IsActive() {
+		ai.LavaSpew.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, min(sim.CurrentTime+time.Millisecond*1620, ai.nextMassiveCrash))
+}
@@ -0,0 +1,197 @@
+package bwd
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addNefarian(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        41376,
+			Name:      "Nefarian",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      55_400_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    72000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       true,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     NefarianTargetInputs(),
+		},
+		AI: NewNefarianAI(),
+	})
+	core.AddPresetEncounter("Nefarian", []string{
+		bossPrefix + "/Nefarian",
+	})
+}
+
+// Models the final phase of the encounter, where Nefarian is tanked on the
+// central platform.
+type NefarianAI struct {
+	Target *core.Target
+
+	IsHeroic           bool
+	IncludeElectrocute bool
+	IncludeShadowblaze bool
+
+	ShadowflameBreath *core.Spell
+	Electrocute       *core.Spell
+	Shadowblaze       *core.Spell
+
+	nextElectrocuteThreshold float64
+}
+
+func NefarianTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Electrocute",
+			Tooltip:   "Model the raid-wide Electrocute at every 10% of boss health.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+		{
+			Label:     "Include Shadowblaze",
+			Tooltip:   "Force the raid to move out of Shadowblaze every 30 seconds.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewNefarianAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &NefarianAI{}
+	}
+}
+
+func (ai *NefarianAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeElectrocute = config.TargetInputs[1].BoolValue
+	ai.IncludeShadowblaze = config.TargetInputs[2].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.3
+	}
+
+	ai.registerShadowflameBreathSpell(target)
+	ai.registerElectrocuteSpell(target)
+	ai.registerShadowblazeSpell(target)
+}
+
+func (ai *NefarianAI) Reset(sim *core.Simulation) {
+	ai.nextElectrocuteThreshold = 0.9
+	ai.ShadowflameBreath.CD.Set(core.DurationFromSeconds(10 + 5*sim.RandomFloat("Shadowflame Breath Timing")))
+	ai.Shadowblaze.CD.Set(time.Second * 30)
+}
+
+func (ai *NefarianAI) registerShadowflameBreathSpell(target *core.Target) {
+	ai.ShadowflameBreath = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 77826},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Second * 2,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealDamage(sim, target, sim.Roll(85500, 94500), spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *NefarianAI) registerElectrocuteSpell(target *core.Target) {
+	ai.Electrocute = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 81198},
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, 100000, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *NefarianAI) registerShadowblazeSpell(target *core.Target) {
+	ai.Shadowblaze = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 81031},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				unit.MoveDuration(time.Second*2, sim)
+			}
+		},
+	})
+}
+
+func (ai *NefarianAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.IncludeElectrocute && ai.nextElectrocuteThreshold > 0.05 && sim.GetRemainingDurationPercent() <= ai.nextElectrocuteThreshold {
+		ai.nextElectrocuteThreshold -= 0.1
+		ai.Electrocute.Cast(sim, &ai.Target.Unit)
+	}
+
+	if ai.IncludeShadowblaze && ai.Shadowblaze.IsReady(sim) {
+		ai.Shadowblaze.Cast(sim, &ai.Target.Unit)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.Target.CurrentTarget != nil && ai.ShadowflameBreath.IsReady(sim) {
+		ai.ShadowflameBreath.Cast(sim, ai.Target.CurrentTarget)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
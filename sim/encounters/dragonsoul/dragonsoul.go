@@ -0,0 +1,6 @@
+package dragonsoul
+
+func Register() {
+	addMorchok("Dragon Soul 25")
+	addUltraxion("Dragon Soul 25")
+}
@@ -0,0 +1,269 @@
+package dragonsoul
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addMorchok(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        55265,
+			Name:      "Morchok",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      82_500_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    95000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     MorchokTargetInputs(),
+		},
+		AI: NewMorchokAI(),
+	})
+	core.AddPresetEncounter("Morchok", []string{
+		bossPrefix + "/Morchok",
+	})
+}
+
+type MorchokAI struct {
+	Target *core.Target
+
+	IsHeroic          bool
+	IncludeBlackBlood bool
+
+	Stomp             *core.Spell
+	CrushArmor        *core.Aura
+	ResonatingCrystal *core.Spell
+	BlackBlood        *core.Spell
+}
+
+func MorchokTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Black Blood",
+			Tooltip:   "Model the Earthen Vortex and Black Blood of the Earth phase, where the raid runs behind pillars and Morchok stops attacking.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewMorchokAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &MorchokAI{}
+	}
+}
+
+func (ai *MorchokAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeBlackBlood = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.5
+	}
+
+	ai.registerStompSpell(target)
+	ai.registerCrushArmor(target)
+	ai.registerResonatingCrystalSpell(target)
+	ai.registerBlackBloodSpell(target)
+}
+
+func (ai *MorchokAI) Reset(sim *core.Simulation) {
+	ai.Stomp.CD.Set(time.Second * 12)
+	ai.ResonatingCrystal.CD.Set(time.Second * 19)
+	ai.BlackBlood.CD.Set(time.Second * 56)
+}
+
+func (ai *MorchokAI) registerStompSpell(target *core.Target) {
+	ai.Stomp = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 103414},
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 12,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// The damage is split between everyone in melee range, with the two
+			// closest players taking a double share.
+			meleeUnits := make([]*core.Unit, 0, len(sim.Raid.AllPlayerUnits))
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				if unit.DistanceFromTarget <= core.MaxMeleeRange {
+					meleeUnits = append(meleeUnits, unit)
+				}
+			}
+
+			share := 1_200_000 / float64(len(meleeUnits)+2)
+			for _, unit := range meleeUnits {
+				baseDamage := share
+				if unit == target {
+					baseDamage *= 2
+				}
+				spell.CalcAndDealDamage(sim, unit, baseDamage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *MorchokAI) registerCrushArmor(target *core.Target) {
+	if ai.Target.CurrentTarget == nil {
+		return
+	}
+
+	ai.CrushArmor = ai.Target.CurrentTarget.GetOrRegisterAura(core.Aura{
+		Label:     "Crush Armor",
+		ActionID:  core.ActionID{SpellID: 103687},
+		MaxStacks: 10,
+		Duration:  time.Second * 20,
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.ArmorMultiplier /= 1.0 - 0.1*float64(oldStacks)
+			aura.Unit.PseudoStats.ArmorMultiplier *= 1.0 - 0.1*float64(newStacks)
+		},
+	})
+
+	core.MakeProcTriggerAura(&target.Unit, core.ProcTrigger{
+		Name:     "Crush Armor Trigger",
+		Callback: core.CallbackOnSpellHitDealt,
+		ProcMask: core.ProcMaskMelee,
+		Outcome:  core.OutcomeLanded,
+		Harmful:  true,
+		ICD:      time.Second * 6,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			if result.Target != ai.CrushArmor.Unit {
+				return
+			}
+			ai.CrushArmor.Activate(sim)
+			ai.CrushArmor.AddStack(sim)
+		},
+	})
+}
+
+func (ai *MorchokAI) registerResonatingCrystalSpell(target *core.Target) {
+	ai.ResonatingCrystal = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 103640},
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Resonating Crystal",
+			},
+			NumberOfTicks: 1,
+			TickLength:    time.Second * 12,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// Explodes once the crystal finishes charging; soakers spread the
+				// damage across the raid.
+				dot.Spell.CalcAndDealPeriodicDamage(sim, target, sim.Roll(33250, 36750), dot.Spell.OutcomeAlwaysHit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.Dot(aoeTarget).Apply(sim)
+			}
+		},
+	})
+}
+
+func (ai *MorchokAI) registerBlackBloodSpell(target *core.Target) {
+	ai.BlackBlood = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 103851},
+		SpellSchool: core.SpellSchoolNature,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 75,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			// Earthen Vortex pulls everyone in for 5s before the Black Blood
+			// pours out; the raid then hides behind a pillar while Morchok
+			// stops attacking.
+			blackBloodDuration := time.Second * 17
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				unit.MoveDuration(time.Second*5, sim)
+			}
+			ai.Target.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+time.Second*5+blackBloodDuration, false)
+			ai.Target.ExtendGCDUntil(sim, sim.CurrentTime+time.Second*5+blackBloodDuration)
+		},
+	})
+}
+
+func (ai *MorchokAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.IncludeBlackBlood && ai.BlackBlood.IsReady(sim) {
+		ai.BlackBlood.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	if ai.Target.CurrentTarget != nil && ai.Stomp.IsReady(sim) {
+		ai.Stomp.Cast(sim, ai.Target.CurrentTarget)
+		return
+	}
+
+	if ai.ResonatingCrystal.IsReady(sim) {
+		ai.ResonatingCrystal.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
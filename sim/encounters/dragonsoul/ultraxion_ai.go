@@ -0,0 +1,217 @@
+package dragonsoul
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addUltraxion(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        55294,
+			Name:      "Ultraxion",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeDragonkin,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      102_000_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    105000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     UltraxionTargetInputs(),
+		},
+		AI: NewUltraxionAI(),
+	})
+	core.AddPresetEncounter("Ultraxion", []string{
+		bossPrefix + "/Ultraxion",
+	})
+}
+
+type UltraxionAI struct {
+	Target *core.Target
+
+	IsHeroic           bool
+	IncludeFadingLight bool
+
+	UnstableMonstrosity *core.Spell
+	HourOfTwilight      *core.Spell
+	FadingLight         *core.Spell
+}
+
+func UltraxionTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Fading Light",
+			Tooltip:   "Model the tank leaving the Twilight realm when Fading Light expires.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewUltraxionAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &UltraxionAI{}
+	}
+}
+
+func (ai *UltraxionAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeFadingLight = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.45
+	}
+
+	ai.registerUnstableMonstrositySpell(target)
+	ai.registerHourOfTwilightSpell(target)
+	ai.registerFadingLightSpell(target)
+}
+
+func (ai *UltraxionAI) Reset(sim *core.Simulation) {
+	ai.UnstableMonstrosity.CD.Duration = time.Second * 6
+	ai.UnstableMonstrosity.CD.Set(time.Second * 6)
+	ai.HourOfTwilight.CD.Set(time.Second * 45)
+	ai.FadingLight.CD.Set(core.DurationFromSeconds(10 + 5*sim.RandomFloat("Fading Light Timing")))
+}
+
+func (ai *UltraxionAI) registerUnstableMonstrositySpell(target *core.Target) {
+	ai.UnstableMonstrosity = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 109176},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Twilight Instability hits a random player and splashes everyone
+			// nearby, so spread it across the whole raid.
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(11400, 12600), spell.OutcomeAlwaysHit)
+			}
+
+			// Pulses speed up by a second every minute, down to once per second.
+			spell.CD.Duration = max(time.Second, time.Second*6-sim.CurrentTime/time.Minute*time.Second)
+		},
+	})
+}
+
+func (ai *UltraxionAI) registerHourOfTwilightSpell(target *core.Target) {
+	ai.HourOfTwilight = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 106371},
+		SpellSchool: core.SpellSchoolShadow,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 45,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Second * 5,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Soakers use Heroic Will to leave the Twilight realm, so the damage
+			// is split between everyone that stays in.
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(47500, 52500), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *UltraxionAI) registerFadingLightSpell(target *core.Target) {
+	ai.FadingLight = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 105925},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 15,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Fading Light",
+			},
+			NumberOfTicks: 1,
+			TickLength:    time.Second * 7,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// The tank has to shift out of the Twilight realm before the
+				// debuff expires, which takes them away from the boss briefly.
+				target.MoveDuration(time.Second*2, sim)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.Dot(target).Apply(sim)
+		},
+	})
+}
+
+func (ai *UltraxionAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.UnstableMonstrosity.IsReady(sim) {
+		ai.UnstableMonstrosity.Cast(sim, &ai.Target.Unit)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.HourOfTwilight.IsReady(sim) {
+		ai.HourOfTwilight.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	if ai.IncludeFadingLight && ai.Target.CurrentTarget != nil && ai.FadingLight.IsReady(sim) {
+		ai.FadingLight.Cast(sim, ai.Target.CurrentTarget)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
@@ -0,0 +1,256 @@
+package firelands
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addBethtilac(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        52498,
+			Name:      "Beth'tilac",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeBeast,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      51_500_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    78000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    false,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     BethtilacTargetInputs(),
+		},
+		AI: NewBethtilacAI(),
+	})
+	core.AddPresetEncounter("Beth'tilac", []string{
+		bossPrefix + "/Beth'tilac",
+	})
+}
+
+type BethtilacAI struct {
+	Target *core.Target
+
+	IsHeroic     bool
+	PhaseTwoOnly bool
+
+	EmberFlare            *core.Spell
+	SmolderingDevastation *core.Spell
+	WidowsKiss            *core.Spell
+	WidowsKissDebuff      *core.Aura
+	Frenzy                *core.Aura
+
+	devastationsRemaining int
+}
+
+func BethtilacTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Phase 2 Only",
+			Tooltip:   "Skip the web phase and start the encounter with Beth'tilac on the ground.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+	}
+}
+
+func NewBethtilacAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &BethtilacAI{}
+	}
+}
+
+func (ai *BethtilacAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.PhaseTwoOnly = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.35
+	}
+
+	ai.registerEmberFlareSpell(target)
+	ai.registerSmolderingDevastationSpell(target)
+	ai.registerWidowsKissSpell(target)
+	ai.registerFrenzyAura(target)
+}
+
+func (ai *BethtilacAI) Reset(sim *core.Simulation) {
+	ai.devastationsRemaining = 3
+	if ai.PhaseTwoOnly {
+		ai.devastationsRemaining = 0
+	}
+
+	ai.EmberFlare.CD.Set(time.Second * 6)
+	ai.SmolderingDevastation.CD.Set(time.Second * 80)
+	ai.WidowsKiss.CD.Set(0)
+}
+
+func (ai *BethtilacAI) inPhaseTwo() bool {
+	return ai.devastationsRemaining == 0
+}
+
+func (ai *BethtilacAI) registerEmberFlareSpell(target *core.Target) {
+	ai.EmberFlare = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 98934},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(11700, 12300)
+			if ai.inPhaseTwo() {
+				baseDamage *= 1.5
+			}
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *BethtilacAI) registerSmolderingDevastationSpell(target *core.Target) {
+	ai.SmolderingDevastation = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 99052},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 90,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Second * 8,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			ai.devastationsRemaining--
+		},
+	})
+}
+
+func (ai *BethtilacAI) registerWidowsKissSpell(target *core.Target) {
+	actionID := core.ActionID{SpellID: 99476}
+
+	if ai.Target.CurrentTarget != nil {
+		// Each stack reduces healing received by 2%. The tank swaps at 10 stacks,
+		// so the debuff is modeled as capped there.
+		ai.WidowsKissDebuff = ai.Target.CurrentTarget.GetOrRegisterAura(core.Aura{
+			Label:     "The Widow's Kiss",
+			ActionID:  actionID,
+			MaxStacks: 10,
+			Duration:  time.Second * 20,
+			OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+				aura.Unit.PseudoStats.HealingTakenMultiplier /= 1.0 - 0.02*float64(oldStacks)
+				aura.Unit.PseudoStats.HealingTakenMultiplier *= 1.0 - 0.02*float64(newStacks)
+			},
+		})
+	}
+
+	ai.WidowsKiss = target.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID,
+		SpellSchool: core.SpellSchoolPhysical,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 2,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			ai.WidowsKissDebuff.Activate(sim)
+			ai.WidowsKissDebuff.AddStack(sim)
+			spell.CalcAndDealDamage(sim, target, 2000*float64(ai.WidowsKissDebuff.GetStacks()), spell.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ai *BethtilacAI) registerFrenzyAura(target *core.Target) {
+	ai.Frenzy = target.GetOrRegisterAura(core.Aura{
+		Label:     "Frenzy",
+		ActionID:  core.ActionID{SpellID: 99497},
+		MaxStacks: 100,
+		Duration:  core.NeverExpires,
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks int32, newStacks int32) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.0 + 0.05*float64(oldStacks)
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.0 + 0.05*float64(newStacks)
+		},
+	})
+}
+
+func (ai *BethtilacAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if ai.EmberFlare.IsReady(sim) {
+		ai.EmberFlare.Cast(sim, &ai.Target.Unit)
+	}
+
+	if ai.inPhaseTwo() {
+		if !ai.Frenzy.IsActive() {
+			ai.Frenzy.Activate(sim)
+			core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period:          time.Second * 5,
+				TickImmediately: true,
+				OnAction: func(sim *core.Simulation) {
+					ai.Frenzy.AddStack(sim)
+				},
+			})
+		}
+
+		if ai.Target.CurrentTarget != nil && ai.WidowsKiss.IsReady(sim) {
+			ai.WidowsKiss.Cast(sim, ai.Target.CurrentTarget)
+		}
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if !ai.inPhaseTwo() && ai.SmolderingDevastation.IsReady(sim) {
+		// Everyone on the ground has to run up onto the web to survive the cast.
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			unit.MoveDuration(time.Second*4, sim)
+		}
+		ai.SmolderingDevastation.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
@@ -0,0 +1,6 @@
+package firelands
+
+func Register() {
+	addBethtilac("Firelands 25")
+	addRagnaros("Firelands 25")
+}
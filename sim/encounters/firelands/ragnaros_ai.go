@@ -0,0 +1,299 @@
+package firelands
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func addRagnaros(bossPrefix string) {
+	core.AddPresetTarget(&core.PresetTarget{
+		PathPrefix: bossPrefix,
+		Config: &proto.Target{
+			Id:        52409,
+			Name:      "Ragnaros",
+			Level:     88,
+			MobType:   proto.MobType_MobTypeElemental,
+			TankIndex: 0,
+
+			Stats: stats.Stats{
+				stats.Health:      61_800_000,
+				stats.Armor:       11977,
+				stats.AttackPower: 650,
+			}.ToFloatArray(),
+
+			SpellSchool:      proto.SpellSchool_SpellSchoolPhysical,
+			SwingSpeed:       2.0,
+			MinBaseDamage:    88000, // TODO: Find real value
+			DamageSpread:     0.4,
+			SuppressDodge:    true,
+			ParryHaste:       false,
+			DualWield:        false,
+			DualWieldPenalty: false,
+			TargetInputs:     RagnarosTargetInputs(),
+		},
+		AI: NewRagnarosAI(),
+	})
+	core.AddPresetEncounter("Ragnaros", []string{
+		bossPrefix + "/Ragnaros",
+	})
+}
+
+// Models the first phase of the encounter, which is the one most relevant
+// for tank and healer gearing.
+type RagnarosAI struct {
+	Target *core.Target
+
+	IsHeroic             bool
+	IncludeSulfurasSmash bool
+
+	BurningWound    *core.Spell
+	WrathOfRagnaros *core.Spell
+	HandOfRagnaros  *core.Spell
+	MagmaTrap       *core.Spell
+	SulfurasSmash   *core.Spell
+}
+
+func RagnarosTargetInputs() []*proto.TargetInput {
+	return []*proto.TargetInput{
+		{
+			Label:     "Heroic",
+			Tooltip:   "Use heroic damage values.",
+			InputType: proto.InputType_Bool,
+			BoolValue: false,
+		},
+		{
+			Label:     "Include Sulfuras Smash",
+			Tooltip:   "Force the raid to dodge the lava waves from Sulfuras Smash.",
+			InputType: proto.InputType_Bool,
+			BoolValue: true,
+		},
+	}
+}
+
+func NewRagnarosAI() core.AIFactory {
+	return func() core.TargetAI {
+		return &RagnarosAI{}
+	}
+}
+
+func (ai *RagnarosAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+	ai.IsHeroic = config.TargetInputs[0].BoolValue
+	ai.IncludeSulfurasSmash = config.TargetInputs[1].BoolValue
+
+	if ai.IsHeroic {
+		target.PseudoStats.DamageDealtMultiplier *= 1.4
+	}
+
+	ai.registerBurningWoundSpell(target)
+	ai.registerWrathOfRagnarosSpell(target)
+	ai.registerHandOfRagnarosSpell(target)
+	ai.registerMagmaTrapSpell(target)
+	ai.registerSulfurasSmashSpell(target)
+}
+
+func (ai *RagnarosAI) Reset(sim *core.Simulation) {
+	ai.WrathOfRagnaros.CD.Set(time.Second * 6)
+	ai.HandOfRagnaros.CD.Set(time.Second * 25)
+	ai.MagmaTrap.CD.Set(time.Second * 15)
+	ai.SulfurasSmash.CD.Set(time.Second * 30)
+}
+
+func (ai *RagnarosAI) registerBurningWoundSpell(target *core.Target) {
+	ai.BurningWound = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 99399},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagNone,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Burning Wound",
+				MaxStacks: 20,
+				Duration:  time.Second * 20,
+			},
+			NumberOfTicks: 10,
+			TickLength:    time.Second * 2,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.SnapshotBaseDamage = 3000 * float64(dot.Aura.GetStacks())
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.Spell.OutcomeAlwaysHit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dot := spell.Dot(target)
+			if dot.IsActive() {
+				dot.Refresh(sim)
+				dot.AddStack(sim)
+			} else {
+				dot.Apply(sim)
+				dot.SetStacks(sim, 1)
+			}
+			dot.TakeSnapshot(sim, true)
+		},
+	})
+
+	core.MakeProcTriggerAura(&target.Unit, core.ProcTrigger{
+		Name:     "Burning Wound Trigger",
+		Callback: core.CallbackOnSpellHitDealt,
+		ProcMask: core.ProcMaskMelee,
+		Outcome:  core.OutcomeLanded,
+		Harmful:  true,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			ai.BurningWound.Cast(sim, result.Target)
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerWrathOfRagnarosSpell(target *core.Target) {
+	ai.WrathOfRagnaros = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 98263},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Hits three different random ranged players.
+			var players []*core.Unit
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				if unit.IsActive() && unit.DistanceFromTarget > core.MaxMeleeRange {
+					players = append(players, unit)
+				}
+			}
+			for i := 0; i < 3 && i < len(players); i++ {
+				j := i + int(sim.RandomFloat("Wrath of Ragnaros Target")*float64(len(players)-i))
+				players[i], players[j] = players[j], players[i]
+				spell.CalcAndDealDamage(sim, players[i], sim.Roll(48750, 51250), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerHandOfRagnarosSpell(target *core.Target) {
+	ai.HandOfRagnaros = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 98237},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// Only the melee group is close enough to be hit.
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				if aoeTarget.DistanceFromTarget > core.MaxMeleeRange {
+					continue
+				}
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(38000, 42000), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerMagmaTrapSpell(target *core.Target) {
+	ai.MagmaTrap = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 98164},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 25,
+			},
+			DefaultCast: core.Cast{
+				GCD: time.Millisecond * 1620,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			// A player soaks the trap, erupting for raid-wide damage.
+			for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+				spell.CalcAndDealDamage(sim, aoeTarget, sim.Roll(58500, 61500), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) registerSulfurasSmashSpell(target *core.Target) {
+	ai.SulfurasSmash = target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 98710},
+		SpellSchool: core.SpellSchoolFire,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    target.NewTimer(),
+				Duration: time.Second * 30,
+			},
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			for _, unit := range sim.Raid.AllPlayerUnits {
+				unit.MoveDuration(time.Second*3, sim)
+			}
+		},
+	})
+}
+
+func (ai *RagnarosAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	if ai.IncludeSulfurasSmash && ai.SulfurasSmash.IsReady(sim) {
+		ai.SulfurasSmash.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	for _, spell := range []*core.Spell{ai.WrathOfRagnaros, ai.HandOfRagnaros, ai.MagmaTrap} {
+		if spell.IsReady(sim) {
+			spell.Cast(sim, &ai.Target.Unit)
+			return
+		}
+	}
+
+	ai.Target.WaitUntil(sim, sim.CurrentTime+time.Millisecond*1620)
+}
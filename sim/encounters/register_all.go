@@ -2,6 +2,10 @@ package encounters
 
 import (
 	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/encounters/bastion"
+	"github.com/wowsims/cata/sim/encounters/bwd"
+	"github.com/wowsims/cata/sim/encounters/dragonsoul"
+	"github.com/wowsims/cata/sim/encounters/firelands"
 	"github.com/wowsims/cata/sim/encounters/icc"
 	"github.com/wowsims/cata/sim/encounters/naxxramas"
 	"github.com/wowsims/cata/sim/encounters/toc"
@@ -13,6 +17,13 @@ func init() {
 	ulduar.Register()
 	toc.Register()
 	icc.Register()
+
+	bastion.Register()
+	bwd.Register()
+	firelands.Register()
+	dragonsoul.Register()
+
+	core.RegisterScriptedAI(NewScriptedAI)
 }
 
 func AddSingleTargetBossEncounter(presetTarget *core.PresetTarget) {
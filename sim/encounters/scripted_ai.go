@@ -0,0 +1,301 @@
+package encounters
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+// Generic implementation of TargetAI which plays back an EncounterScript, so
+// that fights can be described in JSON instead of Go.
+type ScriptedAI struct {
+	Target *core.Target
+
+	Script *proto.EncounterScript
+
+	phases []*scriptedPhase
+
+	// Index of the phase currently running, or -1 before the pull.
+	phaseIdx   int
+	phaseStart time.Duration
+
+	// Movement and target events scheduled by the current phase.
+	pendingActions []*core.PendingAction
+}
+
+type scriptedPhase struct {
+	config *proto.EncounterPhase
+	aura   *core.Aura
+	spells []*scriptedSpell
+}
+
+type scriptedSpell struct {
+	config *proto.ScriptedSpell
+	spell  *core.Spell
+
+	nextCastAt time.Duration
+}
+
+func NewScriptedAI(script *proto.EncounterScript) core.TargetAI {
+	return &ScriptedAI{
+		Script: script,
+	}
+}
+
+func (ai *ScriptedAI) Initialize(target *core.Target, config *proto.Target) {
+	ai.Target = target
+
+	numTargets := target.Env.GetNumTargets()
+	for i, phaseConfig := range ai.Script.Phases {
+		for _, event := range phaseConfig.TargetEvents {
+			if event.TargetIndex <= 0 || event.TargetIndex >= numTargets {
+				panic(fmt.Sprintf("Encounter script phase %d has a target event for invalid target index %d", i+1, event.TargetIndex))
+			}
+		}
+
+		phase := &scriptedPhase{
+			config: phaseConfig,
+			aura:   ai.registerPhaseAura(target, i, phaseConfig),
+		}
+		for _, spellConfig := range phaseConfig.Spells {
+			phase.spells = append(phase.spells, &scriptedSpell{
+				config: spellConfig,
+				spell:  ai.registerScriptedSpell(target, spellConfig),
+			})
+		}
+		ai.phases = append(ai.phases, phase)
+	}
+}
+
+func (ai *ScriptedAI) registerPhaseAura(target *core.Target, idx int, config *proto.EncounterPhase) *core.Aura {
+	label := fmt.Sprintf("Phase %d", idx+1)
+	if config.Name != "" {
+		label += ": " + config.Name
+	}
+
+	damageTakenMultiplier := config.DamageTakenMultiplier
+	if damageTakenMultiplier == 0 {
+		damageTakenMultiplier = 1
+	}
+
+	return target.GetOrRegisterAura(core.Aura{
+		Label:    label,
+		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= damageTakenMultiplier
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= damageTakenMultiplier
+		},
+	})
+}
+
+func (ai *ScriptedAI) registerScriptedSpell(target *core.Target, config *proto.ScriptedSpell) *core.Spell {
+	return target.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: config.SpellId},
+		SpellSchool: core.SpellSchoolFromProto(config.School),
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNone,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      time.Millisecond * 1620,
+				CastTime: core.DurationFromSeconds(config.CastTime),
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			rollDamage := func() float64 {
+				return sim.Roll(config.MinDamage, max(config.MinDamage, config.MaxDamage))
+			}
+
+			switch config.Target {
+			case proto.ScriptedSpell_Tank:
+				if ai.Target.CurrentTarget != nil {
+					spell.CalcAndDealDamage(sim, ai.Target.CurrentTarget, rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			case proto.ScriptedSpell_Raid:
+				for _, aoeTarget := range sim.Raid.GetActiveUnits() {
+					spell.CalcAndDealDamage(sim, aoeTarget, rollDamage(), spell.OutcomeAlwaysHit)
+				}
+			case proto.ScriptedSpell_RandomPlayer:
+				players := sim.Raid.AllPlayerUnits
+				victim := players[int(sim.RandomFloat("Scripted Spell Target")*float64(len(players)))]
+				spell.CalcAndDealDamage(sim, victim, rollDamage(), spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+
+func (ai *ScriptedAI) Reset(sim *core.Simulation) {
+	ai.phaseIdx = -1
+	ai.phaseStart = 0
+	ai.pendingActions = ai.pendingActions[:0]
+
+	// Targets which spawn later in the fight start out despawned. This has to
+	// wait until the pull, since those targets are reset after this one.
+	sim.AddPendingAction(&core.PendingAction{
+		NextActionAt: 0,
+		Priority:     core.ActionPriorityPrePull,
+		OnAction: func(sim *core.Simulation) {
+			for _, targetIdx := range ai.lateSpawningTargets() {
+				sim.Encounter.Targets[targetIdx].Despawn(sim)
+			}
+		},
+	})
+}
+
+// Returns the targets whose first scripted event is a spawn.
+func (ai *ScriptedAI) lateSpawningTargets() []int32 {
+	seen := make(map[int32]bool)
+	var targets []int32
+	for _, phase := range ai.Script.Phases {
+		for _, event := range phase.TargetEvents {
+			if seen[event.TargetIndex] {
+				continue
+			}
+			seen[event.TargetIndex] = true
+			if event.Type == proto.ScriptedTargetEvent_Spawn {
+				targets = append(targets, event.TargetIndex)
+			}
+		}
+	}
+	return targets
+}
+
+func (ai *ScriptedAI) startPhase(sim *core.Simulation, idx int) {
+	if ai.phaseIdx >= 0 {
+		ai.phases[ai.phaseIdx].aura.Deactivate(sim)
+	}
+	for _, pa := range ai.pendingActions {
+		pa.Cancel(sim)
+	}
+	ai.pendingActions = ai.pendingActions[:0]
+
+	ai.phaseIdx = idx
+	ai.phaseStart = sim.CurrentTime
+
+	phase := ai.phases[idx]
+	phase.aura.Activate(sim)
+
+	for _, spell := range phase.spells {
+		spell.nextCastAt = ai.phaseStart + core.DurationFromSeconds(spell.config.FirstCast)
+	}
+
+	for _, movement := range phase.config.Movements {
+		ai.scheduleMovement(sim, movement)
+	}
+
+	for _, event := range phase.config.TargetEvents {
+		target := sim.Encounter.Targets[event.TargetIndex]
+		spawn := event.Type == proto.ScriptedTargetEvent_Spawn
+
+		pa := &core.PendingAction{
+			NextActionAt: ai.phaseStart + core.DurationFromSeconds(event.Time),
+			OnAction: func(sim *core.Simulation) {
+				if spawn {
+					target.Spawn(sim)
+				} else {
+					target.Despawn(sim)
+				}
+			},
+		}
+		sim.AddPendingAction(pa)
+		ai.pendingActions = append(ai.pendingActions, pa)
+	}
+}
+
+func (ai *ScriptedAI) scheduleMovement(sim *core.Simulation, config *proto.ScriptedMovement) {
+	duration := core.DurationFromSeconds(config.Duration)
+	move := func(sim *core.Simulation) {
+		for _, unit := range sim.Raid.AllPlayerUnits {
+			unit.MoveDuration(duration, sim)
+		}
+		if config.StopBossMelee {
+			ai.Target.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+duration, false)
+		}
+	}
+
+	pa := &core.PendingAction{
+		NextActionAt: ai.phaseStart + core.DurationFromSeconds(config.Time),
+		OnAction: func(sim *core.Simulation) {
+			move(sim)
+			if config.Period > 0 {
+				ai.pendingActions = append(ai.pendingActions, core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+					Period:   core.DurationFromSeconds(config.Period),
+					OnAction: move,
+				}))
+			}
+		},
+	}
+	sim.AddPendingAction(pa)
+	ai.pendingActions = append(ai.pendingActions, pa)
+}
+
+// Returns the boss's remaining health, between 0 and 1. Bosses without a
+// health bar fall back to the fight's remaining health or duration.
+func (ai *ScriptedAI) healthPercent(sim *core.Simulation) float64 {
+	if ai.Target.HasHealthBar() {
+		return ai.Target.CurrentHealthPercent()
+	}
+	return sim.GetRemainingDurationPercent()
+}
+
+// Returns the index of the latest phase whose trigger has been met.
+func (ai *ScriptedAI) currentPhase(sim *core.Simulation) int {
+	idx := max(ai.phaseIdx, 0)
+	for idx+1 < len(ai.phases) {
+		next := ai.phases[idx+1].config
+		timeReached := next.StartTime > 0 && sim.CurrentTime >= core.DurationFromSeconds(next.StartTime)
+		healthReached := next.StartHealthPercent > 0 && ai.healthPercent(sim) <= next.StartHealthPercent
+		if !timeReached && !healthReached {
+			break
+		}
+		idx++
+	}
+	return idx
+}
+
+func (ai *ScriptedAI) ExecuteCustomRotation(sim *core.Simulation) {
+	if len(ai.phases) == 0 {
+		return
+	}
+
+	if idx := ai.currentPhase(sim); idx != ai.phaseIdx {
+		ai.startPhase(sim, idx)
+	}
+
+	if !ai.Target.GCD.IsReady(sim) {
+		return
+	}
+
+	nextActionAt := sim.CurrentTime + time.Millisecond*1620
+	for _, spell := range ai.phases[ai.phaseIdx].spells {
+		if sim.CurrentTime < spell.nextCastAt {
+			nextActionAt = min(nextActionAt, spell.nextCastAt)
+			continue
+		}
+
+		if spell.config.Period > 0 {
+			spell.nextCastAt = sim.CurrentTime + core.DurationFromSeconds(spell.config.Period)
+		} else {
+			spell.nextCastAt = core.NeverExpires
+		}
+		spell.spell.Cast(sim, &ai.Target.Unit)
+		return
+	}
+
+	// Wake up in time for a timed phase change.
+	if ai.phaseIdx+1 < len(ai.phases) {
+		if startTime := ai.phases[ai.phaseIdx+1].config.StartTime; startTime > 0 {
+			nextActionAt = min(nextActionAt, core.DurationFromSeconds(startTime))
+		}
+	}
+
+	ai.Target.WaitUntil(sim, nextActionAt)
+}
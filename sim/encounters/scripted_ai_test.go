@@ -0,0 +1,135 @@
+package encounters
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+// The raid is a single target dummy, so the scripted boss is the only thing
+// acting and no agent has to be registered.
+func makeScriptedRequest(script *proto.EncounterScript) *proto.RaidSimRequest {
+	targetStats := make([]float64, stats.Len)
+	targetStats[stats.Health] = 1000000
+
+	return &proto.RaidSimRequest{
+		SimOptions: &proto.SimOptions{
+			Iterations: 1,
+			RandomSeed: 100,
+			IsTest:     true,
+		},
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Buffs: &proto.PartyBuffs{},
+				},
+			},
+			TargetDummies: 1,
+		},
+		Encounter: &proto.Encounter{
+			Targets: []*proto.Target{
+				{Name: "Boss", Level: 88, MobType: proto.MobType_MobTypeDemon, Stats: targetStats},
+			},
+			Duration: 180,
+			Script:   script,
+		},
+	}
+}
+
+// Each phase casts its own spell every 10s, so the cast metrics show when
+// each phase ran.
+var testScript = &proto.EncounterScript{
+	Phases: []*proto.EncounterPhase{
+		{
+			Name: "Pull",
+			Spells: []*proto.ScriptedSpell{
+				{SpellId: 1001, Target: proto.ScriptedSpell_Raid, MinDamage: 100, MaxDamage: 100, FirstCast: 5, Period: 10},
+			},
+		},
+		{
+			Name:      "Adds",
+			StartTime: 60,
+			Spells: []*proto.ScriptedSpell{
+				{SpellId: 1002, Target: proto.ScriptedSpell_Raid, MinDamage: 100, MaxDamage: 100, Period: 10},
+			},
+		},
+		{
+			Name:               "Burn",
+			StartHealthPercent: 0.3,
+			Spells: []*proto.ScriptedSpell{
+				{SpellId: 1003, Target: proto.ScriptedSpell_Raid, MinDamage: 100, MaxDamage: 100, Period: 10},
+			},
+		},
+	},
+}
+
+func expectCasts(t *testing.T, metrics *proto.UnitMetrics, expected map[int32]int32) {
+	t.Helper()
+	casts := make(map[int32]int32)
+	for _, action := range metrics.Actions {
+		for _, target := range action.Targets {
+			casts[action.Id.GetSpellId()] += target.Casts
+		}
+	}
+	for spellID, numCasts := range expected {
+		if casts[spellID] != numCasts {
+			t.Errorf("Expected %d casts of spell %d, got %d", numCasts, spellID, casts[spellID])
+		}
+	}
+}
+
+func TestScriptedAIPhaseTriggers(t *testing.T) {
+	result := core.RunRaidSim(makeScriptedRequest(testScript))
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed with error: %s", result.ErrorResult)
+	}
+
+	// The boss has no health bar, so the burn phase starts once 30% of the
+	// 180s fight remains, at the first GCD after 126s.
+	expectCasts(t, result.EncounterMetrics.Targets[0], map[int32]int32{
+		1001: 6, // 5s, 15s, ... 55s
+		1002: 7, // 60s, 70s, ... 120s
+		1003: 6, // ~126.5s, ~136.5s, ... ~176.5s
+	})
+}
+
+func TestScriptedAIHealthTriggerUsesTargetHealth(t *testing.T) {
+	sim := core.NewSim(makeScriptedRequest(testScript))
+	target := sim.Encounter.Targets[0]
+	target.EnableHealthBar()
+
+	// Most of the fight remains when the boss drops to 20% health at 75s.
+	sim.Reset()
+	sim.PrePull()
+	sim.AddPendingAction(&core.PendingAction{
+		NextActionAt: time.Second * 75,
+		OnAction: func(sim *core.Simulation) {
+			target.RemoveHealth(sim, target.MaxHealth()*0.8)
+		},
+	})
+	for !sim.Step() {
+	}
+	sim.Cleanup()
+
+	// The burn phase starts right after 75s instead of at 126s.
+	expectCasts(t, target.GetMetricsProto(), map[int32]int32{
+		1001: 6,  // 5s, 15s, ... 55s
+		1002: 2,  // 60s, 70s
+		1003: 11, // ~76.5s, ~86.5s, ... ~176.5s
+	})
+}
+
+func TestScriptedAIRequiresRegistration(t *testing.T) {
+	core.RegisterScriptedAI(nil)
+	defer core.RegisterScriptedAI(NewScriptedAI)
+
+	request := makeScriptedRequest(testScript)
+	request.SimOptions.IsTest = false
+	if result := core.RunRaidSim(request); !strings.Contains(result.ErrorResult, "scripted target AI") {
+		t.Fatalf("Expected an error for a script without the scripted AI, got %q", result.ErrorResult)
+	}
+}
@@ -44,7 +44,7 @@ func (hunter *Hunter) registerExplosiveTrapSpell(timer *core.Timer) {
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				baseDamage := 292 + 0.546*dot.Spell.RangedAttackPower(target)
 				dot.Spell.DamageMultiplierAdditive += bonusPeriodicDamageMultiplier
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.CalcAndDealPeriodicDamage(sim, aoeTarget, baseDamage/10, dot.Spell.OutcomeRangedHitAndCritNoBlock)
 				}
 				dot.Spell.DamageMultiplierAdditive -= bonusPeriodicDamageMultiplier
@@ -63,7 +63,7 @@ func (hunter *Hunter) registerExplosiveTrapSpell(timer *core.Timer) {
 				core.StartDelayedAction(sim, core.DelayedActionOptions{
 					DoAt: 0,
 					OnAction: func(sim *core.Simulation) {
-						for _, aoeTarget := range sim.Encounter.TargetUnits {
+						for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 							baseDamage := 292 + (0.0546 * spell.RangedAttackPower(aoeTarget))
 							baseDamage *= sim.Encounter.AOECapMultiplier()
 							spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeRangedHitAndCritNoBlock)
@@ -72,7 +72,7 @@ func (hunter *Hunter) registerExplosiveTrapSpell(timer *core.Timer) {
 					},
 				})
 			} else {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					baseDamage := 292 + (0.0546 * spell.RangedAttackPower(aoeTarget))
 					baseDamage *= sim.Encounter.AOECapMultiplier()
 					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeRangedHitAndCritNoBlock)
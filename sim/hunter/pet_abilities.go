@@ -263,7 +263,7 @@ func (hp *HunterPet) newDemoralizingScreech() *core.Spell {
 		School:  core.SpellSchoolPhysical,
 		OnSpellHitDealt: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
 			if result.Landed() {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					debuffs.Get(aoeTarget).Activate(sim)
 				}
 			}
@@ -247,7 +247,7 @@ func (mage *Mage) applyPyromaniac() {
 		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
 			dotSpells := []*core.Spell{mage.LivingBomb, mage.Ignite, mage.PyroblastDot, mage.Combustion}
 			activeDotTargets := 0
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				for _, spells := range dotSpells {
 					if spells.Dot(aoeTarget).IsActive() {
 						activeDotTargets++
@@ -287,7 +287,7 @@ func (mage *Mage) applyMoltenFury() {
 	mage.RegisterResetEffect(func(sim *core.Simulation) {
 		sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int32) {
 			if isExecute == 35 {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					moltenFuryAuras.Get(aoeTarget).Activate(sim)
 				}
 			}
@@ -1,63 +0,0 @@
-package paladin
-
-import (
-	"time"
-
-	"github.com/wowsims/cata/sim/core"
-	"github.com/wowsims/cata/sim/core/proto"
-)
-
-func (paladin *Paladin) registerExorcismSpell() {
-	paladin.Exorcism = paladin.RegisterSpell(core.SpellConfig{
-		ActionID:    core.ActionID{SpellID: 48801},
-		SpellSchool: core.SpellSchoolHoly,
-		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
-
-		ManaCost: core.ManaCostOptions{
-			BaseCost:   0.08,
-			Multiplier: 1 - 0.02*float64(paladin.Talents.Benediction),
-		},
-		Cast: core.CastConfig{
-			DefaultCast: core.Cast{
-				GCD:      core.GCDDefault,
-				CastTime: time.Millisecond * 1500,
-			},
-			CD: core.Cooldown{
-				Timer:    paladin.NewTimer(),
-				Duration: time.Second * 15,
-			},
-			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
-				if paladin.CurrentMana() >= cast.Cost {
-					castTime := paladin.ApplyCastSpeedForSpell(cast.CastTime, spell)
-					if castTime > 0 {
-						paladin.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+castTime, false)
-					}
-				}
-			},
-		},
-
-		DamageMultiplierAdditive: 1 +
-			paladin.getTalentSanctityOfBattleBonus() +
-			paladin.getMajorGlyphOfExorcismBonus() +
-			paladin.getItemSetAegisBattlegearBonus2(),
-		DamageMultiplier: 1,
-		ThreatMultiplier: 1,
-		CritMultiplier:   paladin.SpellCritMultiplier(),
-
-		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			baseDamage := sim.Roll(1028, 1146) +
-				.15*spell.SpellPower() +
-				.15*spell.MeleeAttackPower()
-
-			bonusCrit := core.TernaryFloat64(
-				target.MobType == proto.MobType_MobTypeDemon || target.MobType == proto.MobType_MobTypeUndead,
-				100*core.CritRatingPerCritChance,
-				0)
-
-			spell.BonusCritRating += bonusCrit
-			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
-			spell.BonusCritRating -= bonusCrit
-		},
-	})
-}
@@ -1,7 +0,0 @@
-package paladin
-
-import (
-	"time"
-)
-
-const SealDuration = time.Minute * 30
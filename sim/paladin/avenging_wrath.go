@@ -0,0 +1,57 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerAvengingWrath() {
+	actionID := core.ActionID{SpellID: 31884}
+
+	// Healing isn't affected by PseudoStats, so the healing half of the buff is a spell mod.
+	healingMod := paladin.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  SpellMaskHealing,
+		Kind:       core.SpellMod_DamageDone_Pct,
+		FloatValue: 0.2,
+	})
+
+	paladin.AvengingWrathAura = paladin.RegisterAura(core.Aura{
+		Label:    "Avenging Wrath",
+		ActionID: actionID,
+		Duration: time.Second * 20,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.2
+			healingMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.2
+			healingMod.Deactivate()
+		},
+	})
+
+	paladin.AvengingWrath = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL | core.SpellFlagMCD,
+		ClassSpellMask: SpellMaskAvengingWrath,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.08,
+		},
+		Cast: core.CastConfig{
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Minute*3 - time.Second*20*time.Duration(paladin.Talents.SanctifiedWrath),
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			paladin.AvengingWrathAura.Activate(sim)
+		},
+	})
+
+	paladin.AddMajorCooldown(core.MajorCooldown{
+		Spell: paladin.AvengingWrath,
+		Type:  core.CooldownTypeDPS,
+	})
+}
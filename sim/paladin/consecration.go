@@ -0,0 +1,55 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerConsecration() {
+	paladin.Consecration = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 26573},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskConsecration,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.55,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Second * 30,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultSpellCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Dot: core.DotConfig{
+			IsAOE: true,
+			Aura: core.Aura{
+				Label: "Consecration",
+			},
+			NumberOfTicks: 10,
+			TickLength:    time.Second * 1,
+
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// Consecration recalculates everything on each tick
+				baseDamage := 81 + 0.027*dot.Spell.SpellPower() + 0.027*dot.Spell.MeleeAttackPower()
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+					dot.Spell.CalcAndDealPeriodicDamage(sim, aoeTarget, baseDamage, dot.Spell.OutcomeMagicHitAndCrit)
+				}
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.AOEDot().Apply(sim)
+		},
+	})
+}
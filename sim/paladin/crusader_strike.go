@@ -0,0 +1,58 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerCrusaderStrike() {
+	actionID := core.ActionID{SpellID: 35395}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+
+	paladin.CrusaderStrike = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskMeleeMHSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskCrusaderStrike,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Millisecond * 4500,
+			},
+		},
+
+		DamageMultiplier: 1.35,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Sanctity of Battle reduces the cooldown of Crusader Strike by melee haste.
+			if paladin.Talents.SanctityOfBattle {
+				spell.CD.Set(sim.CurrentTime + time.Duration(float64(spell.CD.Duration)*spell.CdMultiplier/paladin.SwingSpeed()))
+			}
+
+			baseDamage := spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+
+			if result.Landed() {
+				holyPower := int32(1)
+				if paladin.ZealotryAura.IsActive() {
+					holyPower = 3
+				}
+				paladin.GainHolyPower(sim, holyPower, hpMetrics)
+			} else {
+				spell.IssueRefund(sim)
+			}
+		},
+	})
+}
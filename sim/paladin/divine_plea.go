@@ -4,42 +4,44 @@ import (
 	"time"
 
 	"github.com/wowsims/cata/sim/core"
-	"github.com/wowsims/cata/sim/core/proto"
 )
 
-func (paladin *Paladin) registerDivinePleaSpell() {
+func (paladin *Paladin) registerDivinePlea() {
 	actionID := core.ActionID{SpellID: 54428}
-	hasGlyph := paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfDivinePlea)
 	manaMetrics := paladin.NewManaMetrics(actionID)
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
 	var manaPA *core.PendingAction
 
+	healingMod := paladin.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  SpellMaskHealing,
+		Kind:       core.SpellMod_DamageDone_Pct,
+		FloatValue: -0.5,
+	})
+
 	paladin.DivinePleaAura = paladin.RegisterAura(core.Aura{
 		Label:    "Divine Plea",
 		ActionID: actionID,
-		Duration: time.Second*15 + 1, // Add 1 to make sure the last tick takes effect
+		Duration: time.Second*9 + 1, // Add 1 to make sure the last tick takes effect
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			manaPA = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
 				Period: time.Second * 3,
 				OnAction: func(sim *core.Simulation) {
-					paladin.AddMana(sim, 0.05*paladin.MaxMana(), manaMetrics)
+					paladin.AddMana(sim, 0.04*paladin.MaxMana(), manaMetrics)
 				},
 			})
-			if hasGlyph {
-				aura.Unit.PseudoStats.DamageTakenMultiplier *= 0.97
-			}
+			healingMod.Activate()
 		},
 		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
 			manaPA.Cancel(sim)
-			if hasGlyph {
-				aura.Unit.PseudoStats.DamageTakenMultiplier /= 0.97
-			}
+			healingMod.Deactivate()
 		},
 	})
 
 	paladin.DivinePlea = paladin.RegisterSpell(core.SpellConfig{
-		ActionID:    actionID,
-		SpellSchool: core.SpellSchoolHoly,
-		Flags:       core.SpellFlagAPL,
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskDivinePlea,
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
@@ -47,12 +49,17 @@ func (paladin *Paladin) registerDivinePleaSpell() {
 			},
 			CD: core.Cooldown{
 				Timer:    paladin.NewTimer(),
-				Duration: time.Minute * 1,
+				Duration: time.Minute * 2,
 			},
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			paladin.DivinePleaAura.Activate(sim)
+
+			// Shield of the Templar grants Holy Power when Divine Plea is cast.
+			if paladin.Talents.ShieldOfTheTemplar > 0 {
+				paladin.GainHolyPower(sim, paladin.Talents.ShieldOfTheTemplar, hpMetrics)
+			}
 		},
 	})
 }
@@ -0,0 +1,63 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerDivineStorm() {
+	if !paladin.Talents.DivineStorm {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 53385}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+
+	paladin.DivineStorm = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskMeleeMHSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskDivineStorm,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.05,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Millisecond * 4500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Sanctity of Battle reduces the cooldown of Divine Storm by melee haste.
+			if paladin.Talents.SanctityOfBattle {
+				spell.CD.Set(sim.CurrentTime + time.Duration(float64(spell.CD.Duration)*spell.CdMultiplier/paladin.SwingSpeed()))
+			}
+
+			numHits := 0
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+				baseDamage := spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
+				result := spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+				if result.Landed() {
+					numHits++
+				}
+			}
+
+			// Divine Storm only grants Holy Power when it hits at least 4 targets.
+			if numHits >= 4 {
+				paladin.GainHolyPower(sim, 1, hpMetrics)
+			}
+		},
+	})
+}
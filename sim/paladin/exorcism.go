@@ -0,0 +1,56 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) registerExorcism() {
+	paladin.Exorcism = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 879},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskExorcism,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.30,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 1500,
+			},
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				castTime := paladin.ApplyCastSpeedForSpell(cast.CastTime, spell)
+				if castTime > 0 {
+					paladin.AutoAttacks.StopMeleeUntil(sim, sim.CurrentTime+castTime, false)
+				}
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultSpellCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(2591, 2891) +
+				0.344*max(spell.SpellPower(), spell.MeleeAttackPower())
+
+			bonusCrit := core.TernaryFloat64(
+				target.MobType == proto.MobType_MobTypeDemon || target.MobType == proto.MobType_MobTypeUndead,
+				100*core.CritRatingPerCritChance,
+				0)
+
+			spell.BonusCritRating += bonusCrit
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHitAndCrit)
+			spell.BonusCritRating -= bonusCrit
+
+			if paladin.TheArtOfWarAura.IsActive() {
+				paladin.TheArtOfWarAura.Deactivate(sim)
+			}
+		},
+	})
+}
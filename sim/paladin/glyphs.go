@@ -0,0 +1,112 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+func (paladin *Paladin) ApplyGlyphs() {
+	paladin.applyPrimeGlyphs()
+	paladin.applyMajorGlyphs()
+}
+
+func (paladin *Paladin) applyPrimeGlyphs() {
+	// Glyph of Seal of Truth is handled in seals.go
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfCrusaderStrike) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskCrusaderStrike,
+			Kind:       core.SpellMod_BonusCrit_Rating,
+			FloatValue: 5 * core.CritRatingPerCritChance,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfExorcism) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskExorcism,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.2,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfJudgement) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskJudgement,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.1,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfTemplarSVerdict) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskTemplarsVerdict,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.15,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfHammerOfTheRighteous) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskHammerOfTheRighteous,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.1,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfShieldOfTheRighteous) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskShieldOfTheRighteous,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.1,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfWordOfGlory) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskWordOfGlory,
+			Kind:       core.SpellMod_DamageDone_Flat,
+			FloatValue: 0.1,
+		})
+	}
+
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfHolyShock) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskHolyShock,
+			Kind:       core.SpellMod_BonusCrit_Rating,
+			FloatValue: 5 * core.CritRatingPerCritChance,
+		})
+	}
+}
+
+func (paladin *Paladin) applyMajorGlyphs() {
+	if paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfTheAsceticCrusader) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskCrusaderStrike,
+			Kind:       core.SpellMod_PowerCost_Pct,
+			FloatValue: -0.3,
+		})
+	}
+
+	if paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfHammerOfWrath) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask:  SpellMaskHammerOfWrath,
+			Kind:       core.SpellMod_PowerCost_Pct,
+			FloatValue: -1,
+		})
+	}
+
+	if paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfConsecration) {
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask: SpellMaskConsecration,
+			Kind:      core.SpellMod_Cooldown_Flat,
+			TimeValue: time.Second * 6,
+		})
+		paladin.AddStaticMod(core.SpellModConfig{
+			ClassMask: SpellMaskConsecration,
+			Kind:      core.SpellMod_DotNumberOfTicks_Flat,
+			IntValue:  2,
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerHammerOfWrath() {
+	paladin.HammerOfWrath = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 24275},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskMeleeOrRangedSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskHammerOfWrath,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.12,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			// Sanctified Wrath allows Hammer of Wrath to be used at any health during Avenging Wrath.
+			return sim.IsExecutePhase20() ||
+				(paladin.Talents.SanctifiedWrath > 0 && paladin.AvengingWrathAura.IsActive())
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(3815, 4215) +
+				0.117*spell.SpellPower() +
+				0.39*spell.MeleeAttackPower()
+
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
+		},
+	})
+}
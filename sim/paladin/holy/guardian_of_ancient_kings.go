@@ -0,0 +1,69 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (holy *HolyPaladin) registerGuardianOfAncientKings() {
+	actionID := core.ActionID{SpellID: 86150}
+
+	lightOfTheAncientKings := holy.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86678},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful | core.SpellFlagIgnoreModifiers,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	// The guardian copies the next 5 heals cast by the Paladin.
+	holy.GuardianOfAncientKingsAura = holy.RegisterAura(core.Aura{
+		Label:     "Guardian of Ancient Kings",
+		ActionID:  actionID,
+		Duration:  time.Second * 30,
+		MaxStacks: 5,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.SetStacks(sim, aura.MaxStacks)
+		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if spell.ClassSpellMask&paladin.SpellMaskHealing == 0 {
+				return
+			}
+
+			lightOfTheAncientKings.CalcAndDealHealing(sim, result.Target, result.Damage, lightOfTheAncientKings.OutcomeHealing)
+			aura.RemoveStack(sim)
+			if aura.GetStacks() == 0 {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	holy.GuardianOfAncientKings = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL | core.SpellFlagMCD,
+		ClassSpellMask: paladin.SpellMaskGuardianOfAncientKings,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Minute * 5,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			holy.GuardianOfAncientKingsAura.Activate(sim)
+		},
+	})
+
+	holy.AddMajorCooldown(core.MajorCooldown{
+		Spell: holy.GuardianOfAncientKings,
+		Type:  core.CooldownTypeDPS,
+	})
+}
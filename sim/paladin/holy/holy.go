@@ -1,6 +1,8 @@
 package holy
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -28,11 +30,16 @@ func NewHolyPaladin(character *core.Character, options *proto.Player) *HolyPalad
 	holyOptions := options.GetHolyPaladin()
 
 	holy := &HolyPaladin{
-		Paladin: paladin.NewPaladin(character, options.TalentsString),
+		Paladin: paladin.NewPaladin(character, options.TalentsString, holyOptions.Options.ClassOptions),
 		Options: holyOptions.Options,
 	}
 
-	holy.PaladinAura = holyOptions.Options.ClassOptions.Aura
+	holy.EnableAutoAttacks(holy, core.AutoAttackOptions{
+		MainHand:       holy.WeaponFromMainHand(holy.DefaultMeleeCritMultiplier()),
+		AutoSwingMelee: true,
+	})
+
+	holy.RegisterGuardianOfAncientKings = holy.registerGuardianOfAncientKings
 
 	return holy
 }
@@ -41,6 +48,10 @@ type HolyPaladin struct {
 	*paladin.Paladin
 
 	Options *proto.HolyPaladin_Options
+
+	HolyShock   *core.Spell
+	HolyLight   *core.Spell
+	DivineLight *core.Spell
 }
 
 func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
@@ -54,8 +65,61 @@ func (holy *HolyPaladin) ApplyTalents() {
 
 func (holy *HolyPaladin) Initialize() {
 	holy.Paladin.Initialize()
+	holy.RegisterSpecializationEffects()
+
+	holy.registerHolyShock()
+	holy.registerHolyLight()
+	holy.registerDivineLight()
 }
 
 func (holy *HolyPaladin) Reset(sim *core.Simulation) {
 	holy.Paladin.Reset(sim)
 }
+
+func (holy *HolyPaladin) RegisterSpecializationEffects() {
+	// Illuminated Healing
+	holy.RegisterMastery()
+
+	// Walk in the Light (85102)
+	holy.AddStaticMod(core.SpellModConfig{
+		ClassMask:  paladin.SpellMaskHealing,
+		Kind:       core.SpellMod_DamageDone_Pct,
+		FloatValue: 0.1,
+	})
+
+	// Meditation (95859)
+	holy.PseudoStats.SpiritRegenRateCombat = 0.5
+}
+
+func (holy *HolyPaladin) GetMasteryBonus() float64 {
+	return (12 + 1.5*holy.GetMasteryPoints()) / 100
+}
+
+func (holy *HolyPaladin) RegisterMastery() {
+	illuminatedHealing := holy.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86273},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagNoOnCastComplete | core.SpellFlagHelpful,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+
+		Shield: core.ShieldConfig{
+			Aura: core.Aura{
+				Label:    "Illuminated Healing",
+				Duration: time.Second * 15,
+			},
+		},
+	})
+
+	core.MakeProcTriggerAura(&holy.Unit, core.ProcTrigger{
+		Name:           "Illuminated Healing Trigger",
+		ActionID:       core.ActionID{SpellID: 76669},
+		Callback:       core.CallbackOnHealDealt,
+		ClassSpellMask: paladin.SpellMaskHealing,
+		Handler: func(sim *core.Simulation, _ *core.Spell, result *core.SpellResult) {
+			illuminatedHealing.Shield(result.Target).Apply(sim, result.Damage*holy.GetMasteryBonus())
+		},
+	})
+}
@@ -0,0 +1,74 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (holy *HolyPaladin) registerHolyLight() {
+	holy.HolyLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 635},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHolyLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.12,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 2500,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.432,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.IsOpponent(&holy.Unit) {
+				target = &holy.Unit
+			}
+
+			spell.CalcAndDealHealing(sim, target, sim.Roll(2728, 3040), spell.OutcomeHealingCrit)
+		},
+	})
+}
+
+func (holy *HolyPaladin) registerDivineLight() {
+	holy.DivineLight = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 82326},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskDivineLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.35,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD:      core.GCDDefault,
+				CastTime: time.Millisecond * 3000,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 1.1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.IsOpponent(&holy.Unit) {
+				target = &holy.Unit
+			}
+
+			spell.CalcAndDealHealing(sim, target, sim.Roll(7501, 8355), spell.OutcomeHealingCrit)
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package holy
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (holy *HolyPaladin) registerHolyShock() {
+	actionID := core.ActionID{SpellID: 20473}
+	hpMetrics := holy.NewHolyPowerMetrics(actionID)
+
+	holy.HolyShock = holy.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHolyShock,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.07,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    holy.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   holy.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.269,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.IsOpponent(&holy.Unit) {
+				target = &holy.Unit
+			}
+
+			spell.CalcAndDealHealing(sim, target, sim.Roll(2629, 2847), spell.OutcomeHealingCrit)
+			holy.GainHolyPower(sim, 1, hpMetrics)
+		},
+	})
+}
@@ -0,0 +1,85 @@
+package paladin
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+)
+
+/*
+  unit specific holy power bar
+*/
+
+const MaxHolyPower = 3
+
+type holyPowerBar struct {
+	paladin   *Paladin
+	holyPower int32
+}
+
+func (hpb *holyPowerBar) reset() {
+	if hpb.paladin == nil {
+		return
+	}
+
+	hpb.holyPower = 0
+}
+
+func (paladin *Paladin) EnableHolyPowerBar() {
+	paladin.holyPowerBar = holyPowerBar{
+		paladin: paladin,
+	}
+}
+
+func (paladin *Paladin) HasHolyPowerBar() bool {
+	return paladin.holyPowerBar.paladin != nil
+}
+
+func (paladin *Paladin) NewHolyPowerMetrics(actionID core.ActionID) *core.ResourceMetrics {
+	return paladin.Metrics.NewResourceMetrics(actionID, proto.ResourceType_ResourceTypeHolyPower)
+}
+
+// Returns the amount of Holy Power currently stored on the bar.
+func (hpb *holyPowerBar) CurrentHolyPower() int32 {
+	return hpb.holyPower
+}
+
+// Returns the amount of Holy Power the next spender will act as if it consumed.
+// Divine Purpose makes the next spender behave as if 3 charges were consumed.
+func (hpb *holyPowerBar) GetHolyPowerValue() int32 {
+	if hpb.paladin.DivinePurposeAura.IsActive() {
+		return MaxHolyPower
+	}
+	return hpb.holyPower
+}
+
+func (hpb *holyPowerBar) GainHolyPower(sim *core.Simulation, amount int32, metrics *core.ResourceMetrics) {
+	if hpb.paladin == nil {
+		return
+	}
+
+	newHolyPower := min(hpb.holyPower+amount, MaxHolyPower)
+	metrics.AddEvent(float64(amount), float64(newHolyPower-hpb.holyPower))
+
+	if sim.Log != nil {
+		hpb.paladin.Log(sim, "Gained %d holy power from %s (%d --> %d) of %d total.", amount, metrics.ActionID, hpb.holyPower, newHolyPower, MaxHolyPower)
+	}
+	sim.CombatLogResource(&hpb.paladin.Unit, metrics, float64(amount), float64(newHolyPower-hpb.holyPower), float64(newHolyPower))
+
+	hpb.holyPower = newHolyPower
+}
+
+// Consumes all Holy Power, or the Divine Purpose proc if one is active.
+func (hpb *holyPowerBar) SpendHolyPower(sim *core.Simulation, metrics *core.ResourceMetrics) {
+	if hpb.paladin.DivinePurposeAura.IsActive() {
+		hpb.paladin.DivinePurposeAura.Deactivate(sim)
+		return
+	}
+
+	if sim.Log != nil {
+		hpb.paladin.Log(sim, "Spent %d holy power from %s (%d --> %d) of %d total.", hpb.holyPower, metrics.ActionID, hpb.holyPower, 0, MaxHolyPower)
+	}
+	metrics.AddEvent(float64(-hpb.holyPower), float64(-hpb.holyPower))
+	sim.CombatLogResource(&hpb.paladin.Unit, metrics, float64(-hpb.holyPower), float64(-hpb.holyPower), 0)
+
+	hpb.holyPower = 0
+}
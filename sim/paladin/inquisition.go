@@ -0,0 +1,51 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+func (paladin *Paladin) registerInquisition() {
+	actionID := core.ActionID{SpellID: 84963}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+
+	paladin.InquisitionAura = paladin.RegisterAura(core.Aura{
+		Label:    "Inquisition",
+		ActionID: actionID,
+		Duration: time.Second * 10,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] *= 1.3
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexHoly] /= 1.3
+		},
+	})
+
+	// Inquiry of Faith extends the duration of Inquisition.
+	durationPerHolyPower := time.Duration(float64(time.Second*10) * (1 + 0.66*float64(paladin.Talents.InquiryOfFaith)))
+
+	paladin.Inquisition = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagAPL | SpellFlagHolyPowerSpender,
+		ClassSpellMask: SpellMaskInquisition,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.GetHolyPowerValue() > 0
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			paladin.InquisitionAura.Duration = durationPerHolyPower * time.Duration(paladin.GetHolyPowerValue())
+			paladin.InquisitionAura.Activate(sim)
+			paladin.SpendHolyPower(sim, hpMetrics)
+		},
+	})
+}
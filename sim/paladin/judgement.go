@@ -0,0 +1,65 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerJudgement() {
+	/*
+	 * Judgement releases the energy of the active seal. Damage scales with hybrid AP/SP
+	 * coefficients depending on the seal, and Seal of Truth judgements are empowered by Censure stacks.
+	 */
+	paladin.Judgement = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 20271},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskMeleeOrRangedSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ClassSpellMask: SpellMaskJudgement,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.05,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    paladin.NewTimer(),
+				Duration: time.Second * 8,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.CurrentSeal.IsActive()
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			var baseDamage float64
+			switch paladin.CurrentSeal {
+			case paladin.SealOfTruthAura:
+				baseDamage = 1 + 0.223*spell.SpellPower() + 0.142*spell.MeleeAttackPower()
+				baseDamage *= 1 + 0.2*float64(paladin.Censure.Dot(target).GetStacks())
+			case paladin.SealOfRighteousnessAura:
+				baseDamage = 1 + 0.32*spell.SpellPower() + 0.2*spell.MeleeAttackPower()
+			default:
+				baseDamage = 1 + 0.25*spell.SpellPower() + 0.16*spell.MeleeAttackPower()
+			}
+
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeRangedHitAndCrit)
+			if !result.Landed() {
+				return
+			}
+
+			for _, onJudgementLanded := range paladin.OnJudgementLanded {
+				onJudgementLanded(sim, target)
+			}
+		},
+	})
+}
@@ -3,73 +3,91 @@ package paladin
 import (
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
 )
 
+var TalentTreeSizes = [3]int{20, 20, 20}
+
 const (
-	SpellFlagSecondaryJudgement = core.SpellFlagAgentReserved1
-	SpellFlagPrimaryJudgement   = core.SpellFlagAgentReserved2
+	SpellFlagHolyPowerSpender = core.SpellFlagAgentReserved1
 )
 
-var TalentTreeSizes = [3]int{20, 20, 20}
+const SpellMaskNone int64 = 0
+const (
+	SpellMaskCrusaderStrike int64 = 1 << iota
+	SpellMaskDivineStorm
+	SpellMaskTemplarsVerdict
+	SpellMaskHammerOfTheRighteous
+	SpellMaskShieldOfTheRighteous
+	SpellMaskAvengersShield
+	SpellMaskJudgement
+	SpellMaskExorcism
+	SpellMaskHammerOfWrath
+	SpellMaskConsecration
+	SpellMaskHolyShock
+	SpellMaskWordOfGlory
+	SpellMaskInquisition
+	SpellMaskSealOfTruth
+	SpellMaskCensure
+	SpellMaskSealOfRighteousness
+	SpellMaskSealOfInsight
+	SpellMaskAvengingWrath
+	SpellMaskZealotry
+	SpellMaskGuardianOfAncientKings
+	SpellMaskDivinePlea
+	SpellMaskHolyLight
+	SpellMaskDivineLight
+
+	SpellMaskSeals   = SpellMaskSealOfTruth | SpellMaskCensure | SpellMaskSealOfRighteousness
+	SpellMaskHealing = SpellMaskWordOfGlory | SpellMaskHolyShock | SpellMaskHolyLight | SpellMaskDivineLight | SpellMaskSealOfInsight
+)
 
 type Paladin struct {
 	core.Character
+	holyPowerBar
+
+	ClassSpellScaling float64
 
 	PaladinAura proto.PaladinAura
+	Seal        proto.PaladinSeal
 
 	Talents *proto.PaladinTalents
 
-	CurrentSeal      *core.Aura
-	CurrentJudgement *core.Aura
-
-	DivinePlea            *core.Spell
-	DivineStorm           *core.Spell
-	HolyWrath             *core.Spell
-	Consecration          *core.Spell
-	CrusaderStrike        *core.Spell
-	Exorcism              *core.Spell
-	HolyShield            *core.Spell
-	HammerOfTheRighteous  *core.Spell
-	HandOfReckoning       *core.Spell
-	ShieldOfRighteousness *core.Spell
-	AvengersShield        *core.Spell
-	JudgementOfWisdom     *core.Spell
-	JudgementOfLight      *core.Spell
-	HammerOfWrath         *core.Spell
-	SealOfVengeance       *core.Spell
-	SealOfRighteousness   *core.Spell
-	SealOfCommand         *core.Spell
-	AvengingWrath         *core.Spell
-	DivineProtection      *core.Spell
-	SovDotSpell           *core.Spell
-	// SealOfWisdom        *core.Spell
-	// SealOfLight         *core.Spell
-
-	HolyShieldAura          *core.Aura
-	RighteousFuryAura       *core.Aura
-	DivinePleaAura          *core.Aura
-	JudgementOfWisdomAura   *core.Aura
-	JudgementOfLightAura    *core.Aura
-	SealOfVengeanceAura     *core.Aura
-	SealOfCommandAura       *core.Aura
-	SealOfRighteousnessAura *core.Aura
-	AvengingWrathAura       *core.Aura
-	DivineProtectionAura    *core.Aura
-	ForbearanceAura         *core.Aura
-	VengeanceAura           *core.Aura
-
-	// SealOfWisdomAura        *core.Aura
-	// SealOfLightAura         *core.Aura
-
-	ArtOfWarInstantCast *core.Aura
-
-	SpiritualAttunementMetrics *core.ResourceMetrics
-
-	HasTuralyonsOrLiadrinsBattlegear2Pc bool
-
-	DemonAndUndeadTargetCount int32
-
-	mutualLockoutDPAW *core.Timer
+	CurrentSeal *core.Aura
+
+	AvengingWrath          *core.Spell
+	Censure                *core.Spell
+	Consecration           *core.Spell
+	CrusaderStrike         *core.Spell
+	DivinePlea             *core.Spell
+	DivineStorm            *core.Spell
+	Exorcism               *core.Spell
+	GuardianOfAncientKings *core.Spell
+	HammerOfWrath          *core.Spell
+	Inquisition            *core.Spell
+	Judgement              *core.Spell
+	SealOfInsight          *core.Spell
+	SealOfRighteousness    *core.Spell
+	SealOfTruth            *core.Spell
+	WordOfGlory            *core.Spell
+	Zealotry               *core.Spell
+
+	AvengingWrathAura          *core.Aura
+	DivinePleaAura             *core.Aura
+	DivinePurposeAura          *core.Aura
+	InquisitionAura            *core.Aura
+	SealOfInsightAura          *core.Aura
+	SealOfRighteousnessAura    *core.Aura
+	SealOfTruthAura            *core.Aura
+	TheArtOfWarAura            *core.Aura
+	ZealotryAura               *core.Aura
+	GuardianOfAncientKingsAura *core.Aura
+
+	// Called when a Judgement lands, so each spec can hook its passive effects.
+	OnJudgementLanded []func(sim *core.Simulation, target *core.Unit)
+
+	// Spec-specific Guardian of Ancient Kings behavior, set before Initialize.
+	RegisterGuardianOfAncientKings func()
 }
 
 // Implemented by each Paladin spec.
@@ -81,6 +99,9 @@ func (paladin *Paladin) GetCharacter() *core.Character {
 	return &paladin.Character
 }
 
+func (paladin *Paladin) HasPrimeGlyph(glyph proto.PaladinPrimeGlyph) bool {
+	return paladin.HasGlyph(int32(glyph))
+}
 func (paladin *Paladin) HasMajorGlyph(glyph proto.PaladinMajorGlyph) bool {
 	return paladin.HasGlyph(int32(glyph))
 }
@@ -93,111 +114,84 @@ func (paladin *Paladin) GetPaladin() *Paladin {
 }
 
 func (paladin *Paladin) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
-	// raidBuffs.DevotionAura = max(raidBuffs.DevotionAura, core.MakeTristateValue(
-	// 	paladin.PaladinAura == proto.PaladinAura_DevotionAura,
-	// 	paladin.Talents.ImprovedDevotionAura == 5))
-
-	// if paladin.PaladinAura == proto.PaladinAura_RetributionAura {
-	// 	raidBuffs.RetributionAura = true
-	// }
-
-	// if paladin.Talents.SanctifiedRetribution {
-	// 	raidBuffs.SanctifiedRetribution = true
-	// }
-
-	// if paladin.Talents.SwiftRetribution == 3 {
-	// 	raidBuffs.SwiftRetribution = paladin.Talents.SwiftRetribution == 3 // TODO: Fix-- though having something between 0/3 and 3/3 is unlikely
-	// }
-
-	// TODO: Figure out a way to just start with 1 DG cooldown available without making a redundant Spell
-	//if paladin.Talents.DivineGuardian == 2 {
-	//	raidBuffs.divineGuardians++
-	//}
+	if paladin.PaladinAura == proto.PaladinAura_DevotionAura {
+		raidBuffs.DevotionAura = true
+	}
+
+	if paladin.Talents.Communion {
+		raidBuffs.Communion = true
+	}
 }
 
 func (paladin *Paladin) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
 func (paladin *Paladin) Initialize() {
-	// // Update auto crit multipliers now that we have the targets.
-	// paladin.AutoAttacks.MHConfig().CritMultiplier = paladin.MeleeCritMultiplier()
-
-	// paladin.registerSealOfVengeanceSpellAndAura()
-	// paladin.registerSealOfRighteousnessSpellAndAura()
-	// paladin.registerSealOfCommandSpellAndAura()
-	// // paladin.setupSealOfTheCrusader()
-	// // paladin.setupSealOfWisdom()
-	// // paladin.setupSealOfLight()
-	// // paladin.setupSealOfRighteousness()
-	// // paladin.setupJudgementRefresh()
-
-	// paladin.registerCrusaderStrikeSpell()
-	// paladin.registerDivineStormSpell()
-	// paladin.registerConsecrationSpell()
-	// paladin.registerHammerOfWrathSpell()
-	// paladin.registerHolyWrathSpell()
-
-	// paladin.registerExorcismSpell()
-	// paladin.registerHolyShieldSpell()
-	// paladin.registerHammerOfTheRighteousSpell()
-	// paladin.registerHandOfReckoningSpell()
-	// paladin.registerShieldOfRighteousnessSpell()
-	// paladin.registerAvengersShieldSpell()
-	// paladin.registerJudgements()
-
-	// paladin.registerSpiritualAttunement()
-	// paladin.registerDivinePleaSpell()
-	// paladin.registerDivineProtectionSpell()
-	// paladin.registerForbearanceDebuff()
-
-	// for i := int32(0); i < paladin.Env.GetNumTargets(); i++ {
-	// 	unit := paladin.Env.GetTargetUnit(i)
-	// 	if unit.MobType == proto.MobType_MobTypeDemon || unit.MobType == proto.MobType_MobTypeUndead {
-	// 		paladin.DemonAndUndeadTargetCount += 1
-	// 	}
-	// }
+	paladin.registerSealOfTruth()
+	paladin.registerSealOfRighteousness()
+	paladin.registerSealOfInsight()
+
+	paladin.registerJudgement()
+	paladin.registerCrusaderStrike()
+	paladin.registerExorcism()
+	paladin.registerHammerOfWrath()
+	paladin.registerConsecration()
+	paladin.registerInquisition()
+	paladin.registerWordOfGlory()
+	paladin.registerAvengingWrath()
+	paladin.registerDivinePlea()
+
+	if paladin.RegisterGuardianOfAncientKings != nil {
+		paladin.RegisterGuardianOfAncientKings()
+	}
 }
 
-func (paladin *Paladin) Reset(_ *core.Simulation) {
+func (paladin *Paladin) Reset(sim *core.Simulation) {
+	paladin.holyPowerBar.reset()
+
 	paladin.CurrentSeal = nil
-	paladin.CurrentJudgement = nil
+	switch paladin.Seal {
+	case proto.PaladinSeal_Truth, proto.PaladinSeal_Vengeance:
+		paladin.CurrentSeal = paladin.SealOfTruthAura
+	case proto.PaladinSeal_Righteousness:
+		paladin.CurrentSeal = paladin.SealOfRighteousnessAura
+	case proto.PaladinSeal_Insight:
+		paladin.CurrentSeal = paladin.SealOfInsightAura
+	}
+	if paladin.CurrentSeal != nil {
+		paladin.CurrentSeal.Activate(sim)
+	}
 }
 
-// maybe need to add stat dependencies
-func NewPaladin(character *core.Character, talentsStr string) *Paladin {
+func NewPaladin(character *core.Character, talentsStr string, options *proto.PaladinOptions) *Paladin {
 	paladin := &Paladin{
-		Character: *character,
-		Talents:   &proto.PaladinTalents{},
+		Character:         *character,
+		Talents:           &proto.PaladinTalents{},
+		ClassSpellScaling: core.GetClassSpellScalingCoefficient(proto.Class_ClassPaladin),
+		PaladinAura:       options.Aura,
+		Seal:              options.Seal,
 	}
 
-	// core.FillTalentsProto(paladin.Talents.ProtoReflect(), talentsStr, TalentTreeSizes)
-
-	// // This is used to cache its effect in talents.go
-	// paladin.HasTuralyonsOrLiadrinsBattlegear2Pc = paladin.HasSetBonus(ItemSetTuralyonsBattlegear, 2)
+	core.FillTalentsProto(paladin.Talents.ProtoReflect(), talentsStr, TalentTreeSizes)
 
-	// paladin.PseudoStats.CanParry = true
+	paladin.EnableHolyPowerBar()
 
-	// paladin.EnableManaBar()
-	// paladin.AddStatDependency(stats.Strength, stats.AttackPower, 2.0)
-	// paladin.AddStatDependency(stats.Agility, stats.MeleeCrit, core.CritPerAgiMaxLevel[character.Class]*core.CritRatingPerCritChance)
+	paladin.PseudoStats.CanParry = true
 
-	// // Paladins get 0.0167 dodge per agi. ~1% per 59.88
-	// paladin.AddStatDependency(stats.Agility, stats.Dodge, (1.0/59.88)*core.DodgeRatingPerDodgeChance)
+	paladin.EnableManaBar()
+	paladin.AddStatDependency(stats.Strength, stats.AttackPower, 2.0)
+	paladin.AddStatDependency(stats.Agility, stats.MeleeCrit, core.CritPerAgiMaxLevel[character.Class]*core.CritRatingPerCritChance)
 
-	// // Paladins get more melee haste from haste than other classes
-	// paladin.PseudoStats.MeleeHasteRatingPerHastePercent /= 1.3
+	// Paladins get 27% of their Strength above base as Parry rating.
+	paladin.AddStat(stats.Parry, -paladin.GetBaseStats()[stats.Strength]*0.27)
+	paladin.AddStatDependency(stats.Strength, stats.Parry, 0.27)
 
-	// // Bonus Armor and Armor are treated identically for Paladins
-	// paladin.AddStatDependency(stats.BonusArmor, stats.Armor, 1)
+	// Bonus Armor and Armor are treated identically for Paladins
+	paladin.AddStatDependency(stats.BonusArmor, stats.Armor, 1)
 
-	// // Base dodge is unaffected by Diminishing Returns
-	// paladin.PseudoStats.BaseDodge += 0.034943
-	// paladin.PseudoStats.BaseParry += 0.05
+	// Base dodge and parry are unaffected by Diminishing Returns
+	paladin.PseudoStats.BaseDodge += 0.05
+	paladin.PseudoStats.BaseParry += 0.05
 
 	return paladin
 }
-
-// Shared 30sec cooldown for Divine Protection and Avenging Wrath
-func (paladin *Paladin) GetMutualLockoutDPAW() *core.Timer {
-	return paladin.Character.GetOrInitTimer(&paladin.mutualLockoutDPAW)
-}
@@ -0,0 +1,59 @@
+package protection
+
+import (
+	"slices"
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (prot *ProtectionPaladin) registerAvengersShield() {
+	prot.AvengersShield = prot.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 31935},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskMeleeOrRangedSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskAvengersShield,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.06,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			CD: core.Cooldown{
+				Timer:    prot.NewTimer(),
+				Duration: time.Second * 15,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return prot.PseudoStats.CanBlock
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   prot.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Grand Crusader procs double the damage of the next Avenger's Shield.
+			bonusMultiplier := core.TernaryFloat64(prot.GrandCrusaderAura.IsActive(), 2, 1)
+
+			// Bounces from the primary target to the next active targets.
+			activeTargets := sim.Encounter.ActiveTargetUnits
+			firstIndex := max(slices.Index(activeTargets, target), 0)
+			for hitIndex := 0; hitIndex < min(3, len(activeTargets)); hitIndex++ {
+				curTarget := activeTargets[(firstIndex+hitIndex)%len(activeTargets)]
+				baseDamage := sim.Roll(3288, 4010) + 0.21*spell.SpellPower() + 0.419*spell.MeleeAttackPower()
+				spell.CalcAndDealDamage(sim, curTarget, baseDamage*bonusMultiplier, spell.OutcomeRangedHitAndCrit)
+			}
+
+			if prot.GrandCrusaderAura.IsActive() {
+				prot.GrandCrusaderAura.Deactivate(sim)
+			}
+		},
+	})
+}
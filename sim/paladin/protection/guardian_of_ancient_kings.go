@@ -0,0 +1,49 @@
+package protection
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (prot *ProtectionPaladin) registerGuardianOfAncientKings() {
+	actionID := core.ActionID{SpellID: 86150}
+
+	prot.GuardianOfAncientKingsAura = prot.RegisterAura(core.Aura{
+		Label:    "Guardian of Ancient Kings",
+		ActionID: core.ActionID{SpellID: 86659},
+		Duration: time.Second * 12,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier *= 0.5
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.DamageTakenMultiplier /= 0.5
+		},
+	})
+
+	prot.GuardianOfAncientKings = prot.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL | core.SpellFlagMCD,
+		ClassSpellMask: paladin.SpellMaskGuardianOfAncientKings,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    prot.NewTimer(),
+				Duration: time.Minute * 3,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			prot.GuardianOfAncientKingsAura.Activate(sim)
+		},
+	})
+
+	prot.AddMajorCooldown(core.MajorCooldown{
+		Spell: prot.GuardianOfAncientKings,
+		Type:  core.CooldownTypeSurvival,
+	})
+}
@@ -0,0 +1,72 @@
+package protection
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (prot *ProtectionPaladin) registerHammerOfTheRighteous() {
+	if !prot.Talents.HammerOfTheRighteous {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 53595}
+	hpMetrics := prot.NewHolyPowerMetrics(actionID)
+
+	hammerOfTheRighteousAoe := prot.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 88263},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellDamage,
+		Flags:          core.SpellFlagMeleeMetrics,
+		ClassSpellMask: paladin.SpellMaskHammerOfTheRighteous,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   prot.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := sim.Roll(729, 907) + 0.18*spell.MeleeAttackPower()
+			baseDamage *= sim.Encounter.AOECapMultiplier()
+
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
+			}
+		},
+	})
+
+	prot.HammerOfTheRighteous = prot.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskMeleeMHSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
+		ClassSpellMask: paladin.SpellMaskHammerOfTheRighteous,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.12,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+			// Hammer of the Righteous shares its cooldown with Crusader Strike.
+			CD: prot.CrusaderStrike.CD,
+		},
+
+		DamageMultiplier: 0.3,
+		CritMultiplier:   prot.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := spell.Unit.MHNormalizedWeaponDamage(sim, spell.MeleeAttackPower())
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+
+			if result.Landed() {
+				hammerOfTheRighteousAoe.Cast(sim, target)
+				prot.GainHolyPower(sim, 1, hpMetrics)
+			} else {
+				spell.IssueRefund(sim)
+			}
+		},
+	})
+}
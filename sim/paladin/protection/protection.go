@@ -1,6 +1,8 @@
 package protection
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -28,26 +30,16 @@ func NewProtectionPaladin(character *core.Character, options *proto.Player) *Pro
 	protOptions := options.GetProtectionPaladin()
 
 	prot := &ProtectionPaladin{
-		Paladin: paladin.NewPaladin(character, options.TalentsString),
+		Paladin: paladin.NewPaladin(character, options.TalentsString, protOptions.Options.ClassOptions),
 		Options: protOptions.Options,
-		Seal:    protOptions.Options.ClassOptions.Seal,
 	}
 
-	// prot.PaladinAura = protOptions.Options.ClassOptions.Aura
-
-	// //prot.HasGlyphAS = prot.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfAvengerSShield)
-
-	// prot.EnableAutoAttacks(prot, core.AutoAttackOptions{
-	// 	MainHand:       prot.WeaponFromMainHand(0), // Set crit multiplier later when we have targets.
-	// 	AutoSwingMelee: true,
-	// })
+	prot.EnableAutoAttacks(prot, core.AutoAttackOptions{
+		MainHand:       prot.WeaponFromMainHand(prot.DefaultMeleeCritMultiplier()),
+		AutoSwingMelee: true,
+	})
 
-	// healingModel := options.HealingModel
-	// if healingModel != nil {
-	// 	if healingModel.InspirationUptime > 0.0 {
-	// 		core.ApplyInspiration(prot.GetCharacter(), healingModel.InspirationUptime)
-	// 	}
-	// }
+	prot.RegisterGuardianOfAncientKings = prot.registerGuardianOfAncientKings
 
 	return prot
 }
@@ -57,11 +49,15 @@ type ProtectionPaladin struct {
 
 	Options *proto.ProtectionPaladin_Options
 
-	Judgement proto.PaladinJudgement
+	core.VengeanceTracker
 
-	Seal proto.PaladinSeal
+	AvengersShield       *core.Spell
+	HammerOfTheRighteous *core.Spell
+	ShieldOfTheRighteous *core.Spell
 
-	HasGlyphAS bool
+	GrandCrusaderAura *core.Aura
+	RighteousFuryAura *core.Aura
+	SacredDutyAura    *core.Aura
 }
 
 func (prot *ProtectionPaladin) GetPaladin() *paladin.Paladin {
@@ -70,11 +66,15 @@ func (prot *ProtectionPaladin) GetPaladin() *paladin.Paladin {
 
 func (prot *ProtectionPaladin) Initialize() {
 	prot.Paladin.Initialize()
-	// prot.ActivateRighteousFury()
+	prot.RegisterSpecializationEffects()
 
-	// if prot.Options.ClassOptions.UseAvengingWrath {
-	// 	prot.RegisterAvengingWrathCD()
-	// }
+	prot.registerAvengersShield()
+	prot.registerHammerOfTheRighteous()
+	prot.registerShieldOfTheRighteous()
+	prot.registerRighteousFury()
+
+	prot.applyGrandCrusader()
+	prot.applySacredDuty()
 }
 
 func (prot *ProtectionPaladin) ApplyTalents() {
@@ -84,19 +84,139 @@ func (prot *ProtectionPaladin) ApplyTalents() {
 
 func (prot *ProtectionPaladin) Reset(sim *core.Simulation) {
 	prot.Paladin.Reset(sim)
+}
+
+func (prot *ProtectionPaladin) RegisterSpecializationEffects() {
+	// Divine Bulwark
+	prot.RegisterMastery()
+
+	// Touched by the Light (53592)
+	prot.AddStatDependency(stats.Strength, stats.SpellPower, 0.6)
+	prot.MultiplyStat(stats.Stamina, 1.15)
+
+	// Vengeance
+	core.ApplyVengeanceEffect(prot.GetCharacter(), &prot.VengeanceTracker, 84839)
+
+	// Judgements of the Wise (31878)
+	prot.registerJudgementsOfTheWise()
+}
+
+func CalcMasteryPercent(points float64) float64 {
+	return 18.0 + 2.25*points
+}
+
+func (prot *ProtectionPaladin) RegisterMastery() {
+	// Divine Bulwark grants a flat amount of block chance that scales with mastery.
+	prot.AddStat(stats.Block, CalcMasteryPercent(prot.GetMasteryPoints())*core.BlockRatingPerBlockChance)
+
+	// and keep it updated when mastery changes
+	prot.AddOnMasteryStatChanged(func(sim *core.Simulation, oldMastery, newMastery float64) {
+		oldBlockRating := (2.25 * core.MasteryRatingToMasteryPoints(oldMastery)) * core.BlockRatingPerBlockChance
+		newBlockRating := (2.25 * core.MasteryRatingToMasteryPoints(newMastery)) * core.BlockRatingPerBlockChance
+
+		prot.AddStatDynamic(sim, stats.Block, -oldBlockRating+newBlockRating)
+	})
+}
+
+func (prot *ProtectionPaladin) registerJudgementsOfTheWise() {
+	actionID := core.ActionID{SpellID: 31930}
+	manaMetrics := prot.NewManaMetrics(actionID)
+	var manaPA *core.PendingAction
+
+	jotwAura := prot.RegisterAura(core.Aura{
+		Label:    "Judgements of the Wise",
+		ActionID: actionID,
+		Duration: time.Second*10 + 1, // Add 1 to make sure the last tick takes effect
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			manaPA = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period: time.Second * 2,
+				OnAction: func(sim *core.Simulation) {
+					prot.AddMana(sim, 0.03*prot.BaseMana, manaMetrics)
+				},
+			})
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			manaPA.Cancel(sim)
+		},
+	})
 
-	switch prot.Seal {
-	case proto.PaladinSeal_Vengeance:
-		prot.CurrentSeal = prot.SealOfVengeanceAura
-		prot.SealOfVengeanceAura.Activate(sim)
-	case proto.PaladinSeal_Command:
-		prot.CurrentSeal = prot.SealOfCommandAura
-		prot.SealOfCommandAura.Activate(sim)
-	case proto.PaladinSeal_Righteousness:
-		prot.CurrentSeal = prot.SealOfRighteousnessAura
-		prot.SealOfRighteousnessAura.Activate(sim)
+	prot.OnJudgementLanded = append(prot.OnJudgementLanded, func(sim *core.Simulation, _ *core.Unit) {
+		jotwAura.Deactivate(sim)
+		jotwAura.Activate(sim)
+	})
+}
+
+func (prot *ProtectionPaladin) registerRighteousFury() {
+	prot.RighteousFuryAura = core.MakePermanent(prot.RegisterAura(core.Aura{
+		Label:    "Righteous Fury",
+		ActionID: core.ActionID{SpellID: 25780},
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.ThreatMultiplier *= 3
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			aura.Unit.PseudoStats.ThreatMultiplier /= 3
+		},
+	}))
+}
+
+func (prot *ProtectionPaladin) applyGrandCrusader() {
+	if prot.Talents.GrandCrusader == 0 {
+		return
 	}
 
-	prot.RighteousFuryAura.Activate(sim)
-	prot.Paladin.PseudoStats.Stunned = false
+	prot.GrandCrusaderAura = prot.RegisterAura(core.Aura{
+		Label:    "Grand Crusader",
+		ActionID: core.ActionID{SpellID: 85416},
+		Duration: time.Second * 6,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			prot.AvengersShield.CD.Reset()
+		},
+	})
+
+	core.MakeProcTriggerAura(&prot.Unit, core.ProcTrigger{
+		Name:           "Grand Crusader Trigger",
+		ActionID:       core.ActionID{SpellID: 85043},
+		Callback:       core.CallbackOnSpellHitDealt,
+		ClassSpellMask: paladin.SpellMaskCrusaderStrike | paladin.SpellMaskHammerOfTheRighteous,
+		Outcome:        core.OutcomeLanded,
+		ProcChance:     0.1 * float64(prot.Talents.GrandCrusader),
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			prot.GrandCrusaderAura.Activate(sim)
+		},
+	})
+}
+
+func (prot *ProtectionPaladin) applySacredDuty() {
+	if prot.Talents.SacredDuty == 0 {
+		return
+	}
+
+	critMod := prot.AddDynamicMod(core.SpellModConfig{
+		ClassMask:  paladin.SpellMaskShieldOfTheRighteous,
+		Kind:       core.SpellMod_BonusCrit_Rating,
+		FloatValue: 100 * core.CritRatingPerCritChance,
+	})
+
+	prot.SacredDutyAura = prot.RegisterAura(core.Aura{
+		Label:    "Sacred Duty",
+		ActionID: core.ActionID{SpellID: 85433},
+		Duration: time.Second * 10,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			critMod.Activate()
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			critMod.Deactivate()
+		},
+	})
+
+	core.MakeProcTriggerAura(&prot.Unit, core.ProcTrigger{
+		Name:           "Sacred Duty Trigger",
+		ActionID:       core.ActionID{SpellID: 53710},
+		Callback:       core.CallbackOnCastComplete,
+		ClassSpellMask: paladin.SpellMaskJudgement | paladin.SpellMaskAvengersShield,
+		ProcChance:     0.25 * float64(prot.Talents.SacredDuty),
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			prot.SacredDutyAura.Activate(sim)
+		},
+	})
 }
@@ -0,0 +1,52 @@
+package protection
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (prot *ProtectionPaladin) registerShieldOfTheRighteous() {
+	if !prot.Talents.ShieldOfTheRighteous {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: 53600}
+	hpMetrics := prot.NewHolyPowerMetrics(actionID)
+
+	prot.ShieldOfTheRighteous = prot.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskMeleeMHSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagAPL | paladin.SpellFlagHolyPowerSpender,
+		ClassSpellMask: paladin.SpellMaskShieldOfTheRighteous,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return prot.PseudoStats.CanBlock && prot.GetHolyPowerValue() > 0
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   prot.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			holyPowerMultiplier := []float64{0, 1, 3, 6}[prot.GetHolyPowerValue()]
+			baseDamage := (596 + 0.1*spell.MeleeAttackPower()) * holyPowerMultiplier
+
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
+			if result.Landed() {
+				prot.SpendHolyPower(sim, hpMetrics)
+			}
+
+			if prot.SacredDutyAura.IsActive() {
+				prot.SacredDutyAura.Deactivate(sim)
+			}
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package retribution
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+type AncientGuardianPet struct {
+	core.Pet
+
+	paladin *RetributionPaladin
+}
+
+func (ret *RetributionPaladin) NewAncientGuardian() *AncientGuardianPet {
+	ancientGuardian := &AncientGuardianPet{
+		Pet:     core.NewPet("Ancient Guardian", &ret.Character, stats.Stats{}, ret.ancientGuardianStatInheritance(), false, true),
+		paladin: ret,
+	}
+
+	ancientGuardian.EnableAutoAttacks(ancientGuardian, core.AutoAttackOptions{
+		MainHand: core.Weapon{
+			BaseDamageMin:  5500,
+			BaseDamageMax:  7000,
+			SwingSpeed:     2,
+			CritMultiplier: ret.DefaultMeleeCritMultiplier(),
+			SpellSchool:    core.SpellSchoolPhysical,
+		},
+		AutoSwingMelee: true,
+	})
+
+	// Every landed guardian swing empowers the Paladin with a stack of Ancient Power.
+	core.MakePermanent(ancientGuardian.RegisterAura(core.Aura{
+		Label: "Ancient Power Trigger",
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			// Swings landing as the guardian departs no longer empower the Paladin.
+			if !result.Landed() || !spell.ProcMask.Matches(core.ProcMaskMeleeMHAuto) || !ret.AncientPowerAura.IsActive() {
+				return
+			}
+			ret.AncientPowerAura.AddStack(sim)
+		},
+	}))
+
+	ret.AddPet(ancientGuardian)
+
+	return ancientGuardian
+}
+
+func (ret *RetributionPaladin) ancientGuardianStatInheritance() core.PetStatInheritance {
+	return func(ownerStats stats.Stats) stats.Stats {
+		return stats.Stats{
+			stats.MeleeHit:  ownerStats[stats.MeleeHit],
+			stats.Expertise: ownerStats[stats.Expertise],
+			stats.MeleeCrit: ownerStats[stats.MeleeCrit],
+		}
+	}
+}
+
+func (ancientGuardian *AncientGuardianPet) GetPet() *core.Pet {
+	return &ancientGuardian.Pet
+}
+
+func (ancientGuardian *AncientGuardianPet) Initialize() {
+}
+
+func (ancientGuardian *AncientGuardianPet) Reset(_ *core.Simulation) {
+}
+
+func (ancientGuardian *AncientGuardianPet) ExecuteCustomRotation(_ *core.Simulation) {
+}
@@ -0,0 +1,98 @@
+package retribution
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/stats"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (ret *RetributionPaladin) registerGuardianOfAncientKings() {
+	actionID := core.ActionID{SpellID: 86150}
+	duration := time.Second * 30
+
+	// Each stack of Ancient Power increases Strength by 1%.
+	strDeps := make([]*stats.StatDependency, 20)
+	for i := 1; i <= 20; i++ {
+		strDeps[i-1] = ret.NewDynamicMultiplyStat(stats.Strength, 1+0.01*float64(i))
+	}
+
+	ancientFury := ret.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 86704},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskSpellDamage,
+		Flags:       core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   ret.DefaultSpellCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			// Damage per stack of Ancient Power is split evenly among all enemies.
+			stacks := float64(ret.AncientPowerAura.GetStacks())
+			numTargets := float64(len(sim.Encounter.ActiveTargetUnits))
+			baseDamage := (229 + 0.1*spell.MeleeAttackPower()) * stacks / numTargets
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
+				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
+			}
+		},
+	})
+
+	ret.AncientPowerAura = ret.RegisterAura(core.Aura{
+		Label:     "Ancient Power",
+		ActionID:  core.ActionID{SpellID: 86700},
+		Duration:  core.NeverExpires,
+		MaxStacks: 20,
+		OnStacksChange: func(aura *core.Aura, sim *core.Simulation, oldStacks, newStacks int32) {
+			if oldStacks > 0 {
+				ret.DisableDynamicStatDep(sim, strDeps[oldStacks-1])
+			}
+			if newStacks > 0 {
+				ret.EnableDynamicStatDep(sim, strDeps[newStacks-1])
+			}
+		},
+	})
+
+	ret.GuardianOfAncientKingsAura = ret.RegisterAura(core.Aura{
+		Label:    "Guardian of Ancient Kings",
+		ActionID: actionID,
+		Duration: duration,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			ret.AncientPowerAura.Activate(sim)
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			// Ancient Fury is released when the guardian departs, but not when the fight ends.
+			if sim.GetRemainingDuration() > 0 {
+				ancientFury.Cast(sim, ret.CurrentTarget)
+			}
+			ret.AncientPowerAura.Deactivate(sim)
+		},
+	})
+
+	ret.GuardianOfAncientKings = ret.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		Flags:          core.SpellFlagAPL | core.SpellFlagMCD,
+		ClassSpellMask: paladin.SpellMaskGuardianOfAncientKings,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    ret.NewTimer(),
+				Duration: time.Minute * 5,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, _ *core.Spell) {
+			ret.AncientGuardian.EnableWithTimeout(sim, ret.AncientGuardian, duration)
+			ret.GuardianOfAncientKingsAura.Activate(sim)
+		},
+	})
+
+	ret.AddMajorCooldown(core.MajorCooldown{
+		Spell: ret.GuardianOfAncientKings,
+		Type:  core.CooldownTypeDPS,
+	})
+}
@@ -1,6 +1,8 @@
 package retribution
 
 import (
+	"time"
+
 	"github.com/wowsims/cata/sim/core"
 	"github.com/wowsims/cata/sim/core/proto"
 	"github.com/wowsims/cata/sim/core/stats"
@@ -27,27 +29,29 @@ func RegisterRetributionPaladin() {
 func NewRetributionPaladin(character *core.Character, options *proto.Player) *RetributionPaladin {
 	retOptions := options.GetRetributionPaladin()
 
-	pal := paladin.NewPaladin(character, options.TalentsString)
-
 	ret := &RetributionPaladin{
-		Paladin: pal,
-		Seal:    retOptions.Options.ClassOptions.Seal,
+		Paladin: paladin.NewPaladin(character, options.TalentsString, retOptions.Options.ClassOptions),
 	}
 
-	ret.PaladinAura = retOptions.Options.ClassOptions.Aura
-
 	ret.EnableAutoAttacks(ret, core.AutoAttackOptions{
-		MainHand:       ret.WeaponFromMainHand(0), // Set crit multiplier later when we have targets.
+		MainHand:       ret.WeaponFromMainHand(ret.DefaultMeleeCritMultiplier()),
 		AutoSwingMelee: true,
 	})
 
+	ret.AncientGuardian = ret.NewAncientGuardian()
+	ret.RegisterGuardianOfAncientKings = ret.registerGuardianOfAncientKings
+
 	return ret
 }
 
 type RetributionPaladin struct {
 	*paladin.Paladin
 
-	Seal proto.PaladinSeal
+	AncientGuardian *AncientGuardianPet
+
+	TemplarsVerdict *core.Spell
+
+	AncientPowerAura *core.Aura
 }
 
 func (ret *RetributionPaladin) GetPaladin() *paladin.Paladin {
@@ -56,7 +60,8 @@ func (ret *RetributionPaladin) GetPaladin() *paladin.Paladin {
 
 func (ret *RetributionPaladin) Initialize() {
 	ret.Paladin.Initialize()
-	//ret.RegisterAvengingWrathCD()
+	ret.RegisterSpecializationEffects()
+	ret.registerTemplarsVerdict()
 }
 
 func (ret *RetributionPaladin) ApplyTalents() {
@@ -66,16 +71,76 @@ func (ret *RetributionPaladin) ApplyTalents() {
 
 func (ret *RetributionPaladin) Reset(sim *core.Simulation) {
 	ret.Paladin.Reset(sim)
+}
+
+func (ret *RetributionPaladin) RegisterSpecializationEffects() {
+	// Hand of Light
+	ret.RegisterMastery()
+
+	// Sheath of Light (53503)
+	ret.AddStatDependency(stats.AttackPower, stats.SpellPower, 0.3)
+
+	// Two-Handed Weapon Specialization (85512)
+	if mh := ret.GetMHWeapon(); mh != nil && mh.HandType == proto.HandType_HandTypeTwoHand {
+		ret.PseudoStats.SchoolDamageDealtMultiplier[stats.SchoolIndexPhysical] *= 1.25
+	}
+
+	// Judgements of the Bold (89901)
+	ret.registerJudgementsOfTheBold()
+}
+
+func (ret *RetributionPaladin) GetMasteryBonus() float64 {
+	return (16.8 + 2.1*ret.GetMasteryPoints()) / 100
+}
+
+func (ret *RetributionPaladin) RegisterMastery() {
+	handOfLight := ret.RegisterSpell(core.SpellConfig{
+		ActionID:    core.ActionID{SpellID: 96172},
+		SpellSchool: core.SpellSchoolHoly,
+		ProcMask:    core.ProcMaskEmpty,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	core.MakeProcTriggerAura(&ret.Unit, core.ProcTrigger{
+		Name:           "Hand of Light",
+		ActionID:       core.ActionID{SpellID: 76672},
+		Callback:       core.CallbackOnSpellHitDealt,
+		ClassSpellMask: paladin.SpellMaskCrusaderStrike | paladin.SpellMaskTemplarsVerdict | paladin.SpellMaskDivineStorm,
+		Outcome:        core.OutcomeLanded,
+		Handler: func(sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			baseDamage := result.Damage * ret.GetMasteryBonus()
+			handOfLight.CalcAndDealDamage(sim, result.Target, baseDamage, handOfLight.OutcomeAlwaysHit)
+		},
+	})
+}
+
+func (ret *RetributionPaladin) registerJudgementsOfTheBold() {
+	actionID := core.ActionID{SpellID: 89906}
+	manaMetrics := ret.NewManaMetrics(actionID)
+	var manaPA *core.PendingAction
 
-	// switch ret.Seal {
-	// case proto.PaladinSeal_Vengeance:
-	// 	ret.CurrentSeal = ret.SealOfVengeanceAura
-	// 	ret.SealOfVengeanceAura.Activate(sim)
-	// case proto.PaladinSeal_Command:
-	// 	ret.CurrentSeal = ret.SealOfCommandAura
-	// 	ret.SealOfCommandAura.Activate(sim)
-	// case proto.PaladinSeal_Righteousness:
-	// 	ret.CurrentSeal = ret.SealOfRighteousnessAura
-	// 	ret.SealOfRighteousnessAura.Activate(sim)
-	// }
+	jotbAura := ret.RegisterAura(core.Aura{
+		Label:    "Judgements of the Bold",
+		ActionID: actionID,
+		Duration: time.Second*10 + 1, // Add 1 to make sure the last tick takes effect
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			manaPA = core.StartPeriodicAction(sim, core.PeriodicActionOptions{
+				Period: time.Second * 2,
+				OnAction: func(sim *core.Simulation) {
+					ret.AddMana(sim, 0.03*ret.BaseMana, manaMetrics)
+				},
+			})
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			manaPA.Cancel(sim)
+		},
+	})
+
+	ret.OnJudgementLanded = append(ret.OnJudgementLanded, func(sim *core.Simulation, _ *core.Unit) {
+		jotbAura.Deactivate(sim)
+		jotbAura.Activate(sim)
+	})
 }
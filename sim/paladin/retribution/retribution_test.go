@@ -1,131 +1,87 @@
 package retribution
 
 import (
+	"testing"
+
 	_ "github.com/wowsims/cata/sim/common" // imported to get item effects included.
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
 )
 
 func init() {
 	RegisterRetributionPaladin()
 }
 
-// func TestRetribution(t *testing.T) {
-// 	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
-// 		Class:      proto.Class_ClassPaladin,
-// 		Race:       proto.Race_RaceBloodElf,
-// 		OtherRaces: []proto.Race{proto.Race_RaceHuman, proto.Race_RaceDraenei, proto.Race_RaceDwarf},
+func TestRetribution(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		Class:      proto.Class_ClassPaladin,
+		Race:       proto.Race_RaceBloodElf,
+		OtherRaces: []proto.Race{proto.Race_RaceHuman, proto.Race_RaceDraenei, proto.Race_RaceDwarf},
 
-// 		GearSet:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p1"),
-// 		Talents:     StandardTalents,
-// 		Glyphs:      StandardGlyphs,
-// 		Consumes:    FullConsumes,
-// 		SpecOptions: core.SpecOptionsCombo{Label: "Retribution Paladin SOV", SpecOptions: DefaultOptions},
-// 		OtherSpecOptions: []core.SpecOptionsCombo{
-// 			{
-// 				Label: "Retribution Paladin SOC",
-// 				SpecOptions: &proto.Player_RetributionPaladin{
-// 					RetributionPaladin: &proto.RetributionPaladin{
-// 						Options: &proto.RetributionPaladin_Options{
-// 							Judgement: proto.PaladinJudgement_JudgementOfWisdom,
-// 							Seal:      proto.PaladinSeal_Command,
-// 							Aura:      proto.PaladinAura_RetributionAura,
-// 						},
-// 					},
-// 				},
-// 			},
-// 			{
-// 				Label: "Retribution Paladin SOR",
-// 				SpecOptions: &proto.Player_RetributionPaladin{
-// 					RetributionPaladin: &proto.RetributionPaladin{
-// 						Options: &proto.RetributionPaladin_Options{
-// 							Judgement: proto.PaladinJudgement_JudgementOfWisdom,
-// 							Seal:      proto.PaladinSeal_Righteousness,
-// 							Aura:      proto.PaladinAura_RetributionAura,
-// 						},
-// 					},
-// 				},
-// 			},
-// 			{
-// 				Label: "Retribution Paladin SOV 2 Target Swapping",
-// 				SpecOptions: &proto.Player_RetributionPaladin{
-// 					RetributionPaladin: &proto.RetributionPaladin{
-// 						Options: &proto.RetributionPaladin_Options{
-// 							Judgement: proto.PaladinJudgement_JudgementOfWisdom,
-// 							Seal:      proto.PaladinSeal_Vengeance,
-// 							Aura:      proto.PaladinAura_RetributionAura,
-// 						},
-// 					},
-// 				},
-// 			},
-// 		},
-// 		Rotation: core.GetAplRotation("../../../ui/retribution_paladin/apls", "default"),
+		GearSet:  core.GetGearSet("../../../ui/paladin/retribution/gear_sets", "p1_bis"),
+		Talents:  StandardTalents,
+		Glyphs:   StandardGlyphs,
+		Consumes: FullConsumes,
 
-// 		ItemFilter: core.ItemFilter{
-// 			WeaponTypes: []proto.WeaponType{
-// 				proto.WeaponType_WeaponTypeAxe,
-// 				proto.WeaponType_WeaponTypeSword,
-// 				proto.WeaponType_WeaponTypePolearm,
-// 				proto.WeaponType_WeaponTypeMace,
-// 			},
-// 			ArmorType: proto.ArmorType_ArmorTypePlate,
-// 			RangedWeaponTypes: []proto.RangedWeaponType{
-// 				proto.RangedWeaponType_RangedWeaponTypeRelic,
-// 			},
-// 		},
-// 	}))
-// }
+		SpecOptions: core.SpecOptionsCombo{Label: "Seal of Truth", SpecOptions: DefaultOptions},
+		OtherSpecOptions: []core.SpecOptionsCombo{
+			{Label: "Seal of Righteousness", SpecOptions: SealOfRighteousnessOptions},
+		},
+		Rotation: core.GetAplRotation("../../../ui/paladin/retribution/apls", "default"),
 
-// func BenchmarkSimulate(b *testing.B) {
-// 	rsr := &proto.RaidSimRequest{
-// 		Raid: core.SinglePlayerRaidProto(
-// 			&proto.Player{
-// 				Race:          proto.Race_RaceBloodElf,
-// 				Class:         proto.Class_ClassPaladin,
-// 				TalentsString: StandardTalents,
-// 				Glyphs:        StandardGlyphs,
-// 				Equipment:     core.GetGearSet("../../../ui/retribution_paladin/gear_sets", "p1").GearSet,
-// 				Consumes:      FullConsumes,
-// 				Spec:          DefaultOptions,
-// 				Buffs:         core.FullIndividualBuffs,
-// 			},
-// 			core.FullPartyBuffs,
-// 			core.FullRaidBuffs,
-// 			core.FullDebuffs),
-// 		Encounter: &proto.Encounter{
-// 			Duration: 300,
-// 			Targets: []*proto.Target{
-// 				core.NewDefaultTarget(),
-// 			},
-// 		},
-// 		SimOptions: core.AverageDefaultSimTestOptions,
-// 	}
+		ItemFilter: ItemFilter,
+	}))
+}
 
-// 	core.RaidBenchmark(b, rsr)
-// }
+var StandardTalents = "203002-02-23203213211113002311"
+var StandardGlyphs = &proto.Glyphs{
+	Prime1: int32(proto.PaladinPrimeGlyph_GlyphOfSealOfTruth),
+	Prime2: int32(proto.PaladinPrimeGlyph_GlyphOfExorcism),
+	Prime3: int32(proto.PaladinPrimeGlyph_GlyphOfTemplarSVerdict),
+	Major1: int32(proto.PaladinMajorGlyph_GlyphOfTheAsceticCrusader),
+	Major2: int32(proto.PaladinMajorGlyph_GlyphOfHammerOfWrath),
+	Major3: int32(proto.PaladinMajorGlyph_GlyphOfConsecration),
+}
 
-// var StandardTalents = "050501-05-05232051203331302133231331"
-// var StandardGlyphs = &proto.Glyphs{
-// 	Major1: int32(proto.PaladinMajorGlyph_GlyphOfSealOfVengeance),
-// 	Major2: int32(proto.PaladinMajorGlyph_GlyphOfJudgement),
-// 	Major3: int32(proto.PaladinMajorGlyph_GlyphOfConsecration),
-// 	Minor1: int32(proto.PaladinMinorGlyph_GlyphOfSenseUndead),
-// 	Minor2: int32(proto.PaladinMinorGlyph_GlyphOfLayOnHands),
-// 	Minor3: int32(proto.PaladinMinorGlyph_GlyphOfBlessingOfKings),
-// }
+var DefaultOptions = &proto.Player_RetributionPaladin{
+	RetributionPaladin: &proto.RetributionPaladin{
+		Options: &proto.RetributionPaladin_Options{
+			ClassOptions: &proto.PaladinOptions{
+				Seal: proto.PaladinSeal_Truth,
+				Aura: proto.PaladinAura_RetributionAura,
+			},
+		},
+	},
+}
 
-// var DefaultOptions = &proto.Player_RetributionPaladin{
-// 	RetributionPaladin: &proto.RetributionPaladin{
-// 		Options: &proto.RetributionPaladin_Options{
-// 			Judgement: proto.PaladinJudgement_JudgementOfWisdom,
-// 			Seal:      proto.PaladinSeal_Vengeance,
-// 			Aura:      proto.PaladinAura_RetributionAura,
-// 		},
-// 	},
-// }
+var SealOfRighteousnessOptions = &proto.Player_RetributionPaladin{
+	RetributionPaladin: &proto.RetributionPaladin{
+		Options: &proto.RetributionPaladin_Options{
+			ClassOptions: &proto.PaladinOptions{
+				Seal: proto.PaladinSeal_Righteousness,
+				Aura: proto.PaladinAura_RetributionAura,
+			},
+		},
+	},
+}
 
-// var FullConsumes = &proto.Consumes{
-// 	Flask:           proto.Flask_FlaskOfRelentlessAssault,
-// 	DefaultPotion:   proto.Potions_HastePotion,
-// 	DefaultConjured: proto.Conjured_ConjuredDarkRune,
-// 	Food:            proto.Food_FoodRoastedClefthoof,
-// 	ThermalSapper:   true,
-// }
+var FullConsumes = &proto.Consumes{
+	Flask:         proto.Flask_FlaskOfTitanicStrength,
+	DefaultPotion: proto.Potions_GolembloodPotion,
+	PrepopPotion:  proto.Potions_GolembloodPotion,
+	Food:          proto.Food_FoodBeerBasedCrocolisk,
+}
+
+var ItemFilter = core.ItemFilter{
+	ArmorType: proto.ArmorType_ArmorTypePlate,
+
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeAxe,
+		proto.WeaponType_WeaponTypeSword,
+		proto.WeaponType_WeaponTypePolearm,
+		proto.WeaponType_WeaponTypeMace,
+	},
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeRelic,
+	},
+}
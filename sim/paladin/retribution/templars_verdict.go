@@ -0,0 +1,44 @@
+package retribution
+
+import (
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/paladin"
+)
+
+func (ret *RetributionPaladin) registerTemplarsVerdict() {
+	actionID := core.ActionID{SpellID: 85256}
+	hpMetrics := ret.NewHolyPowerMetrics(actionID)
+
+	ret.TemplarsVerdict = ret.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolPhysical,
+		ProcMask:       core.ProcMaskMeleeMHSpecial,
+		Flags:          core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL | paladin.SpellFlagHolyPowerSpender,
+		ClassSpellMask: paladin.SpellMaskTemplarsVerdict,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			IgnoreHaste: true,
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return ret.GetHolyPowerValue() > 0
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   ret.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			weaponDamageMultiplier := []float64{0, 0.3, 0.9, 2.35}[ret.GetHolyPowerValue()]
+			baseDamage := weaponDamageMultiplier * spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
+
+			result := spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+			if result.Landed() {
+				ret.SpendHolyPower(sim, hpMetrics)
+			}
+		},
+	})
+}
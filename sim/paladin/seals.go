@@ -0,0 +1,243 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/cata/sim/core"
+	"github.com/wowsims/cata/sim/core/proto"
+	"github.com/wowsims/cata/sim/core/stats"
+)
+
+const SealDuration = time.Minute * 30
+
+// Swaps the active seal. Only one seal can be active at a time.
+func (paladin *Paladin) activateSeal(sim *core.Simulation, seal *core.Aura) {
+	if paladin.CurrentSeal != nil {
+		paladin.CurrentSeal.Deactivate(sim)
+	}
+	paladin.CurrentSeal = seal
+	paladin.CurrentSeal.Activate(sim)
+}
+
+func (paladin *Paladin) registerSealSpell(actionID core.ActionID, classMask int64, seal *core.Aura) *core.Spell {
+	return paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		Flags:          core.SpellFlagAPL,
+		ClassSpellMask: classMask,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.14,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.CurrentSeal != seal
+		},
+
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			paladin.activateSeal(sim, seal)
+		},
+	})
+}
+
+func (paladin *Paladin) registerSealOfTruth() {
+	/*
+	 * Seal of Truth applies Censure on every landed main-hand melee attack, stacking up to 5 times.
+	 * Once Censure is on the target, every melee hit also deals 3% weapon damage per stack as Holy damage.
+	 */
+	paladin.Censure = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 31803},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskProc,
+		Flags:          core.SpellFlagMeleeMetrics,
+		ClassSpellMask: SpellMaskCensure,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		Dot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Censure",
+				MaxStacks: 5,
+			},
+			NumberOfTicks: 5,
+			TickLength:    time.Second * 3,
+
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
+				tickValue := 0.01*dot.Spell.SpellPower() + 0.0193*dot.Spell.MeleeAttackPower()
+				dot.Snapshot(target, tickValue*float64(dot.GetStacks()))
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeSnapshotCrit)
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			dot := spell.Dot(target)
+			if !dot.IsActive() {
+				dot.Apply(sim)
+				dot.SetStacks(sim, 1)
+			} else {
+				dot.Refresh(sim)
+				dot.AddStack(sim)
+			}
+			dot.TakeSnapshot(sim, false)
+		},
+	})
+
+	onSpecialOrSwingProc := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 42463},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagMeleeMetrics,
+		ClassSpellMask: SpellMaskSealOfTruth,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			stacks := float64(paladin.Censure.Dot(target).GetStacks())
+			baseDamage := 0.03 * stacks * spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
+			spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMeleeSpecialCritOnly)
+		},
+	})
+
+	var expertise float64
+	if paladin.HasPrimeGlyph(proto.PaladinPrimeGlyph_GlyphOfSealOfTruth) {
+		expertise = 10 * core.ExpertisePerQuarterPercentReduction
+	}
+
+	paladin.SealOfTruthAura = paladin.RegisterAura(core.Aura{
+		Label:    "Seal of Truth",
+		Tag:      "Seal",
+		ActionID: core.ActionID{SpellID: 31801},
+		Duration: SealDuration,
+
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			if expertise > 0 {
+				paladin.AddStatDynamic(sim, stats.Expertise, expertise)
+			}
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			if expertise > 0 {
+				paladin.AddStatDynamic(sim, stats.Expertise, -expertise)
+			}
+		},
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.Landed() || !spell.ProcMask.Matches(core.ProcMaskMeleeMH) {
+				return
+			}
+
+			if paladin.Censure.Dot(result.Target).IsActive() {
+				onSpecialOrSwingProc.Cast(sim, result.Target)
+			}
+			paladin.Censure.Cast(sim, result.Target)
+		},
+	})
+
+	paladin.SealOfTruth = paladin.registerSealSpell(core.ActionID{SpellID: 31801}, SpellMaskSealOfTruth, paladin.SealOfTruthAura)
+}
+
+func (paladin *Paladin) registerSealOfRighteousness() {
+	/*
+	 * Seal of Righteousness deals hybrid AP/SP Holy damage scaled by weapon speed on every main-hand melee hit.
+	 * With Seals of Command it also hits up to 2 additional nearby enemies.
+	 */
+	numTargets := 1
+	if paladin.Talents.SealsOfCommand {
+		numTargets = 3
+	}
+
+	onSpecialOrSwingProc := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 25742},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagMeleeMetrics,
+		ClassSpellMask: SpellMaskSealOfRighteousness,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultMeleeCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseDamage := paladin.AutoAttacks.MH().SwingSpeed * (0.011*spell.MeleeAttackPower() + 0.022*spell.SpellPower())
+
+			curTarget := target
+			for hitIndex := 0; hitIndex < min(numTargets, len(sim.Encounter.ActiveTargetUnits)); hitIndex++ {
+				spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeSpecialCritOnly)
+				curTarget = sim.Environment.NextTargetUnit(curTarget)
+			}
+		},
+	})
+
+	paladin.SealOfRighteousnessAura = paladin.RegisterAura(core.Aura{
+		Label:    "Seal of Righteousness",
+		Tag:      "Seal",
+		ActionID: core.ActionID{SpellID: 20154},
+		Duration: SealDuration,
+
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.Landed() || !spell.ProcMask.Matches(core.ProcMaskMeleeMH) {
+				return
+			}
+
+			onSpecialOrSwingProc.Cast(sim, result.Target)
+		},
+	})
+
+	paladin.SealOfRighteousness = paladin.registerSealSpell(core.ActionID{SpellID: 20154}, SpellMaskSealOfRighteousness, paladin.SealOfRighteousnessAura)
+}
+
+func (paladin *Paladin) registerSealOfInsight() {
+	/*
+	 * Seal of Insight has a 15 PPM chance on main-hand melee hits to heal the Paladin
+	 * and restore 4% of base mana.
+	 */
+	manaMetrics := paladin.NewManaMetrics(core.ActionID{SpellID: 20167})
+
+	onSpecialOrSwingProc := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 20167},
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful,
+		ClassSpellMask: SpellMaskSealOfInsight,
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			baseHealing := 0.15*spell.MeleeAttackPower() + 0.15*spell.HealingPower(target)
+			spell.CalcAndDealHealing(sim, &paladin.Unit, baseHealing, spell.OutcomeHealing)
+			paladin.AddMana(sim, 0.04*paladin.BaseMana, manaMetrics)
+		},
+	})
+
+	var ppmm core.PPMManager
+	paladin.SealOfInsightAura = paladin.RegisterAura(core.Aura{
+		Label:    "Seal of Insight",
+		Tag:      "Seal",
+		ActionID: core.ActionID{SpellID: 20165},
+		Duration: SealDuration,
+
+		OnInit: func(aura *core.Aura, sim *core.Simulation) {
+			ppmm = paladin.AutoAttacks.NewPPMManager(15, core.ProcMaskMeleeMH)
+		},
+		OnSpellHitDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.Landed() || !ppmm.Proc(sim, spell.ProcMask, "Seal of Insight") {
+				return
+			}
+
+			onSpecialOrSwingProc.Cast(sim, &paladin.Unit)
+		},
+	})
+
+	paladin.SealOfInsight = paladin.registerSealSpell(core.ActionID{SpellID: 20165}, SpellMaskSealOfInsight, paladin.SealOfInsightAura)
+}
@@ -0,0 +1,51 @@
+package paladin
+
+import (
+	"github.com/wowsims/cata/sim/core"
+)
+
+func (paladin *Paladin) registerWordOfGlory() {
+	actionID := core.ActionID{SpellID: 85673}
+	hpMetrics := paladin.NewHolyPowerMetrics(actionID)
+	eternalGloryMetrics := paladin.NewHolyPowerMetrics(core.ActionID{SpellID: 88676})
+
+	paladin.WordOfGlory = paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL | SpellFlagHolyPowerSpender,
+		ClassSpellMask: SpellMaskWordOfGlory,
+
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ExtraCastCondition: func(sim *core.Simulation, target *core.Unit) bool {
+			return paladin.GetHolyPowerValue() > 0
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   paladin.DefaultHealingCritMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			if target.IsOpponent(&paladin.Unit) {
+				target = &paladin.Unit
+			}
+
+			holyPower := paladin.GetHolyPowerValue()
+			baseHealing := (sim.Roll(2133, 2363) + 0.198*max(spell.HealingPower(target), spell.MeleeAttackPower())) * float64(holyPower)
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealingCrit)
+
+			spent := core.TernaryInt32(paladin.DivinePurposeAura.IsActive(), 0, paladin.CurrentHolyPower())
+			paladin.SpendHolyPower(sim, hpMetrics)
+
+			// Eternal Glory has a chance to refund the Holy Power that was consumed.
+			if spent > 0 && paladin.Talents.EternalGlory > 0 && sim.Proc(0.15*float64(paladin.Talents.EternalGlory), "Eternal Glory") {
+				paladin.GainHolyPower(sim, spent, eternalGloryMetrics)
+			}
+		},
+	})
+}
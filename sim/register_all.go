@@ -9,6 +9,7 @@ import (
 
 	"github.com/wowsims/cata/sim/druid/feral"
 	restoDruid "github.com/wowsims/cata/sim/druid/restoration"
+	feralTank "github.com/wowsims/cata/sim/druid/tank"
 	_ "github.com/wowsims/cata/sim/encounters"
 	"github.com/wowsims/cata/sim/hunter/beast_mastery"
 	"github.com/wowsims/cata/sim/hunter/marksmanship"
@@ -50,7 +51,7 @@ func RegisterAll() {
 
 	balance.RegisterBalanceDruid()
 	feral.RegisterFeralDruid()
-	// feralTank.RegisterFeralTankDruid()
+	feralTank.RegisterFeralTankDruid()
 	restoDruid.RegisterRestorationDruid()
 
 	beast_mastery.RegisterBeastMasteryHunter()
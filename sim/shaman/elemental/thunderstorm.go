@@ -44,7 +44,7 @@ func (elemental *ElementalShaman) registerThunderstormSpell() {
 			elemental.AddMana(sim, elemental.MaxMana()*manaRestore, manaMetrics)
 
 			if elemental.Shaman.ThunderstormInRange {
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					baseDamage := elemental.GetShaman().ClassSpellScaling * 1.62999999523 * sim.Encounter.AOECapMultiplier()
 					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 				}
@@ -43,7 +43,7 @@ func (warlock *Warlock) registerSummonInfernalSpell(timer *core.Timer) {
 		CritMultiplier:   warlock.DefaultSpellCritMultiplier(),
 		BonusCoefficient: 0.765,
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := sim.Encounter.AOECapMultiplier() *
 					warlock.CalcAndRollDamageRange(sim, Coefficient_Infernal, Variance_Infernal)
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
@@ -150,7 +150,7 @@ func (infernal *InfernalPet) Initialize() {
 				warlockSP := infernal.owner.Unit.GetStat(stats.SpellPower)
 				baseDmg := (40 + warlockSP*0.2) * sim.Encounter.AOECapMultiplier()
 
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.CalcAndDealDamage(sim, aoeTarget, baseDmg, dot.Spell.OutcomeMagicHit)
 				}
 			},
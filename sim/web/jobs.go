@@ -0,0 +1,386 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/google/uuid"
+	"github.com/wowsims/cata/sim/core"
+	proto "github.com/wowsims/cata/sim/core/proto"
+
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const jobFileExt = ".binpb"
+
+// Runs sims submitted through the /jobs API, with a limit on how many run at
+// once. When dir is set each job is saved there, so results survive restarts
+// and jobs which hadn't finished are queued again on startup.
+type jobManager struct {
+	dir     string
+	workers chan struct{}
+
+	mut  sync.RWMutex
+	jobs map[string]*simJob
+}
+
+type simJob struct {
+	mut sync.Mutex
+	job *proto.SimJob
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newJobManager(dir string, numWorkers int) (*jobManager, error) {
+	m := &jobManager{
+		dir:     dir,
+		workers: make(chan struct{}, max(1, numWorkers)),
+		jobs:    map[string]*simJob{},
+	}
+	if dir == "" {
+		return m, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved []*proto.SimJob
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != jobFileExt {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		job := &proto.SimJob{}
+		if err := googleProto.Unmarshal(data, job); err != nil {
+			log.Printf("Skipping invalid job file %s: %s", entry.Name(), err)
+			continue
+		}
+		saved = append(saved, job)
+	}
+
+	// Keep the original queue order for jobs which need to run again.
+	slices.SortFunc(saved, compareJobs)
+	for _, job := range saved {
+		if isActiveJob(job) {
+			job.Status = proto.SimJobStatus_SimJobStatusQueued
+			job.Progress = &proto.ProgressMetrics{}
+		}
+		m.add(job)
+	}
+
+	return m, nil
+}
+
+func compareJobs(a, b *proto.SimJob) int {
+	if a.CreatedAt != b.CreatedAt {
+		return cmp.Compare(a.CreatedAt, b.CreatedAt)
+	}
+	return strings.Compare(a.Id, b.Id)
+}
+
+func isActiveJob(job *proto.SimJob) bool {
+	return job.Status == proto.SimJobStatus_SimJobStatusQueued || job.Status == proto.SimJobStatus_SimJobStatusRunning
+}
+
+func finalProgressError(progress *proto.ProgressMetrics) string {
+	if progress.FinalRaidResult != nil {
+		return progress.FinalRaidResult.ErrorResult
+	}
+	if progress.FinalBulkResult != nil {
+		return progress.FinalBulkResult.ErrorResult
+	}
+	return ""
+}
+
+// Queues a new job and returns it.
+func (m *jobManager) submit(request *proto.SimJobRequest) (*proto.SimJob, error) {
+	if request.Request == nil {
+		return nil, errors.New("job request is empty")
+	}
+	return m.add(&proto.SimJob{
+		Id:        uuid.NewString(),
+		Status:    proto.SimJobStatus_SimJobStatusQueued,
+		CreatedAt: time.Now().Unix(),
+		Request:   request,
+		Progress:  &proto.ProgressMetrics{},
+	}).snapshot(), nil
+}
+
+func (m *jobManager) add(job *proto.SimJob) *simJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	sj := &simJob{
+		job:    job,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	m.mut.Lock()
+	m.jobs[job.Id] = sj
+	m.mut.Unlock()
+
+	if isActiveJob(job) {
+		m.save(sj)
+		go m.run(sj)
+	} else {
+		cancel()
+	}
+	return sj
+}
+
+func (m *jobManager) get(id string) *simJob {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return m.jobs[id]
+}
+
+// Returns all jobs, oldest first, without their requests or final results.
+func (m *jobManager) list() *proto.SimJobList {
+	m.mut.RLock()
+	jobs := make([]*simJob, 0, len(m.jobs))
+	for _, sj := range m.jobs {
+		jobs = append(jobs, sj)
+	}
+	m.mut.RUnlock()
+
+	list := &proto.SimJobList{Jobs: make([]*proto.SimJob, 0, len(jobs))}
+	for _, sj := range jobs {
+		list.Jobs = append(list.Jobs, sj.summary())
+	}
+
+	slices.SortFunc(list.Jobs, compareJobs)
+	return list
+}
+
+// Cancels a job if it is queued or running, otherwise removes it. Returns nil
+// if there is no such job.
+func (m *jobManager) delete(id string) *proto.SimJob {
+	sj := m.get(id)
+	if sj == nil {
+		return nil
+	}
+
+	sj.mut.Lock()
+	if isActiveJob(sj.job) {
+		sj.job.Status = proto.SimJobStatus_SimJobStatusCanceled
+		sj.mut.Unlock()
+		sj.cancel()
+		m.save(sj)
+		return sj.snapshot()
+	}
+	defer sj.mut.Unlock()
+
+	m.mut.Lock()
+	delete(m.jobs, id)
+	m.mut.Unlock()
+	if m.dir != "" {
+		if err := os.Remove(m.jobPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to remove job %s: %s", id, err)
+		}
+	}
+	return googleProto.Clone(sj.job).(*proto.SimJob)
+}
+
+func (m *jobManager) run(sj *simJob) {
+	defer sj.cancel()
+
+	// Wait for a free worker.
+	select {
+	case m.workers <- struct{}{}:
+	case <-sj.ctx.Done():
+		return
+	}
+	defer func() { <-m.workers }()
+
+	sj.mut.Lock()
+	if sj.job.Status != proto.SimJobStatus_SimJobStatusQueued {
+		sj.mut.Unlock()
+		return
+	}
+	sj.job.Status = proto.SimJobStatus_SimJobStatusRunning
+	// The sims modify their requests, so give them a copy.
+	request := googleProto.Clone(sj.job.Request).(*proto.SimJobRequest)
+	sj.mut.Unlock()
+	m.save(sj)
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	switch request := request.Request.(type) {
+	case *proto.SimJobRequest_RaidSim:
+		core.RunRaidSimAsyncContext(sj.ctx, request.RaidSim, reporter)
+	case *proto.SimJobRequest_StatWeights:
+		core.StatWeightsAsyncContext(sj.ctx, request.StatWeights, reporter)
+	case *proto.SimJobRequest_BulkSim:
+		core.RunBulkSimAsync(sj.ctx, request.BulkSim, reporter)
+	}
+
+	var final *proto.ProgressMetrics
+	for progress := range reporter {
+		if core.IsFinalProgress(progress) {
+			final = progress
+			break
+		}
+		sj.mut.Lock()
+		if sj.job.Status == proto.SimJobStatus_SimJobStatusRunning {
+			sj.job.Progress = progress
+		}
+		sj.mut.Unlock()
+	}
+
+	sj.mut.Lock()
+	if sj.job.Status != proto.SimJobStatus_SimJobStatusRunning {
+		// Canceled while running.
+		sj.mut.Unlock()
+		return
+	}
+	if final == nil {
+		sj.job.Status = proto.SimJobStatus_SimJobStatusFailed
+		sj.job.Error = "sim ended without a result"
+	} else if errStr := finalProgressError(final); errStr != "" {
+		sj.job.Status = proto.SimJobStatus_SimJobStatusFailed
+		sj.job.Error = errStr
+		sj.job.Progress = final
+	} else {
+		sj.job.Status = proto.SimJobStatus_SimJobStatusCompleted
+		sj.job.Progress = final
+	}
+	sj.mut.Unlock()
+	m.save(sj)
+}
+
+func (m *jobManager) jobPath(id string) string {
+	return filepath.Join(m.dir, id+jobFileExt)
+}
+
+func (m *jobManager) save(sj *simJob) {
+	if m.dir == "" {
+		return
+	}
+
+	// Hold the lock while writing, so saves of the same job can't interleave.
+	sj.mut.Lock()
+	defer sj.mut.Unlock()
+	id := sj.job.Id
+	if m.get(id) != sj {
+		// Already removed.
+		return
+	}
+
+	data, err := googleProto.Marshal(sj.job)
+	if err != nil {
+		log.Printf("Failed to marshal job %s: %s", id, err)
+		return
+	}
+
+	// Write to a temporary file first, so a crash never leaves a partial job.
+	tmpPath := m.jobPath(id) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		log.Printf("Failed to save job %s: %s", id, err)
+		return
+	}
+	if err := os.Rename(tmpPath, m.jobPath(id)); err != nil {
+		log.Printf("Failed to save job %s: %s", id, err)
+	}
+}
+
+func (sj *simJob) snapshot() *proto.SimJob {
+	sj.mut.Lock()
+	defer sj.mut.Unlock()
+	return googleProto.Clone(sj.job).(*proto.SimJob)
+}
+
+func (sj *simJob) summary() *proto.SimJob {
+	sj.mut.Lock()
+	defer sj.mut.Unlock()
+	summary := &proto.SimJob{
+		Id:        sj.job.Id,
+		Status:    sj.job.Status,
+		CreatedAt: sj.job.CreatedAt,
+		Error:     sj.job.Error,
+	}
+	if progress := sj.job.Progress; progress != nil {
+		summary.Progress = &proto.ProgressMetrics{
+			CompletedIterations: progress.CompletedIterations,
+			TotalIterations:     progress.TotalIterations,
+			CompletedSims:       progress.CompletedSims,
+			TotalSims:           progress.TotalSims,
+			PresimRunning:       progress.PresimRunning,
+			Dps:                 progress.Dps,
+			Hps:                 progress.Hps,
+		}
+	}
+	return summary
+}
+
+// Handles POST /jobs, GET /jobs, GET /jobs/{id} and DELETE /jobs/{id}.
+func (m *jobManager) handleJobs(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/jobs"), "/")
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return
+		}
+		request := &proto.SimJobRequest{}
+		if err := googleProto.Unmarshal(body, request); err != nil {
+			log.Printf("Failed to parse request: %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		job, err := m.submit(request)
+		if err != nil {
+			log.Printf("Invalid job: %s", err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writeProtoResponse(w, job)
+	case id == "" && r.Method == http.MethodGet:
+		writeProtoResponse(w, m.list())
+	case id != "" && r.Method == http.MethodGet:
+		sj := m.get(id)
+		if sj == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeProtoResponse(w, sj.snapshot())
+	case id != "" && r.Method == http.MethodDelete:
+		job := m.delete(id)
+		if job == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeProtoResponse(w, job)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeProtoResponse(w http.ResponseWriter, msg googleProto.Message) {
+	outbytes, err := googleProto.Marshal(msg)
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal result: %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/x-protobuf")
+	w.Write(outbytes)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wowsims/cata/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// A request which fails immediately, without running any sims.
+func invalidJobRequest() *proto.SimJobRequest {
+	return &proto.SimJobRequest{
+		Request: &proto.SimJobRequest_RaidSim{
+			RaidSim: &proto.RaidSimRequest{SimOptions: &proto.SimOptions{Iterations: 0}},
+		},
+	}
+}
+
+func waitForJobStatus(t *testing.T, m *jobManager, id string, status proto.SimJobStatus) *proto.SimJob {
+	for start := time.Now(); time.Since(start) < time.Second*5; time.Sleep(time.Millisecond * 10) {
+		if job := m.get(id).snapshot(); job.Status == status {
+			return job
+		}
+	}
+	t.Fatalf("Job %s never reached status %s, got %s", id, status, m.get(id).snapshot().Status)
+	return nil
+}
+
+func TestJobManagerPersistsJobs(t *testing.T) {
+	dir := t.TempDir()
+	m, err := newJobManager(dir, 1)
+	if err != nil {
+		t.Fatalf("Failed to create job manager: %s", err)
+	}
+
+	job, err := m.submit(invalidJobRequest())
+	if err != nil {
+		t.Fatalf("Failed to submit job: %s", err)
+	}
+	failed := waitForJobStatus(t, m, job.Id, proto.SimJobStatus_SimJobStatusFailed)
+	if failed.Error != "Iterations can't be 0!" {
+		t.Fatalf("Unexpected job error: %s", failed.Error)
+	}
+
+	reloaded, err := newJobManager(dir, 1)
+	if err != nil {
+		t.Fatalf("Failed to reload job manager: %s", err)
+	}
+	if sj := reloaded.get(job.Id); sj == nil || !googleProto.Equal(sj.snapshot(), failed) {
+		t.Fatalf("Expected the failed job to be reloaded")
+	}
+
+	if reloaded.delete(job.Id) == nil || reloaded.get(job.Id) != nil {
+		t.Fatalf("Expected a finished job to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(dir, job.Id+jobFileExt)); !os.IsNotExist(err) {
+		t.Fatalf("Expected the job file to be removed, got %v", err)
+	}
+}
+
+func TestJobManagerResumesActiveJobs(t *testing.T) {
+	dir := t.TempDir()
+	data, err := googleProto.Marshal(&proto.SimJob{
+		Id:      "resumed",
+		Status:  proto.SimJobStatus_SimJobStatusRunning,
+		Request: invalidJobRequest(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal job: %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "resumed"+jobFileExt), data, 0644); err != nil {
+		t.Fatalf("Failed to write job: %s", err)
+	}
+
+	m, err := newJobManager(dir, 1)
+	if err != nil {
+		t.Fatalf("Failed to create job manager: %s", err)
+	}
+	waitForJobStatus(t, m, "resumed", proto.SimJobStatus_SimJobStatusFailed)
+}
+
+func TestJobManagerCancelQueuedJob(t *testing.T) {
+	m, err := newJobManager("", 1)
+	if err != nil {
+		t.Fatalf("Failed to create job manager: %s", err)
+	}
+
+	// Occupy the only worker, so the job stays queued.
+	m.workers <- struct{}{}
+	job, _ := m.submit(invalidJobRequest())
+	if job.Status != proto.SimJobStatus_SimJobStatusQueued {
+		t.Fatalf("Expected a queued job, got %s", job.Status)
+	}
+	if canceled := m.delete(job.Id); canceled.Status != proto.SimJobStatus_SimJobStatusCanceled {
+		t.Fatalf("Expected a canceled job, got %s", canceled.Status)
+	}
+	<-m.workers
+
+	// The canceled job must never run.
+	time.Sleep(time.Millisecond * 50)
+	list := m.list()
+	if len(list.Jobs) != 1 || list.Jobs[0].Status != proto.SimJobStatus_SimJobStatusCanceled || list.Jobs[0].Request != nil {
+		t.Fatalf("Expected a single canceled job summary, got %v", list.Jobs)
+	}
+}
+
+func TestJobsHandler(t *testing.T) {
+	m, err := newJobManager("", 1)
+	if err != nil {
+		t.Fatalf("Failed to create job manager: %s", err)
+	}
+
+	serve := func(method string, path string, msg googleProto.Message) *httptest.ResponseRecorder {
+		var body []byte
+		if msg != nil {
+			body, _ = googleProto.Marshal(msg)
+		}
+		rec := httptest.NewRecorder()
+		m.handleJobs(rec, httptest.NewRequest(method, path, bytes.NewReader(body)))
+		return rec
+	}
+
+	if rec := serve(http.MethodPost, "/jobs", &proto.SimJobRequest{}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected an empty job to be rejected, got %d", rec.Code)
+	}
+	if rec := serve(http.MethodGet, "/jobs/missing", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected a missing job to return 404, got %d", rec.Code)
+	}
+
+	rec := serve(http.MethodPost, "/jobs", invalidJobRequest())
+	job := &proto.SimJob{}
+	if err := googleProto.Unmarshal(rec.Body.Bytes(), job); err != nil || job.Id == "" {
+		t.Fatalf("Expected a job in the response, got %v", err)
+	}
+	waitForJobStatus(t, m, job.Id, proto.SimJobStatus_SimJobStatusFailed)
+
+	rec = serve(http.MethodGet, "/jobs/"+job.Id, nil)
+	if err := googleProto.Unmarshal(rec.Body.Bytes(), job); err != nil || job.Status != proto.SimJobStatus_SimJobStatusFailed {
+		t.Fatalf("Expected the failed job, got %v %v", job, err)
+	}
+}
@@ -48,6 +48,8 @@ func main() {
 	var host = flag.String("host", "localhost:3333", "URL to host the interface on.")
 	var launch = flag.Bool("launch", true, "auto launch browser")
 	var skipVersionCheck = flag.Bool("nvc", false, "set true to skip version check")
+	var jobDir = flag.String("jobdir", "", "Directory to save /jobs API jobs in, so they survive restarts. If empty, jobs are only kept in memory.")
+	var jobWorkers = flag.Int("jobworkers", 1, "Maximum number of /jobs API jobs to run at once.")
 
 	flag.Parse()
 
@@ -82,9 +84,15 @@ func main() {
 		}()
 	}
 
+	jobs, err := newJobManager(*jobDir, *jobWorkers)
+	if err != nil {
+		log.Fatalf("Failed to load jobs: %s", err)
+	}
+
 	s := &server{
 		progMut:         sync.RWMutex{},
 		asyncProgresses: map[string]*asyncProgress{},
+		jobs:            jobs,
 	}
 	s.runServer(*useFS, *host, *launch, *simName, *wasm, bufio.NewReader(os.Stdin))
 }
@@ -100,6 +108,10 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/optimizeGear": {msg: func() googleProto.Message { return &proto.OptimizeGearRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		request := msg.(*proto.OptimizeGearRequest)
+		return core.OptimizeGear(request, request.Weights)
+	}},
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
@@ -114,11 +126,17 @@ var asyncAPIHandlers = map[string]asyncAPIHandler{
 		// We should have all the async APIs take in context and let it be cancelled via its async ID.
 		core.RunBulkSimAsync(context.Background(), msg.(*proto.BulkSimRequest), reporter)
 	}},
+	"/tuneRotationAsync": {msg: func() googleProto.Message { return &proto.TuneRotationRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		request := msg.(*proto.TuneRotationRequest)
+		core.TuneRotationAsync(request.BaseSettings, request.TuneSettings, reporter)
+	}},
 }
 
 type server struct {
 	progMut         sync.RWMutex
 	asyncProgresses map[string]*asyncProgress
+
+	jobs *jobManager // Handles the /jobs API, which is disabled if nil.
 }
 
 type apiHandler struct {
@@ -194,7 +212,7 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if core.IsFinalProgress(progMetric) {
 					return
 				}
 			}
@@ -252,7 +270,7 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
+		if core.IsFinalProgress(latest) {
 			s.progMut.Lock()
 			delete(s.asyncProgresses, msg.ProgressId)
 			s.progMut.Unlock()
@@ -260,6 +278,12 @@ func (s *server) setupAsyncServer() {
 		w.Header().Add("Content-Type", "application/x-protobuf")
 		w.Write(outbytes)
 	})))
+
+	if s.jobs != nil {
+		jobsHandler := corsMiddleware(http.HandlerFunc(s.jobs.handleJobs))
+		http.Handle("/jobs", jobsHandler)
+		http.Handle("/jobs/", jobsHandler)
+	}
 }
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
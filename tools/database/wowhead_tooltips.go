@@ -626,9 +626,33 @@ func (item WowheadItemResponse) ToGemProto() *proto.UIGem {
 		Quality:            proto.ItemQuality(item.GetQuality()),
 		Unique:             item.GetUnique(),
 		RequiredProfession: item.GetRequiredProfession(),
+		MetaRequirements:   item.GetMetaGemRequirements(),
 	}
 }
 
+var metaGemRequirementsRegex = regexp.MustCompile(`Requires at least [^<]*`)
+var metaGemColorCountRegex = regexp.MustCompile(`([0-9]+) (Red|Yellow|Blue) [Gg]ems?`)
+
+// Parses e.g. 'Requires at least 1 Blue Gem and 1 Yellow Gem.' from a meta gem's tooltip.
+func (item WowheadItemResponse) GetMetaGemRequirements() *proto.MetaGemRequirements {
+	if item.GetSocketColor() != proto.GemColor_GemColorMeta {
+		return nil
+	}
+	reqs := &proto.MetaGemRequirements{}
+	for _, match := range metaGemColorCountRegex.FindAllStringSubmatch(metaGemRequirementsRegex.FindString(item.Tooltip), -1) {
+		count, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "Red":
+			reqs.MinRed = int32(count)
+		case "Yellow":
+			reqs.MinYellow = int32(count)
+		case "Blue":
+			reqs.MinBlue = int32(count)
+		}
+	}
+	return reqs
+}
+
 var strengthGemStatRegexes = []*regexp.Regexp{regexp.MustCompile(`\+([0-9]+) Strength`), regexp.MustCompile(`\+([0-9]+) (to )?All Stats`)}
 var agilityGemStatRegexes = []*regexp.Regexp{regexp.MustCompile(`\+([0-9]+) Agility`), regexp.MustCompile(`\+([0-9]+) (to )?All Stats`)}
 var staminaGemStatRegexes = []*regexp.Regexp{regexp.MustCompile(`\+([0-9]+) Stamina`), regexp.MustCompile(`\+([0-9]+) (to )?All Stats`)}
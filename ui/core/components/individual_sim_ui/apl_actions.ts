@@ -4,6 +4,7 @@ import {
 	APLAction,
 	APLActionActivateAura,
 	APLActionAutocastOtherCooldowns,
+	APLActionCallActionList,
 	APLActionCancelAura,
 	APLActionCastFriendlySpell,
 	APLActionCastSpell,
@@ -17,8 +18,12 @@ import {
 	APLActionMultidot,
 	APLActionMultishield,
 	APLActionResetSequence,
+	APLActionRetarget,
+	APLActionRetarget_RetargetMode as RetargetMode,
+	APLActionRunActionList,
 	APLActionSchedule,
 	APLActionSequence,
+	APLActionSetVariable,
 	APLActionStrictSequence,
 	APLActionTriggerICD,
 	APLActionWait,
@@ -267,6 +272,23 @@ function itemSwapSetFieldConfig(field: string): AplHelpers.APLPickerBuilderField
 	};
 }
 
+function retargetModeFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
+	return {
+		field: field,
+		newValue: () => RetargetMode.ModeMin,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: [
+					{ value: RetargetMode.ModeMin, label: 'Lowest' },
+					{ value: RetargetMode.ModeMax, label: 'Highest' },
+				],
+			}),
+	};
+}
+
 function actionFieldConfig(field: string): AplHelpers.APLPickerBuilderFieldConfig<any, any> {
 	return {
 		field: field,
@@ -345,7 +367,10 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: APLActionCastFriendlySpell.create,
 		fields: [
 			AplHelpers.actionIdFieldConfig('spellId', 'friendly_spells', ''),
-			AplHelpers.unitFieldConfig('target', 'players')
+			AplHelpers.unitFieldConfig('target', 'players'),
+			AplHelpers.booleanFieldConfig('smartTarget', 'Smart Target', {
+				labelTooltip: 'If checked, casts on whichever player is missing the most health instead of the selected target.',
+			}),
 		],
 		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getRaid()!.size() > 1,
 	}),
@@ -537,6 +562,36 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: APLActionStrictSequence.create,
 		fields: [actionListFieldConfig('actions')],
 	}),
+	['callActionList']: inputBuilder({
+		label: 'Call Action List',
+		submenu: ['Action Lists'],
+		shortDescription: 'Performs the first ready action from a named action list, or moves on to the next action if none are ready.',
+		fullDescription: `
+			<p>Use the <b>name</b> field to refer to an action list defined in this rotation. Action lists which call themselves, directly or indirectly, are ignored.</p>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: APLActionCallActionList.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
+	['runActionList']: inputBuilder({
+		label: 'Run Action List',
+		submenu: ['Action Lists'],
+		shortDescription: 'Like <b>Call Action List</b>, except no actions after this one are considered when none of the listed actions are ready.',
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: APLActionRunActionList.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
+	['setVariable']: inputBuilder({
+		label: 'Set Variable',
+		submenu: ['Misc'],
+		shortDescription: 'Overrides the value of a variable until the end of the iteration.',
+		fullDescription: `
+			<p>The new value is evaluated when this action executes. This action is only ready when it would change the stored value.</p>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: APLActionSetVariable.create,
+		fields: [AplHelpers.stringFieldConfig('name'), AplValues.valueFieldConfig('value')],
+	}),
 	['changeTarget']: inputBuilder({
 		label: 'Change Target',
 		submenu: ['Misc'],
@@ -544,6 +599,17 @@ const actionKindFactories: { [f in NonNullable<APLActionKind>]: ActionKindConfig
 		newValue: () => APLActionChangeTarget.create(),
 		fields: [AplHelpers.unitFieldConfig('newTarget', 'targets')],
 	}),
+	['retarget']: inputBuilder({
+		label: 'Retarget',
+		submenu: ['Misc'],
+		shortDescription: 'Changes the current target to the target with the lowest or highest value.',
+		fullDescription: `
+			<p>The value is evaluated once for each active target, with the current target set to that target. The current target is kept if it is tied for the best value.</p>
+		`,
+		includeIf: (player: Player<any>, isPrepull: boolean) => !isPrepull,
+		newValue: () => APLActionRetarget.create({ mode: RetargetMode.ModeMin }),
+		fields: [retargetModeFieldConfig('mode'), AplValues.valueFieldConfig('value')],
+	}),
 	['activateAura']: inputBuilder({
 		label: 'Activate Aura',
 		submenu: ['Misc'],
@@ -1,5 +1,5 @@
 import { Player, UnitMetadata } from '../../player.js';
-import { APLValueEclipsePhase, APLValueRuneSlot, APLValueRuneType } from '../../proto/apl.js';
+import { APLValueEclipsePhase, APLValueResourceType, APLValueRuneSlot, APLValueRuneType } from '../../proto/apl.js';
 import { ActionID, OtherAction, UnitReference, UnitReference_Type as UnitType } from '../../proto/common.js';
 import { FeralDruid_Rotation_AplType } from '../../proto/druid.js';
 import { ActionId, defaultTargetIcon, getPetIconFromName } from '../../proto_utils/action_id.js';
@@ -681,6 +681,28 @@ export function runeTypeFieldConfig(field: string, includeDeath: boolean): APLPi
 	};
 }
 
+export function resourceTypeFieldConfig(field: string): APLPickerBuilderFieldConfig<any, any> {
+	const values = [
+		{ value: APLValueResourceType.ResourceEnergy, label: 'Energy' },
+		{ value: APLValueResourceType.ResourceFocus, label: 'Focus' },
+		{ value: APLValueResourceType.ResourceBloodRunes, label: 'Blood Runes' },
+		{ value: APLValueResourceType.ResourceFrostRunes, label: 'Frost Runes' },
+		{ value: APLValueResourceType.ResourceUnholyRunes, label: 'Unholy Runes' },
+	];
+
+	return {
+		field: field,
+		newValue: () => APLValueResourceType.ResourceEnergy,
+		factory: (parent, player, config) =>
+			new TextDropdownPicker(parent, player, {
+				...config,
+				defaultLabel: 'None',
+				equals: (a, b) => a == b,
+				values: values,
+			}),
+	};
+}
+
 export function runeSlotFieldConfig(field: string): APLPickerBuilderFieldConfig<any, any> {
 	return {
 		field: field,
@@ -95,7 +95,10 @@ class APLPrepullActionPicker extends Input<Player<any>, APLPrepullAction> {
 		this.player = player;
 
 		const itemHeaderElem = ListPicker.getItemHeaderElem(this);
-		makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.prepullActions[index]?.warnings || []);
+		makeListItemWarnings(itemHeaderElem, player, player => {
+			const itemStats = player.getCurrentStats().rotationStats?.prepullActions[index];
+			return [...(itemStats?.warnings || []), ...(itemStats?.lints || [])];
+		});
 
 		this.hidePicker = new HidePicker(itemHeaderElem, player, {
 			changedEvent: () => this.player.rotationChangeEmitter,
@@ -194,7 +197,10 @@ class APLListItemPicker extends Input<Player<any>, APLListItem> {
 		this.player = player;
 
 		const itemHeaderElem = ListPicker.getItemHeaderElem(this);
-		makeListItemWarnings(itemHeaderElem, player, player => player.getCurrentStats().rotationStats?.priorityList[index]?.warnings || []);
+		makeListItemWarnings(itemHeaderElem, player, player => {
+			const itemStats = player.getCurrentStats().rotationStats?.priorityList[index];
+			return [...(itemStats?.warnings || []), ...(itemStats?.lints || [])];
+		});
 
 		this.hidePicker = new HidePicker(itemHeaderElem, player, {
 			changedEvent: () => this.player.rotationChangeEmitter,
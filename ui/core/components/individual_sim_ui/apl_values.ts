@@ -18,12 +18,14 @@ import {
 	APLValueCompare,
 	APLValueCompare_ComparisonOperator as ComparisonOperator,
 	APLValueConst,
+	APLValueCountTargetsWhere,
 	APLValueCurrentComboPoints,
 	APLValueCurrentEclipsePhase,
 	APLValueCurrentEnergy,
 	APLValueCurrentFocus,
 	APLValueCurrentHealth,
 	APLValueCurrentHealthPercent,
+	APLValueCurrentHolyPower,
 	APLValueCurrentLunarEnergy,
 	APLValueCurrentMana,
 	APLValueCurrentManaPercent,
@@ -48,13 +50,17 @@ import {
 	APLValueMath,
 	APLValueMath_MathOperator as MathOperator,
 	APLValueMax,
+	APLValueMaxOverTargets,
 	APLValueMin,
+	APLValueMinOverTargets,
 	APLValueNextRuneCooldown,
 	APLValueNot,
 	APLValueNumberTargets,
 	APLValueOr,
 	APLValueRemainingTime,
 	APLValueRemainingTimePercent,
+	APLValueResourceAtTime,
+	APLValueResourceTimeToValue,
 	APLValueRuneCooldown,
 	APLValueRuneSlotCooldown,
 	APLValueSequenceIsComplete,
@@ -71,6 +77,7 @@ import {
 	APLValueSpellTravelTime,
 	APLValueTotemRemainingTime,
 	APLValueUnitIsMoving,
+	APLValueVariable,
 	APLValueWarlockShouldRecastDrainSoul,
 	APLValueWarlockShouldRefreshCorruption,
 } from '../../proto/apl.js';
@@ -549,6 +556,36 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueNumberTargets.create,
 		fields: [],
 	}),
+	countTargetsWhere: inputBuilder({
+		label: 'Count Targets Where',
+		submenu: ['Encounter'],
+		shortDescription: 'Number of active targets for which the condition is true.',
+		fullDescription: `
+			<p>The condition is evaluated once for each active target, with the current target set to that target.</p>
+		`,
+		newValue: APLValueCountTargetsWhere.create,
+		fields: [valueFieldConfig('condition')],
+	}),
+	minOverTargets: inputBuilder({
+		label: 'Min Over Targets',
+		submenu: ['Encounter'],
+		shortDescription: 'Lowest value over all active targets.',
+		fullDescription: `
+			<p>The value is evaluated once for each active target, with the current target set to that target.</p>
+		`,
+		newValue: APLValueMinOverTargets.create,
+		fields: [valueFieldConfig('val')],
+	}),
+	maxOverTargets: inputBuilder({
+		label: 'Max Over Targets',
+		submenu: ['Encounter'],
+		shortDescription: 'Highest value over all active targets.',
+		fullDescription: `
+			<p>The value is evaluated once for each active target, with the current target set to that target.</p>
+		`,
+		newValue: APLValueMaxOverTargets.create,
+		fields: [valueFieldConfig('val')],
+	}),
 	frontOfTarget: inputBuilder({
 		label: 'Front of Target',
 		submenu: ['Encounter'],
@@ -650,6 +687,46 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() == Class.ClassDeathKnight,
 		fields: [],
 	}),
+	currentHolyPower: inputBuilder({
+		label: 'Holy Power',
+		submenu: ['Resources'],
+		shortDescription: 'Amount of currently available Holy Power.',
+		newValue: APLValueCurrentHolyPower.create,
+		includeIf: (player: Player<any>, _isPrepull: boolean) => player.getClass() == Class.ClassPaladin,
+		fields: [],
+	}),
+	resourceTimeToValue: inputBuilder({
+		label: 'Time To Resource',
+		submenu: ['Resources'],
+		shortDescription: 'Amount of time until a resource regenerates to the given amount, assuming none is spent.',
+		fullDescription: `
+			<p>Uses the current regen rate, including active haste effects.</p>
+			<p>Rune resources count both runes of their type, including Death runes. Returns a very large value if the amount can never be reached.</p>
+		`,
+		newValue: () =>
+			APLValueResourceTimeToValue.create({
+				amount: { value: { oneofKind: 'const', const: { val: '0' } } },
+			}),
+		includeIf: (player: Player<any>, _isPrepull: boolean) =>
+			[Class.ClassRogue, Class.ClassDruid, Class.ClassHunter, Class.ClassDeathKnight].includes(player.getClass()),
+		fields: [AplHelpers.resourceTypeFieldConfig('resource'), valueFieldConfig('amount')],
+	}),
+	resourceAtTime: inputBuilder({
+		label: 'Resource In',
+		submenu: ['Resources'],
+		shortDescription: 'Predicted amount of a resource after the given delay, assuming none is spent.',
+		fullDescription: `
+			<p>Uses the current regen rate, including active haste effects.</p>
+			<p>Rune resources count both runes of their type, including Death runes.</p>
+		`,
+		newValue: () =>
+			APLValueResourceAtTime.create({
+				delay: { value: { oneofKind: 'const', const: { val: '0s' } } },
+			}),
+		includeIf: (player: Player<any>, _isPrepull: boolean) =>
+			[Class.ClassRogue, Class.ClassDruid, Class.ClassHunter, Class.ClassDeathKnight].includes(player.getClass()),
+		fields: [AplHelpers.resourceTypeFieldConfig('resource'), valueFieldConfig('delay')],
+	}),
 	currentLunarEnergy: inputBuilder({
 		label: 'Solar Energy',
 		submenu: ['Eclipse'],
@@ -957,6 +1034,13 @@ const valueKindFactories: { [f in NonNullable<APLValueKind>]: ValueKindConfig<AP
 		newValue: APLValueSequenceTimeToReady.create,
 		fields: [AplHelpers.stringFieldConfig('sequenceName')],
 	}),
+	variable: inputBuilder({
+		label: 'Variable',
+		submenu: ['Logic'],
+		shortDescription: 'Returns the current value of a variable defined in this rotation.',
+		newValue: APLValueVariable.create,
+		fields: [AplHelpers.stringFieldConfig('name')],
+	}),
 
 	// Class/spec specific values
 	totemRemainingTime: inputBuilder({
@@ -238,6 +238,7 @@ export class BulkTab extends SimTab {
 	private autoGem: boolean;
 	private simTalents: boolean;
 	private autoEnchant: boolean;
+	private autoReforge: boolean;
 	private defaultGems: SimGem[];
 	private savedTalents: TalentLoadout[];
 	private gemIconElements: HTMLImageElement[];
@@ -266,6 +267,7 @@ export class BulkTab extends SimTab {
 		this.fastMode = true;
 		this.autoGem = true;
 		this.autoEnchant = true;
+		this.autoReforge = false;
 		this.savedTalents = [];
 		this.simTalents = false;
 		this.defaultGems = [UIGem.create(), UIGem.create(), UIGem.create(), UIGem.create()];
@@ -291,6 +293,7 @@ export class BulkTab extends SimTab {
 			this.doCombos = settings.combinations;
 			this.fastMode = settings.fastMode;
 			this.autoEnchant = settings.autoEnchant;
+			this.autoReforge = settings.autoReforge;
 			this.savedTalents = settings.talentsToSim;
 			this.autoGem = settings.autoGem;
 			this.simTalents = settings.simTalents;
@@ -326,6 +329,8 @@ export class BulkTab extends SimTab {
 			fastMode: this.fastMode,
 			autoEnchant: this.autoEnchant,
 			autoGem: this.autoGem,
+			autoReforge: this.autoReforge,
+			epWeights: this.simUI.player.getEpWeights().toProto(),
 			simTalents: this.simTalents,
 			talentsToSim: this.savedTalents,
 			defaultRedGem: this.defaultGems[0].id,
@@ -849,6 +854,16 @@ export class BulkTab extends SimTab {
 			},
 		});
 
+		new BooleanPicker<BulkTab>(settingsBlock.bodyElement, this, {
+			label: 'Auto Reforge',
+			labelTooltip: 'When checked bulk simulator will reforge each replacement item based on your stat weights, respecting hit and expertise caps.',
+			changedEvent: (_obj: BulkTab) => this.itemsChangedEmitter,
+			getValue: _obj => this.autoReforge,
+			setValue: (id: EventID, obj: BulkTab, value: boolean) => {
+				obj.autoReforge = value;
+			},
+		});
+
 		new BooleanPicker<BulkTab>(settingsBlock.bodyElement, this, {
 			label: 'Sim Talents',
 			labelTooltip: 'When checked bulk simulator will sim chosen talent setups. Warning, it might cause the bulk sim to run for a lot longer',
@@ -53,6 +53,10 @@ export const simLaunchStatuses: Record<Spec, SimStatus> = {
 		phase: Phase.Phase1,
 		status: LaunchStatus.Unlaunched,
 	},
+	[Spec.SpecFeralTankDruid]: {
+		phase: Phase.Phase1,
+		status: LaunchStatus.Unlaunched,
+	},
 	// Hunter
 	[Spec.SpecBeastMasteryHunter]: {
 		phase: Phase.Phase1,
@@ -75,6 +75,42 @@ export class FeralDruid extends PlayerSpec<Spec.SpecFeralDruid> {
 	};
 }
 
+export class FeralTankDruid extends PlayerSpec<Spec.SpecFeralTankDruid> {
+	static specIndex = 1;
+	static specID = Spec.SpecFeralTankDruid as Spec.SpecFeralTankDruid;
+	static classID = Class.ClassDruid as Class.ClassDruid;
+	static friendlyName = 'Feral Tank';
+	static simLink = getSpecSiteUrl('druid', 'feral_tank');
+
+	static isTankSpec = true;
+	static isHealingSpec = false;
+	static isRangedDpsSpec = false;
+	static isMeleeDpsSpec = false;
+
+	static canDualWield = false;
+
+	readonly specIndex = FeralTankDruid.specIndex;
+	readonly specID = FeralTankDruid.specID;
+	readonly classID = FeralTankDruid.classID;
+	readonly friendlyName = FeralTankDruid.friendlyName;
+	readonly simLink = FeralTankDruid.simLink;
+
+	readonly isTankSpec = FeralTankDruid.isTankSpec;
+	readonly isHealingSpec = FeralTankDruid.isHealingSpec;
+	readonly isRangedDpsSpec = FeralTankDruid.isRangedDpsSpec;
+	readonly isMeleeDpsSpec = FeralTankDruid.isMeleeDpsSpec;
+
+	readonly canDualWield = FeralTankDruid.canDualWield;
+
+	static getIcon = (size: IconSize): string => {
+		return `https://wow.zamimg.com/images/wow/icons/${size}/ability_racial_bearform.jpg`;
+	};
+
+	getIcon = (size: IconSize): string => {
+		return FeralTankDruid.getIcon(size);
+	};
+}
+
 export class RestorationDruid extends PlayerSpec<Spec.SpecRestorationDruid> {
 	static specIndex = 2;
 	static specID = Spec.SpecRestorationDruid as Spec.SpecRestorationDruid;
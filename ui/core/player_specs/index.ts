@@ -25,6 +25,7 @@ const specToPlayerSpec: Record<Spec, PlayerSpec<any> | undefined> = {
 	[Spec.SpecBalanceDruid]: DruidSpecs.BalanceDruid,
 	[Spec.SpecFeralDruid]: DruidSpecs.FeralDruid,
 	[Spec.SpecRestorationDruid]: DruidSpecs.RestorationDruid,
+	[Spec.SpecFeralTankDruid]: DruidSpecs.FeralTankDruid,
 	// Hunter
 	[Spec.SpecBeastMasteryHunter]: HunterSpecs.BeastMasteryHunter,
 	[Spec.SpecMarksmanshipHunter]: HunterSpecs.MarksmanshipHunter,
@@ -255,6 +255,7 @@ export const resourceNames: Map<ResourceType, string> = new Map([
 	[ResourceType.ResourceTypeDeathRune, 'Death Rune'],
 	[ResourceType.ResourceTypeSolarEnergy, 'Solar Energy'],
 	[ResourceType.ResourceTypeLunarEnergy, 'Lunar Energy'],
+	[ResourceType.ResourceTypeHolyPower, 'Holy Power'],
 ]);
 
 export const resourceColors: Map<ResourceType, string> = new Map([
@@ -272,6 +273,7 @@ export const resourceColors: Map<ResourceType, string> = new Map([
 	[ResourceType.ResourceTypeDeathRune, '#8b008b'],
 	[ResourceType.ResourceTypeSolarEnergy, '#d2952b'],
 	[ResourceType.ResourceTypeLunarEnergy, '#2c4f8f'],
+	[ResourceType.ResourceTypeHolyPower, '#ffa07a'],
 ]);
 
 export function stringToResourceType(str: string): ResourceType {
@@ -348,6 +350,7 @@ export const masterySpellNames: Map<Spec, string> = new Map([
 	[Spec.SpecUnholyDeathKnight, 'Dreadblade'],
 	[Spec.SpecBalanceDruid, 'Total Eclipse'],
 	[Spec.SpecFeralDruid, 'Razor Claws'],
+	[Spec.SpecFeralTankDruid, 'Savage Defender'],
 	[Spec.SpecRestorationDruid, 'Harmony'],
 	[Spec.SpecHolyPaladin, 'Illuminated Healing'],
 	[Spec.SpecProtectionPaladin, 'Divine Bulwark'],
@@ -381,6 +384,7 @@ export const masterySpellIDs: Map<Spec, number> = new Map([
 	[Spec.SpecUnholyDeathKnight, 77515],
 	[Spec.SpecBalanceDruid, 77492],
 	[Spec.SpecFeralDruid, 77493],
+	[Spec.SpecFeralTankDruid, 77494],
 	[Spec.SpecRestorationDruid, 77495],
 	[Spec.SpecHolyPaladin, 76669],
 	[Spec.SpecProtectionPaladin, 76671],
@@ -40,6 +40,9 @@ import {
 	FeralDruid,
 	FeralDruid_Options,
 	FeralDruid_Rotation,
+	FeralTankDruid,
+	FeralTankDruid_Options,
+	FeralTankDruid_Rotation,
 	RestorationDruid,
 	RestorationDruid_Options,
 	RestorationDruid_Rotation,
@@ -214,7 +217,7 @@ class UnknownSpecOptions {
 }
 
 export type DeathKnightSpecs = Spec.SpecBloodDeathKnight | Spec.SpecFrostDeathKnight | Spec.SpecUnholyDeathKnight;
-export type DruidSpecs = Spec.SpecBalanceDruid | Spec.SpecFeralDruid | Spec.SpecRestorationDruid;
+export type DruidSpecs = Spec.SpecBalanceDruid | Spec.SpecFeralDruid | Spec.SpecRestorationDruid | Spec.SpecFeralTankDruid;
 export type HunterSpecs = Spec.SpecBeastMasteryHunter | Spec.SpecMarksmanshipHunter | Spec.SpecSurvivalHunter;
 export type MageSpecs = Spec.SpecArcaneMage | Spec.SpecFireMage | Spec.SpecFrostMage;
 export type PaladinSpecs = Spec.SpecHolyPaladin | Spec.SpecRetributionPaladin | Spec.SpecProtectionPaladin;
@@ -294,6 +297,8 @@ export type SpecRotation<T extends Spec> =
 		? FeralDruid_Rotation
 		: T extends Spec.SpecRestorationDruid
 		? RestorationDruid_Rotation
+		: T extends Spec.SpecFeralTankDruid
+		? FeralTankDruid_Rotation
 		: // Hunter
 		T extends Spec.SpecBeastMasteryHunter
 		? BeastMasteryHunter_Rotation
@@ -436,6 +441,8 @@ export type SpecOptions<T extends Spec> =
 		? FeralDruid_Options
 		: T extends Spec.SpecRestorationDruid
 		? RestorationDruid_Options
+		: T extends Spec.SpecFeralTankDruid
+		? FeralTankDruid_Options
 		: // Hunter
 		T extends Spec.SpecBeastMasteryHunter
 		? BeastMasteryHunter_Options
@@ -510,6 +517,8 @@ export type SpecType<T extends Spec> =
 		? FeralDruid
 		: T extends Spec.SpecRestorationDruid
 		? RestorationDruid
+		: T extends Spec.SpecFeralTankDruid
+		? FeralTankDruid
 		: // Hunter
 		T extends Spec.SpecBeastMasteryHunter
 		? BeastMasteryHunter
@@ -752,6 +761,29 @@ export const specTypeFunctions: Record<Spec, SpecTypeFunctions<any>> = {
 				? player.spec.restorationDruid.options || RestorationDruid_Options.create()
 				: RestorationDruid_Options.create({ classOptions: {} }),
 	},
+	[Spec.SpecFeralTankDruid]: {
+		rotationCreate: () => FeralTankDruid_Rotation.create(),
+		rotationEquals: (a, b) => FeralTankDruid_Rotation.equals(a as FeralTankDruid_Rotation, b as FeralTankDruid_Rotation),
+		rotationCopy: a => FeralTankDruid_Rotation.clone(a as FeralTankDruid_Rotation),
+		rotationToJson: a => FeralTankDruid_Rotation.toJson(a as FeralTankDruid_Rotation),
+		rotationFromJson: obj => FeralTankDruid_Rotation.fromJson(obj),
+
+		talentsCreate: () => DruidTalents.create(),
+		talentsEquals: (a, b) => DruidTalents.equals(a as DruidTalents, b as DruidTalents),
+		talentsCopy: a => DruidTalents.clone(a as DruidTalents),
+		talentsToJson: a => DruidTalents.toJson(a as DruidTalents),
+		talentsFromJson: obj => DruidTalents.fromJson(obj),
+
+		optionsCreate: () => FeralTankDruid_Options.create({ classOptions: {} }),
+		optionsEquals: (a, b) => FeralTankDruid_Options.equals(a as FeralTankDruid_Options, b as FeralTankDruid_Options),
+		optionsCopy: a => FeralTankDruid_Options.clone(a as FeralTankDruid_Options),
+		optionsToJson: a => FeralTankDruid_Options.toJson(a as FeralTankDruid_Options),
+		optionsFromJson: obj => FeralTankDruid_Options.fromJson(obj),
+		optionsFromPlayer: player =>
+			player.spec.oneofKind == 'feralTankDruid'
+				? player.spec.feralTankDruid.options || FeralTankDruid_Options.create()
+				: FeralTankDruid_Options.create({ classOptions: {} }),
+	},
 	// Hunter
 	[Spec.SpecBeastMasteryHunter]: {
 		rotationCreate: () => BeastMasteryHunter_Rotation.create(),
@@ -1383,6 +1415,14 @@ export function withSpec<SpecType extends Spec>(spec: Spec, player: Player, spec
 				}),
 			};
 			return copy;
+		case Spec.SpecFeralTankDruid:
+			copy.spec = {
+				oneofKind: 'feralTankDruid',
+				feralTankDruid: FeralTankDruid.create({
+					options: specOptions as FeralTankDruid_Options,
+				}),
+			};
+			return copy;
 		// Hunter
 		case Spec.SpecBeastMasteryHunter:
 			copy.spec = {
@@ -1857,6 +1897,7 @@ export const orderedResourceTypes: Array<ResourceType> = [
 	ResourceType.ResourceTypeDeathRune,
 	ResourceType.ResourceTypeLunarEnergy,
 	ResourceType.ResourceTypeSolarEnergy,
+	ResourceType.ResourceTypeHolyPower,
 ];
 
 export const AL_CATEGORY_HARD_MODE = 'Hard Mode';
@@ -0,0 +1,11 @@
+import * as InputHelpers from '../../core/components/input_helpers.js';
+import { Spec } from '../../core/proto/common.js';
+
+// Configuration for spec-specific UI elements on the settings tab.
+// These don't need to be in a separate file but it keeps things cleaner.
+
+export const StartingRage = InputHelpers.makeSpecOptionsNumberInput<Spec.SpecFeralTankDruid>({
+	fieldName: 'startingRage',
+	label: 'Starting Rage',
+	labelTooltip: 'Initial rage at the start of each iteration.',
+});
@@ -0,0 +1,53 @@
+import * as PresetUtils from '../../core/preset_utils.js';
+import { Consumes, Flask, Food, Glyphs, Potions, Profession, UnitReference } from '../../core/proto/common.js';
+import { DruidMajorGlyph, DruidMinorGlyph, DruidPrimeGlyph, FeralTankDruid_Options as FeralTankDruidOptions } from '../../core/proto/druid.js';
+import { SavedTalents } from '../../core/proto/ui.js';
+import DefaultApl from './apls/default.apl.json';
+import P1Gear from './gear_sets/p1.gear.json';
+
+// Preset options for this spec.
+// Eventually we will import these values for the raid sim too, so its good to
+// keep them in a separate file.
+
+export const P1_PRESET = PresetUtils.makePresetGear('P1 Preset', P1Gear);
+
+export const ROTATION_DEFAULT = PresetUtils.makePresetAPLRotation('APL Default', DefaultApl);
+
+// Default talents. Uses the wowhead calculator format, make the talents on
+// https://wowhead.com/cata/talent-calc and copy the numbers in the url.
+export const StandardTalents = {
+	name: 'Standard',
+	data: SavedTalents.create({
+		talentsString: '-2300322312310001220311-020331',
+		glyphs: Glyphs.create({
+			prime1: DruidPrimeGlyph.GlyphOfMangle,
+			prime2: DruidPrimeGlyph.GlyphOfLacerate,
+			prime3: DruidPrimeGlyph.GlyphOfBerserk,
+			major1: DruidMajorGlyph.GlyphOfFrenziedRegeneration,
+			major2: DruidMajorGlyph.GlyphOfMaul,
+			major3: DruidMajorGlyph.GlyphOfBarkskin,
+			minor1: DruidMinorGlyph.GlyphOfChallengingRoar,
+			minor2: DruidMinorGlyph.GlyphOfDash,
+			minor3: DruidMinorGlyph.GlyphOfUnburdenedRebirth,
+		}),
+	}),
+};
+
+export const DefaultOptions = FeralTankDruidOptions.create({
+	classOptions: {
+		innervateTarget: UnitReference.create(),
+	},
+	startingRage: 20,
+});
+
+export const DefaultConsumes = Consumes.create({
+	flask: Flask.FlaskOfSteelskin,
+	food: Food.FoodSkeweredEel,
+	defaultPotion: Potions.EarthenPotion,
+	prepopPotion: Potions.EarthenPotion,
+});
+
+export const OtherDefaults = {
+	profession1: Profession.Leatherworking,
+	profession2: Profession.Engineering,
+};
@@ -0,0 +1,181 @@
+import * as BuffDebuffInputs from '../../core/components/inputs/buffs_debuffs.js';
+import * as OtherInputs from '../../core/components/other_inputs.js';
+import { IndividualSimUI, registerSpecConfig } from '../../core/individual_sim_ui.js';
+import { Player } from '../../core/player.js';
+import { PlayerClasses } from '../../core/player_classes';
+import { APLRotation } from '../../core/proto/apl.js';
+import { Debuffs, Faction, IndividualBuffs, PartyBuffs, PseudoStat, Race, RaidBuffs, Spec, Stat } from '../../core/proto/common.js';
+import { Stats } from '../../core/proto_utils/stats.js';
+import * as DruidInputs from '../inputs.js';
+import * as FeralTankInputs from './inputs.js';
+import * as Presets from './presets.js';
+
+const SPEC_CONFIG = registerSpecConfig(Spec.SpecFeralTankDruid, {
+	cssClass: 'feral-tank-druid-sim-ui',
+	cssScheme: PlayerClasses.getCssClass(PlayerClasses.Druid),
+	// List any known bugs / issues here and they'll be shown on the site.
+	knownIssues: [],
+
+	// All stats for which EP should be calculated.
+	epStats: [
+		Stat.StatStamina,
+		Stat.StatStrength,
+		Stat.StatAgility,
+		Stat.StatAttackPower,
+		Stat.StatExpertise,
+		Stat.StatMeleeHit,
+		Stat.StatMeleeCrit,
+		Stat.StatMeleeHaste,
+		Stat.StatArmor,
+		Stat.StatBonusArmor,
+		Stat.StatDodge,
+		Stat.StatNatureResistance,
+		Stat.StatShadowResistance,
+		Stat.StatFrostResistance,
+		Stat.StatMastery,
+	],
+	epPseudoStats: [PseudoStat.PseudoStatMainHandDps],
+	// Reference stat against which to calculate EP. I think all classes use either spell power or attack power.
+	epReferenceStat: Stat.StatAttackPower,
+	// Which stats to display in the Character Stats section, at the bottom of the left-hand sidebar.
+	displayStats: [
+		Stat.StatHealth,
+		Stat.StatArmor,
+		Stat.StatBonusArmor,
+		Stat.StatStamina,
+		Stat.StatStrength,
+		Stat.StatAgility,
+		Stat.StatAttackPower,
+		Stat.StatExpertise,
+		Stat.StatMeleeHit,
+		Stat.StatMeleeCrit,
+		Stat.StatMeleeHaste,
+		Stat.StatDodge,
+		Stat.StatNatureResistance,
+		Stat.StatShadowResistance,
+		Stat.StatFrostResistance,
+		Stat.StatMastery,
+	],
+
+	defaults: {
+		// Default equipped gear.
+		gear: Presets.P1_PRESET.gear,
+		// Default EP weights for sorting gear in the gear picker.
+		epWeights: Stats.fromMap(
+			{
+				[Stat.StatArmor]: 0.5,
+				[Stat.StatBonusArmor]: 0.5,
+				[Stat.StatStamina]: 2.5,
+				[Stat.StatStrength]: 0.4,
+				[Stat.StatAgility]: 2.8,
+				[Stat.StatAttackPower]: 0.35,
+				[Stat.StatExpertise]: 1.2,
+				[Stat.StatMeleeHit]: 1.2,
+				[Stat.StatMeleeCrit]: 0.9,
+				[Stat.StatMeleeHaste]: 0.4,
+				[Stat.StatDodge]: 1.6,
+				// @todo: Calculate actual weights
+				[Stat.StatMastery]: 1.0,
+			},
+			{
+				[PseudoStat.PseudoStatMainHandDps]: 0.0,
+			},
+		),
+		other: Presets.OtherDefaults,
+		// Default consumes settings.
+		consumes: Presets.DefaultConsumes,
+		// Default talents.
+		talents: Presets.StandardTalents.data,
+		// Default spec-specific settings.
+		specOptions: Presets.DefaultOptions,
+		// Default raid/party buffs settings.
+		raidBuffs: RaidBuffs.create({
+			arcaneBrilliance: true,
+			bloodlust: true,
+			markOfTheWild: true,
+			icyTalons: true,
+			leaderOfThePack: true,
+			powerWordFortitude: true,
+			strengthOfEarthTotem: true,
+			trueshotAura: true,
+			communion: true,
+			devotionAura: true,
+		}),
+		partyBuffs: PartyBuffs.create({}),
+		individualBuffs: IndividualBuffs.create({}),
+		debuffs: Debuffs.create({
+			sunderArmor: true,
+			bloodFrenzy: true,
+			judgement: true,
+			frostFever: true,
+		}),
+	},
+
+	// IconInputs to include in the 'Player' section on the settings tab.
+	playerIconInputs: [DruidInputs.SelfInnervate()],
+	// Buff and Debuff inputs to include/exclude, overriding the EP-based defaults.
+	includeBuffDebuffInputs: [BuffDebuffInputs.StaminaBuff],
+	excludeBuffDebuffInputs: [],
+	// Inputs to include in the 'Other' section on the settings tab.
+	otherInputs: {
+		inputs: [
+			OtherInputs.InputDelay,
+			OtherInputs.TankAssignment,
+			OtherInputs.IncomingHps,
+			OtherInputs.HealingCadence,
+			OtherInputs.HealingCadenceVariation,
+			OtherInputs.BurstWindow,
+			OtherInputs.HpPercentForDefensives,
+			OtherInputs.InspirationUptime,
+			FeralTankInputs.StartingRage,
+			OtherInputs.InFrontOfTarget,
+		],
+	},
+	encounterPicker: {
+		// Whether to include 'Execute Duration (%)' in the 'Encounter' section of the settings tab.
+		showExecuteProportion: false,
+	},
+
+	presets: {
+		// Preset talents that the user can quickly select.
+		talents: [Presets.StandardTalents],
+		// Preset rotations that the user can quickly select.
+		rotations: [Presets.ROTATION_DEFAULT],
+		// Preset gear configurations that the user can quickly select.
+		gear: [Presets.P1_PRESET],
+	},
+
+	autoRotation: (_player: Player<Spec.SpecFeralTankDruid>): APLRotation => {
+		return Presets.ROTATION_DEFAULT.rotation.rotation!;
+	},
+
+	raidSimPresets: [
+		{
+			spec: Spec.SpecFeralTankDruid,
+			talents: Presets.StandardTalents.data,
+			specOptions: Presets.DefaultOptions,
+			consumes: Presets.DefaultConsumes,
+			defaultFactionRaces: {
+				[Faction.Unknown]: Race.RaceUnknown,
+				[Faction.Alliance]: Race.RaceNightElf,
+				[Faction.Horde]: Race.RaceTauren,
+			},
+			defaultGear: {
+				[Faction.Unknown]: {},
+				[Faction.Alliance]: {
+					1: Presets.P1_PRESET.gear,
+				},
+				[Faction.Horde]: {
+					1: Presets.P1_PRESET.gear,
+				},
+			},
+			otherDefaults: Presets.OtherDefaults,
+		},
+	],
+});
+
+export class FeralTankDruidSimUI extends IndividualSimUI<Spec.SpecFeralTankDruid> {
+	constructor(parentElem: HTMLElement, player: Player<Spec.SpecFeralTankDruid>) {
+		super(parentElem, player, SPEC_CONFIG);
+	}
+}
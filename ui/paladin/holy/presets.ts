@@ -1,7 +1,15 @@
 import * as PresetUtils from '../../core/preset_utils.js';
 import { Consumes, Flask, Food, Potions } from '../../core/proto/common.js';
-import { HolyPaladin_Options as HolyPaladinOptions, PaladinAura, PaladinJudgement, PaladinMajorGlyph, PaladinMinorGlyph } from '../../core/proto/paladin.js';
+import {
+	HolyPaladin_Options as HolyPaladinOptions,
+	PaladinAura,
+	PaladinJudgement,
+	PaladinMajorGlyph,
+	PaladinPrimeGlyph,
+	PaladinSeal,
+} from '../../core/proto/paladin.js';
 import { SavedTalents } from '../../core/proto/ui.js';
+import DefaultApl from './apls/default.apl.json';
 import P1Gear from './gear_sets/p1.gear.json';
 import P2Gear from './gear_sets/p2.gear.json';
 import P3Gear from './gear_sets/p3.gear.json';
@@ -18,21 +26,23 @@ export const P2_PRESET = PresetUtils.makePresetGear('P2 Preset', P2Gear);
 export const P3_PRESET = PresetUtils.makePresetGear('P3 Preset', P3Gear);
 export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
+export const ROTATION_PRESET_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
+
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/cata/talent-calc and copy the numbers in the url.
 
 export const StandardTalents = {
 	name: 'Standard',
 	data: SavedTalents.create({
-		// talentsString: '50350151020013053100515221-50023131203',
-		// glyphs: {
-		// 	major1: PaladinMajorGlyph.GlyphOfHolyLight,
-		// 	major2: PaladinMajorGlyph.GlyphOfSealOfWisdom,
-		// 	major3: PaladinMajorGlyph.GlyphOfBeaconOfLight,
-		// 	minor2: PaladinMinorGlyph.GlyphOfLayOnHands,
-		// 	minor1: PaladinMinorGlyph.GlyphOfSenseUndead,
-		// 	minor3: PaladinMinorGlyph.GlyphOfBlessingOfKings,
-		// },
+		talentsString: '03332001222131312321-321',
+		glyphs: {
+			prime1: PaladinPrimeGlyph.GlyphOfHolyShock,
+			prime2: PaladinPrimeGlyph.GlyphOfSealOfInsight,
+			prime3: PaladinPrimeGlyph.GlyphOfWordOfGlory,
+			major1: PaladinMajorGlyph.GlyphOfDivinePlea,
+			major2: PaladinMajorGlyph.GlyphOfDivinity,
+			major3: PaladinMajorGlyph.GlyphOfLightOfDawn,
+		},
 	}),
 };
 
@@ -40,11 +50,12 @@ export const DefaultOptions = HolyPaladinOptions.create({
 	classOptions: {
 		aura: PaladinAura.DevotionAura,
 		judgement: PaladinJudgement.NoJudgement,
+		seal: PaladinSeal.Insight,
 	},
 });
 
 export const DefaultConsumes = Consumes.create({
-	defaultPotion: Potions.MythicalManaPotion,
-	flask: Flask.FlaskOfTheFrostWyrm,
-	food: Food.FoodFishFeast,
+	defaultPotion: Potions.PotionOfConcentration,
+	flask: Flask.FlaskOfTheDraconicMind,
+	food: Food.FoodSeveredSagefish,
 });
@@ -4,7 +4,7 @@ import {
 	PaladinAura as PaladinAura,
 	PaladinJudgement as PaladinJudgement,
 	PaladinMajorGlyph,
-	PaladinMinorGlyph,
+	PaladinPrimeGlyph,
 	ProtectionPaladin_Options as ProtectionPaladinOptions,
 } from '../../core/proto/paladin.js';
 import { SavedTalents } from '../../core/proto/ui.js';
@@ -27,7 +27,7 @@ export const P2_PRESET = PresetUtils.makePresetGear('P2 Preset', P2Gear);
 export const P3_PRESET = PresetUtils.makePresetGear('P3 Preset', P3Gear);
 export const P4_PRESET = PresetUtils.makePresetGear('P4 Preset', P4Gear);
 
-export const ROTATION_DEFAULT = PresetUtils.makePresetAPLRotation('Default (969)', DefaultApl);
+export const ROTATION_DEFAULT = PresetUtils.makePresetAPLRotation('Default', DefaultApl);
 
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/cata/talent-calc and copy the numbers in the url.
@@ -35,28 +35,28 @@ export const ROTATION_DEFAULT = PresetUtils.makePresetAPLRotation('Default (969)
 export const GenericAoeTalents = {
 	name: 'Baseline Example',
 	data: SavedTalents.create({
-		// talentsString: '-05005135200132311333312321-511302012003',
-		// glyphs: {
-		// 	major1: PaladinMajorGlyph.GlyphOfSealOfVengeance,
-		// 	major2: PaladinMajorGlyph.GlyphOfRighteousDefense,
-		// 	major3: PaladinMajorGlyph.GlyphOfDivinePlea,
-		// 	minor1: PaladinMinorGlyph.GlyphOfSenseUndead,
-		// 	minor2: PaladinMinorGlyph.GlyphOfLayOnHands,
-		// 	minor3: PaladinMinorGlyph.GlyphOfBlessingOfKings,
-		// },
+		talentsString: '-32023013122121101231-032',
+		glyphs: {
+			prime1: PaladinPrimeGlyph.GlyphOfShieldOfTheRighteous,
+			prime2: PaladinPrimeGlyph.GlyphOfCrusaderStrike,
+			prime3: PaladinPrimeGlyph.GlyphOfSealOfTruth,
+			major1: PaladinMajorGlyph.GlyphOfConsecration,
+			major2: PaladinMajorGlyph.GlyphOfTheAsceticCrusader,
+			major3: PaladinMajorGlyph.GlyphOfFocusedShield,
+		},
 	}),
 };
 
 export const DefaultOptions = ProtectionPaladinOptions.create({
 	classOptions: {
-		aura: PaladinAura.RetributionAura,
+		aura: PaladinAura.DevotionAura,
 		judgement: PaladinJudgement.JudgementOfWisdom,
 	},
 });
 
 export const DefaultConsumes = Consumes.create({
 	flask: Flask.FlaskOfStoneblood,
-	food: Food.FoodDragonfinFilet,
+	food: Food.FoodBeerBasedCrocolisk,
 	defaultPotion: Potions.EarthenPotion,
 	prepopPotion: Potions.EarthenPotion,
 });
@@ -7,6 +7,7 @@ import { FrostDeathKnightSimUI } from '../death_knight/frost/sim';
 import { UnholyDeathKnightSimUI } from '../death_knight/unholy/sim';
 import { BalanceDruidSimUI } from '../druid/balance/sim.js';
 import { FeralDruidSimUI } from '../druid/feral/sim.js';
+import { FeralTankDruidSimUI } from '../druid/feral_tank/sim.js';
 import { RestorationDruidSimUI } from '../druid/restoration/sim.js';
 import { BeastMasteryHunterSimUI } from '../hunter/beast_mastery/sim';
 import { MarksmanshipHunterSimUI } from '../hunter/marksmanship/sim';
@@ -41,6 +42,7 @@ export const specSimFactories: Partial<Record<Spec, (parentElem: HTMLElement, pl
 	// Druid
 	[Spec.SpecBalanceDruid]: (parentElem: HTMLElement, player: Player<any>) => new BalanceDruidSimUI(parentElem, player),
 	[Spec.SpecFeralDruid]: (parentElem: HTMLElement, player: Player<any>) => new FeralDruidSimUI(parentElem, player),
+	[Spec.SpecFeralTankDruid]: (parentElem: HTMLElement, player: Player<any>) => new FeralTankDruidSimUI(parentElem, player),
 	[Spec.SpecRestorationDruid]: (parentElem: HTMLElement, player: Player<any>) => new RestorationDruidSimUI(parentElem, player),
 	// Hunter
 	[Spec.SpecBeastMasteryHunter]: (parentElem: HTMLElement, player: Player<any>) => new BeastMasteryHunterSimUI(parentElem, player),